@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"sync"
 	"time"
 
 	"lmm/api/clock"
@@ -15,3 +16,38 @@ func (c testClock) Now() time.Time {
 func init() {
 	clock.DefaultClock = &testClock{}
 }
+
+// Clock is a clock.Clock which only goes forward when told to
+type Clock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// NewClock creates a new Clock stopped at now
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the time c stopped at
+func (c *Clock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// Set stops c at now
+func (c *Clock) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = now
+}
+
+// Add moves c forward by d
+func (c *Clock) Add(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+}
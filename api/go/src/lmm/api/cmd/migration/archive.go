@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"lmm/api/clock"
+	dsUtil "lmm/api/pkg/datastore"
+	archiveSource "lmm/api/service/archive/port/adapter/source"
+	archiveApp "lmm/api/service/archive/usecase"
+	articleStorage "lmm/api/service/article/port/adapter/persistence"
+	assetStorage "lmm/api/service/asset/port/adapter/persistence"
+	assetApp "lmm/api/service/asset/usecase"
+	commentStorage "lmm/api/service/comment/port/adapter/persistence"
+	userStorage "lmm/api/service/user/port/adapter/persistence"
+
+	"cloud.google.com/go/datastore"
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+)
+
+// exportArchive writes the whole site into an archive file
+func exportArchive(c context.Context, dataStore *datastore.Client, args []string) error {
+	flags := flag.NewFlagSet("export-archive", flag.ExitOnError)
+	format := flags.String("format", string(archiveApp.FormatTarGz), "archive format, zip or tar.gz")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export-archive [-format zip|tar.gz] <file>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	archiveFormat, err := archiveApp.FormatFromString(*format)
+	if err != nil {
+		return err
+	}
+
+	assetUsecase, uploader, closeAsset, err := newAssetUsecase(c, dataStore)
+	if err != nil {
+		return err
+	}
+	defer closeAsset()
+
+	articleRepo := articleStorage.NewArticleDataStore(dataStore)
+
+	app := archiveApp.New(clock.DefaultClock,
+		archiveSource.NewUserSource(userStorage.NewUserDataStore(dataStore)),
+		archiveSource.NewArticleSource(articleRepo),
+		archiveSource.NewSeriesSource(articleStorage.NewSeriesDataStore(dataStore)),
+		archiveSource.NewCommentSource(commentStorage.NewCommentDataStore(dataStore)),
+		archiveSource.NewPhotoSource(assetUsecase, uploader),
+	)
+
+	f, err := os.Create(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := app.Export(c, f, archiveFormat); err != nil {
+		f.Close()
+		os.Remove(flags.Arg(0))
+		return errors.Wrap(err, "failed to export")
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	log.Printf("exported to %s", flags.Arg(0))
+
+	return nil
+}
+
+// importArchive restores the whole site from an exported archive into an empty project,
+// keeping the ids, authors and timestamps. Nothing is imported if anything in the archive is invalid
+func importArchive(c context.Context, dataStore *datastore.Client, args []string) error {
+	flags := flag.NewFlagSet("import-archive", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "only validate the archive")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s import-archive [-dry-run] <file>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	archive, err := readArchive(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	site, err := newRestoredSite(archive)
+	if err != nil {
+		return errors.Wrap(err, "invalid archive")
+	}
+
+	if *dryRun {
+		log.Printf("%d users, %d articles, %d revisions, %d series, %d comments and %d photos are valid",
+			len(site.users), len(site.articles), len(site.revisions), len(site.series), len(site.comments), len(site.photos))
+		return nil
+	}
+
+	if err := checkEmptyProject(c, dataStore); err != nil {
+		return err
+	}
+
+	assetUsecase, _, closeAsset, err := newAssetUsecase(c, dataStore)
+	if err != nil {
+		return err
+	}
+	defer closeAsset()
+
+	if err := site.restore(c, dataStore, assetUsecase); err != nil {
+		return err
+	}
+
+	log.Printf("site restored, restart the api to rebuild the search index")
+
+	return nil
+}
+
+// checkEmptyProject fails if the project has any user, article or asset,
+// restored entities keep their ids which may conflict with existing ones
+func checkEmptyProject(c context.Context, dataStore *datastore.Client) error {
+	for _, kind := range []string{dsUtil.UserKind, dsUtil.ArticleKind, dsUtil.AssetKind} {
+		count, err := dataStore.Count(c, datastore.NewQuery(kind).KeysOnly().Limit(1))
+		if err != nil {
+			return errors.Wrapf(err, "failed to count %s", kind)
+		}
+		if count > 0 {
+			return errors.Errorf("the project has %s entities, archives can only be imported into an empty project", kind)
+		}
+	}
+	return nil
+}
+
+func readArchive(name string) (*archiveApp.Archive, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := archiveApp.Read(f, info.Size())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", name)
+	}
+	return archive, nil
+}
+
+// newAssetUsecase connects to the asset bucket, the returned func closes the connection
+func newAssetUsecase(c context.Context, dataStore *datastore.Client) (*assetApp.Usecase, *assetStorage.GCSUploader, func(), error) {
+	if config.AssetBucketName == "" {
+		return nil, nil, nil, errors.New("ASSET_BUCKET_NAME required")
+	}
+
+	gsClient, err := storage.NewClient(c)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	bucket := gsClient.Bucket(config.AssetBucketName)
+
+	assetRepo, err := assetStorage.NewAssetDataStore(c, dataStore, bucket)
+	if err != nil {
+		gsClient.Close()
+		return nil, nil, nil, err
+	}
+
+	uploader, err := assetStorage.NewGCSUploader(c, bucket)
+	if err != nil {
+		gsClient.Close()
+		return nil, nil, nil, err
+	}
+
+	return assetApp.New(assetRepo, uploader, assetRepo), uploader, func() { gsClient.Close() }, nil
+}
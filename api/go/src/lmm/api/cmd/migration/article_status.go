@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	dsUtil "lmm/api/pkg/datastore"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+// backfillArticleStatus marks articles saved before publication state was introduced as published
+func backfillArticleStatus(c context.Context, dataStore *datastore.Client, _ []string) error {
+	keys, err := dataStore.GetAll(c, datastore.NewQuery(dsUtil.ArticleKind).KeysOnly(), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get article keys")
+	}
+
+	for _, key := range keys {
+		if _, err := dataStore.RunInTransaction(c, func(tx *datastore.Transaction) error {
+			return backfillOneArticleStatus(c, dataStore, tx, key)
+		}); err != nil {
+			return errors.Wrapf(err, "failed to backfill article %s", key.Encode())
+		}
+	}
+
+	return nil
+}
+
+func backfillOneArticleStatus(c context.Context, dataStore *datastore.Client, tx *datastore.Transaction, key *datastore.Key) error {
+	var article datastore.PropertyList
+	if err := tx.Get(key, &article); err != nil {
+		return err
+	}
+
+	if findProperty(article, "Status") != nil {
+		return nil
+	}
+
+	var publishedAt time.Time
+	if p := findProperty(article, "CreatedAt"); p != nil {
+		publishedAt, _ = p.Value.(time.Time)
+	}
+
+	article = append(article,
+		datastore.Property{Name: "Status", Value: "published"},
+		datastore.Property{Name: "PublishedAt", Value: publishedAt, NoIndex: true},
+	)
+	if _, err := tx.Put(key, &article); err != nil {
+		return err
+	}
+
+	q := datastore.NewQuery(dsUtil.ArticleTagKind).Ancestor(key).Transaction(tx)
+
+	var tags []datastore.PropertyList
+	tagKeys, err := dataStore.GetAll(c, q, &tags)
+	if err != nil {
+		return err
+	}
+
+	for i := range tags {
+		if findProperty(tags[i], "Status") == nil {
+			tags[i] = append(tags[i], datastore.Property{Name: "Status", Value: "published"})
+		}
+	}
+
+	if _, err := tx.PutMulti(tagKeys, tags); err != nil {
+		return err
+	}
+
+	log.Printf("article %s marked as published", key.Encode())
+
+	return nil
+}
+
+func findProperty(props datastore.PropertyList, name string) *datastore.Property {
+	for i := range props {
+		if props[i].Name == name {
+			return &props[i]
+		}
+	}
+	return nil
+}
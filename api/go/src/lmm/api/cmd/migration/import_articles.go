@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"lmm/api/clock"
+	"lmm/api/pkg/transaction"
+	archiveApp "lmm/api/service/archive/usecase"
+	articleApp "lmm/api/service/article/application"
+	"lmm/api/service/article/application/command"
+	articleDomain "lmm/api/service/article/domain"
+	articleModel "lmm/api/service/article/domain/model"
+	articleStorage "lmm/api/service/article/port/adapter/persistence"
+	userStorage "lmm/api/service/user/port/adapter/persistence"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+// markdownArticle is an article read from a Markdown file
+type markdownArticle struct {
+	path      string
+	matter    *archiveApp.FrontMatter
+	body      string
+	createdAt time.Time
+
+	// slug is the link name from front matter, or derived from the file name if not given,
+	// so that importing the same file again finds the imported article
+	slug string
+
+	// imported is true if the article has been imported by a previous run
+	imported bool
+
+	// parseError is reported on validation
+	parseError error
+}
+
+// importArticles posts articles from Markdown files with front matter in a directory by the author,
+// keeping their original posting dates. Nothing is imported if any file is invalid,
+// and files imported by a previous run are skipped
+func importArticles(c context.Context, dataStore *datastore.Client, args []string) error {
+	flags := flag.NewFlagSet("import-articles", flag.ExitOnError)
+	author := flags.String("author", "", "name of the user who posts the imported articles")
+	dryRun := flags.Bool("dry-run", false, "only validate the files")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s import-articles [-dry-run] -author <user> <directory>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 || (*author == "" && !*dryRun) {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	articles, err := loadMarkdownArticles(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	articleRepo := articleStorage.NewArticleDataStore(dataStore)
+
+	failures := validateMarkdownArticles(c, articleRepo, articles)
+	for _, article := range articles {
+		if err, failed := failures[article.path]; failed {
+			log.Printf("%s: %s", article.path, err)
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("%d of %d files are invalid", len(failures), len(articles))
+	}
+
+	if *dryRun {
+		log.Printf("%d files are valid", len(articles))
+		return nil
+	}
+
+	authorID, err := findUserID(c, dataStore, *author)
+	if err != nil {
+		return err
+	}
+
+	return postMarkdownArticles(c, articleRepo, authorID, articles)
+}
+
+// postMarkdownArticles posts the validated articles by the author, older articles first
+func postMarkdownArticles(c context.Context, articleRepo *articleStorage.ArticleDataStore, authorID int64, articles []*markdownArticle) error {
+	app := articleApp.NewArticleCommandService(clock.DefaultClock, articleRepo, &importedArticleEventPublisher{}, articleRepo)
+
+	// older articles are imported first so that they are ordered as they were
+	sort.SliceStable(articles, func(i, j int) bool {
+		return articles[i].createdAt.Before(articles[j].createdAt)
+	})
+
+	imported := 0
+	for _, article := range articles {
+		if article.imported {
+			log.Printf("%s skipped, already imported as %s", article.path, article.slug)
+			continue
+		}
+
+		id, err := app.PostNewArticle(c, command.PostArticle{
+			AuthorID:  authorID,
+			LinkName:  article.slug,
+			Title:     article.matter.Title,
+			Body:      article.body,
+			Tags:      article.matter.Tags,
+			Status:    article.matter.ArticleStatus(),
+			CreatedAt: article.createdAt,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to import %s, %d articles have been imported", article.path, imported)
+		}
+		imported++
+
+		log.Printf("%s imported as %s", article.path, id.String())
+	}
+
+	log.Printf("%d articles imported, %d skipped, restart the api to rebuild the search index", imported, len(articles)-imported)
+
+	return nil
+}
+
+// loadMarkdownArticles reads all *.md files in dir, files which can't be parsed are loaded with the parse error
+func loadMarkdownArticles(dir string) ([]*markdownArticle, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list markdown files")
+	}
+	if len(paths) == 0 {
+		return nil, errors.Errorf("no markdown files in %s", dir)
+	}
+
+	articles := make([]*markdownArticle, len(paths), len(paths))
+	for i, path := range paths {
+		articles[i] = &markdownArticle{path: path}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		articles[i].matter, articles[i].body, articles[i].parseError = archiveApp.ParseMarkdownArticle(f)
+		f.Close()
+	}
+
+	return articles, nil
+}
+
+// validateMarkdownArticles checks the articles as posting would do, returns errors by path.
+// An article is marked as imported if an article with the same slug and title exists
+func validateMarkdownArticles(c context.Context, articleRepo *articleStorage.ArticleDataStore, articles []*markdownArticle) map[string]error {
+	failures := make(map[string]error)
+	slugs := make(map[string]string)
+
+	for _, article := range articles {
+		if article.parseError != nil {
+			failures[article.path] = article.parseError
+			continue
+		}
+
+		createdAt, err := article.matter.CreatedAt()
+		if err != nil {
+			failures[article.path] = err
+			continue
+		}
+		article.createdAt = createdAt
+
+		if _, err := articleModel.NewContent(article.matter.Title, article.body, article.matter.Tags); err != nil {
+			failures[article.path] = err
+			continue
+		}
+
+		if status := article.matter.ArticleStatus(); status != "" {
+			if _, err := articleModel.NewArticleStatus(status); err != nil {
+				failures[article.path] = errors.Wrap(err, status)
+				continue
+			}
+		}
+
+		slug := article.matter.Slug
+		if slug == "" {
+			slug = articleModel.LinkNameFromTitle(strings.TrimSuffix(filepath.Base(article.path), filepath.Ext(article.path)))
+		}
+		article.slug = slug
+
+		if err := articleModel.ValidateLinkName(slug); err != nil {
+			failures[article.path] = errors.Wrap(err, slug)
+			continue
+		}
+
+		if other, ok := slugs[slug]; ok {
+			failures[article.path] = errors.Errorf("slug %s is also used by %s", slug, other)
+			continue
+		}
+		slugs[slug] = article.path
+
+		var found *articleModel.Article
+		err = articleRepo.RunInTransaction(c, func(tx transaction.Transaction) error {
+			found, err = articleRepo.FindByLinkName(tx, slug)
+			return err
+		}, &transaction.Option{ReadOnly: true})
+
+		switch errors.Cause(err) {
+		case articleDomain.ErrNoSuchArticle:
+		case nil:
+			if found.Content().Text().Title() == article.matter.Title {
+				article.imported = true
+				continue
+			}
+			failures[article.path] = errors.Wrap(articleDomain.ErrArticleLinkNameAlreadyUsed, slug)
+		default:
+			failures[article.path] = errors.Wrap(err, "failed to check slug")
+		}
+	}
+
+	return failures
+}
+
+func findUserID(c context.Context, dataStore *datastore.Client, name string) (int64, error) {
+	userRepo := userStorage.NewUserDataStore(dataStore)
+
+	var id int64
+	err := userRepo.RunInTransaction(c, func(tx transaction.Transaction) error {
+		user, err := userRepo.FindByName(tx, strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		id = int64(user.ID())
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find user %s", name)
+	}
+	return id, nil
+}
+
+// importedArticleEventPublisher notifies nothing since imported articles are not new to readers
+type importedArticleEventPublisher struct{}
+
+func (pub *importedArticleEventPublisher) NotifyArticlePublished(c context.Context, article *articleModel.Article) error {
+	return nil
+}
+
+func (pub *importedArticleEventPublisher) NotifyArticleDeleted(c context.Context, article *articleModel.Article) error {
+	return nil
+}
@@ -1,4 +1,70 @@
 package main
 
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"cloud.google.com/go/datastore"
+	"github.com/proproto/goenv"
+)
+
+var config = struct {
+	DataStorePorjectID string `env:"DATASTORE_PROJECT_ID,required"`
+
+	// AssetBucketName is only required by migrations of photos
+	AssetBucketName string `env:"ASSET_BUCKET_NAME"`
+}{}
+
+// migration runs with the arguments after its name
+type migration func(c context.Context, dataStore *datastore.Client, args []string) error
+
+var migrations = map[string]migration{
+	"backfill-article-status": backfillArticleStatus,
+	"export-archive":          exportArchive,
+	"import-archive":          importArchive,
+	"import-articles":         importArticles,
+	"reindex-users":           reindexUserRegisteredAt,
+	"repair-tag-stats":        repairTagStats,
+}
+
+func usage() {
+	names := make([]string, 0, len(migrations))
+	for name := range migrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s <command> [arguments]\n\nCommands:\n", os.Args[0])
+	for _, name := range names {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s\n", name)
+	}
+}
+
 func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	run, ok := migrations[flag.Arg(0)]
+	if !ok {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	goenv.MustBind(&config)
+
+	c := context.Background()
+
+	dataStore, err := datastore.NewClient(c, config.DataStorePorjectID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dataStore.Close()
+
+	if err := run(c, dataStore, flag.Args()[1:]); err != nil {
+		log.Fatal(err)
+	}
 }
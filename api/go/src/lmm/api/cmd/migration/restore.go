@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+
+	"lmm/api/pkg/transaction"
+	archiveApp "lmm/api/service/archive/usecase"
+	articleModel "lmm/api/service/article/domain/model"
+	articleStorage "lmm/api/service/article/port/adapter/persistence"
+	assetApp "lmm/api/service/asset/usecase"
+	commentModel "lmm/api/service/comment/domain/model"
+	commentStorage "lmm/api/service/comment/port/adapter/persistence"
+	userModel "lmm/api/service/user/domain/model"
+	userStorage "lmm/api/service/user/port/adapter/persistence"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+// restoredPhoto is a photo to restore with its file
+type restoredPhoto struct {
+	asset *assetApp.Asset
+	tags  []string
+	data  []byte
+}
+
+// restoredSite is everything in an archive built into models, which are saved with their ids
+type restoredSite struct {
+	users     []*userModel.User
+	photos    []*restoredPhoto
+	articles  []*articleModel.Article
+	revisions map[articleModel.ArticleID][]*articleModel.ArticleRevision
+	series    []*articleModel.Series
+	comments  []*commentModel.Comment
+}
+
+// newRestoredSite validates everything in archive, articles, series and photos must be owned by archived users
+func newRestoredSite(archive *archiveApp.Archive) (*restoredSite, error) {
+	site := &restoredSite{revisions: make(map[articleModel.ArticleID][]*articleModel.ArticleRevision)}
+
+	userIDs := make(map[int64]bool)
+	for _, data := range archive.Users {
+		user, err := userModel.NewUser(userModel.UserID(data.ID), data.Name, data.Email, data.Password, data.Token,
+			userModel.RoleFromString(data.Role), data.RegisteredAt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "user %s", data.Name)
+		}
+
+		profile, err := userModel.NewProfile(data.DisplayName, data.Bio, data.AvatarAssetID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "user %s", data.Name)
+		}
+		user.ChangeProfile(profile)
+
+		site.users = append(site.users, user)
+		userIDs[data.ID] = true
+	}
+
+	for _, data := range archive.Photos {
+		if !userIDs[data.UserID] {
+			return nil, errors.Errorf("photo %s: no such user %d", data.Filename, data.UserID)
+		}
+		site.photos = append(site.photos, &restoredPhoto{
+			asset: &assetApp.Asset{
+				ID:         assetApp.NewAssetID(data.ID),
+				UserID:     data.UserID,
+				Filename:   data.Filename,
+				UploadedAt: data.UploadedAt,
+			},
+			tags: data.Tags,
+			data: archive.PhotoFile(data),
+		})
+	}
+
+	articleIDs := make(map[articleModel.ArticleID]bool)
+	for _, data := range archive.Articles {
+		article, err := restoredArticle(data)
+		if err != nil {
+			return nil, errors.Wrap(err, data.File)
+		}
+		if !userIDs[article.Author().ID()] {
+			return nil, errors.Errorf("%s: no such user %d", data.File, article.Author().ID())
+		}
+
+		site.articles = append(site.articles, article)
+		articleIDs[*article.ID()] = true
+	}
+
+	for _, data := range archive.Revisions {
+		articleID := articleModel.NewArticleID(data.ArticleID)
+		if !articleIDs[*articleID] {
+			return nil, errors.Errorf("revision %d: no such article %s", data.Number, data.ArticleID)
+		}
+
+		content, err := articleModel.NewContent(data.Title, data.Body, data.Tags)
+		if err != nil {
+			return nil, errors.Wrapf(err, "revision %d of %s", data.Number, data.ArticleID)
+		}
+
+		site.revisions[*articleID] = append(site.revisions[*articleID],
+			articleModel.NewArticleRevision(articleID, data.Number, content, articleModel.NewAuthor(data.EditorID), data.CreatedAt))
+	}
+
+	for _, data := range archive.Series {
+		if !userIDs[data.OwnerID] {
+			return nil, errors.Errorf("series %s: no such user %d", data.Title, data.OwnerID)
+		}
+
+		articles := make([]*articleModel.ArticleID, len(data.Articles), len(data.Articles))
+		for i, id := range data.Articles {
+			articles[i] = articleModel.NewArticleID(id)
+			if !articleIDs[*articles[i]] {
+				return nil, errors.Errorf("series %s: no such article %s", data.Title, id)
+			}
+		}
+
+		series, err := articleModel.NewSeries(articleModel.NewSeriesID(data.ID), articleModel.NewAuthor(data.OwnerID),
+			data.Title, data.Description, articles, data.CreatedAt, data.LastModified)
+		if err != nil {
+			return nil, errors.Wrapf(err, "series %s", data.Title)
+		}
+		site.series = append(site.series, series)
+	}
+
+	commentIDs := make(map[string]bool)
+	for _, data := range archive.Comments {
+		commentIDs[data.ID] = true
+	}
+	for _, data := range archive.Comments {
+		comment, err := restoredComment(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "comment %s", data.ID)
+		}
+		if !articleIDs[articleModel.ArticleID(data.ArticleID)] {
+			return nil, errors.Errorf("comment %s: no such article %s", data.ID, data.ArticleID)
+		}
+		if data.ParentID != "" && !commentIDs[data.ParentID] {
+			return nil, errors.Errorf("comment %s: no such comment %s", data.ID, data.ParentID)
+		}
+		site.comments = append(site.comments, comment)
+	}
+
+	return site, nil
+}
+
+// restoredArticle builds the article as it was, keeping the status of scheduled articles
+func restoredArticle(data *archiveApp.ArchivedArticle) (*articleModel.Article, error) {
+	if data.ID == "" {
+		return nil, errors.New("id required")
+	}
+
+	createdAt, err := data.Matter.CreatedAt()
+	if err != nil {
+		return nil, err
+	}
+
+	lastModified, err := data.Matter.UpdatedAt()
+	if err != nil {
+		return nil, err
+	}
+
+	publishedAt, err := data.Matter.PublishedAt()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := articleModel.NewArticleStatus(data.Matter.ArticleStatus())
+	if err != nil {
+		return nil, errors.Wrap(err, data.Matter.ArticleStatus())
+	}
+	if status == articleModel.ArticleStatusScheduled && publishedAt.IsZero() {
+		return nil, errors.New("published date required for scheduled articles")
+	}
+
+	content, err := articleModel.NewContent(data.Matter.Title, data.Body, data.Matter.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	article := articleModel.NewArticle(articleModel.NewArticleID(data.ID), articleModel.NewAuthor(data.Matter.Author),
+		content, status, data.Version, createdAt, lastModified, publishedAt)
+
+	if data.Matter.Slug != "" {
+		if err := article.ChangeLinkName(data.Matter.Slug); err != nil {
+			return nil, errors.Wrap(err, data.Matter.Slug)
+		}
+	}
+
+	return article, nil
+}
+
+func restoredComment(data *archiveApp.Comment) (*commentModel.Comment, error) {
+	commenter, err := commentModel.NewCommenter(data.UserID, data.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := commentModel.NewCommentStatus(data.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *commentModel.CommentID
+	if data.ParentID != "" {
+		parentID = commentModel.NewCommentID(data.ParentID)
+	}
+
+	return commentModel.NewComment(commentModel.NewCommentID(data.ID), data.ArticleID, parentID, commenter,
+		data.Body, status, data.CreatedAt, data.ModeratedAt)
+}
+
+// restore saves users, photos with their files, articles with their revisions, series and comments in order
+func (site *restoredSite) restore(c context.Context, dataStore *datastore.Client, assetUsecase *assetApp.Usecase) error {
+	userRepo := userStorage.NewUserDataStore(dataStore)
+	for i, user := range site.users {
+		if err := userRepo.RunInTransaction(c, func(tx transaction.Transaction) error {
+			return userRepo.Save(tx, user)
+		}, nil); err != nil {
+			return errors.Wrapf(err, "failed to restore user %s, %d users have been restored", user.Name(), i)
+		}
+	}
+	log.Printf("%d users restored", len(site.users))
+
+	for i, photo := range site.photos {
+		if err := assetUsecase.RestorePhoto(c, photo.asset, photo.tags, ioutil.NopCloser(bytes.NewReader(photo.data))); err != nil {
+			return errors.Wrapf(err, "failed to restore photo %s, %d photos have been restored", photo.asset.Filename, i)
+		}
+	}
+	log.Printf("%d photos restored", len(site.photos))
+
+	articleRepo := articleStorage.NewArticleDataStore(dataStore)
+	for i, article := range site.articles {
+		if err := articleRepo.RunInTransaction(c, func(tx transaction.Transaction) error {
+			if err := articleRepo.Save(tx, article); err != nil {
+				return err
+			}
+			for _, revision := range site.revisions[*article.ID()] {
+				if err := articleRepo.SaveRevision(tx, revision); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil); err != nil {
+			return errors.Wrapf(err, "failed to restore article %s, %d articles have been restored", article.ID().String(), i)
+		}
+	}
+	log.Printf("%d articles restored", len(site.articles))
+
+	seriesRepo := articleStorage.NewSeriesDataStore(dataStore)
+	for i, series := range site.series {
+		if err := articleRepo.RunInTransaction(c, func(tx transaction.Transaction) error {
+			return seriesRepo.Save(tx, series)
+		}, nil); err != nil {
+			return errors.Wrapf(err, "failed to restore series %s, %d series have been restored", series.Title(), i)
+		}
+	}
+	log.Printf("%d series restored", len(site.series))
+
+	commentRepo := commentStorage.NewCommentDataStore(dataStore)
+	for i, comment := range site.comments {
+		if err := commentRepo.RunInTransaction(c, func(tx transaction.Transaction) error {
+			return commentRepo.Save(tx, comment)
+		}, nil); err != nil {
+			return errors.Wrapf(err, "failed to restore comment %s, %d comments have been restored", comment.ID().String(), i)
+		}
+	}
+	log.Printf("%d comments restored", len(site.comments))
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	dsUtil "lmm/api/pkg/datastore"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+// datastoreBatchSize is the maximum number of entities written in a call
+var datastoreBatchSize = 500
+
+type tagStat struct {
+	Count int64 `datastore:"Count,noindex"`
+}
+
+// repairTagStats recomputes counters of published articles by tag from article tags.
+// Articles saved while repairing may be miscounted, run it again in that case
+func repairTagStats(c context.Context, dataStore *datastore.Client, _ []string) error {
+	q := datastore.NewQuery(dsUtil.ArticleTagKind).Filter("Status =", "published").Project("Name")
+
+	var tags []struct {
+		Name string `datastore:"Name"`
+	}
+	if _, err := dataStore.GetAll(c, q, &tags); err != nil {
+		return errors.Wrap(err, "failed to get published article tags")
+	}
+
+	counts := make(map[string]int64)
+	for _, tag := range tags {
+		counts[tag.Name]++
+	}
+
+	staleKeys, err := dataStore.GetAll(c, datastore.NewQuery(dsUtil.ArticleTagStatKind).KeysOnly(), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tag stat keys")
+	}
+
+	deleteKeys := make([]*datastore.Key, 0)
+	for _, key := range staleKeys {
+		if counts[key.Name] == 0 {
+			deleteKeys = append(deleteKeys, key)
+		}
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := make([]*datastore.Key, len(names), len(names))
+	stats := make([]*tagStat, len(names), len(names))
+	for i, name := range names {
+		keys[i] = datastore.NameKey(dsUtil.ArticleTagStatKind, name, nil)
+		stats[i] = &tagStat{Count: counts[name]}
+	}
+
+	for begin := 0; begin < len(keys); begin += datastoreBatchSize {
+		end := begin + datastoreBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if _, err := dataStore.PutMulti(c, keys[begin:end], stats[begin:end]); err != nil {
+			return errors.Wrap(err, "failed to put tag stats")
+		}
+	}
+
+	for begin := 0; begin < len(deleteKeys); begin += datastoreBatchSize {
+		end := begin + datastoreBatchSize
+		if end > len(deleteKeys) {
+			end = len(deleteKeys)
+		}
+		if err := dataStore.DeleteMulti(c, deleteKeys[begin:end]); err != nil {
+			return errors.Wrap(err, "failed to delete stale tag stats")
+		}
+	}
+
+	for _, name := range names {
+		log.Printf("tag %s: %d articles", name, counts[name])
+	}
+	log.Printf("%d tag stats repaired, %d stale ones deleted", len(names), len(deleteKeys))
+
+	return nil
+}
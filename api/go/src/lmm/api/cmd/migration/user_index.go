@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+const userKind = "User"
+
+// reindexUserRegisteredAt indexes RegisteredAt of users saved while it was not indexed,
+// otherwise they are missing from users listed in order of registered dates
+func reindexUserRegisteredAt(c context.Context, dataStore *datastore.Client, _ []string) error {
+	keys, err := dataStore.GetAll(c, datastore.NewQuery(userKind).KeysOnly(), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user keys")
+	}
+
+	for _, key := range keys {
+		if _, err := dataStore.RunInTransaction(c, func(tx *datastore.Transaction) error {
+			var user datastore.PropertyList
+			if err := tx.Get(key, &user); err != nil {
+				return err
+			}
+
+			p := findProperty(user, "RegisteredAt")
+			if p == nil || !p.NoIndex {
+				return nil
+			}
+			p.NoIndex = false
+
+			_, err := tx.Put(key, &user)
+			return err
+		}); err != nil {
+			return errors.Wrapf(err, "failed to reindex user %d", key.ID)
+		}
+	}
+
+	log.Printf("%d users checked", len(keys))
+
+	return nil
+}
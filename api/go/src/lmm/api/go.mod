@@ -10,6 +10,7 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/proproto/goenv v0.2.0
 	github.com/stretchr/testify v1.6.0
+	github.com/yuin/goldmark v1.4.12
 	go.uber.org/zap v1.15.0
 	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
@@ -17,4 +18,5 @@ require (
 	google.golang.org/appengine v1.6.6
 	google.golang.org/grpc v1.31.1
 	gopkg.in/go-playground/validator.v8 v8.18.2
+	gopkg.in/yaml.v2 v2.2.8
 )
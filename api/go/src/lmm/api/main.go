@@ -2,9 +2,14 @@ package main
 
 import (
 	"context"
+	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"lmm/api/clock"
 	"lmm/api/pkg/http/middleware"
 	"lmm/api/pkg/pubsub"
 
@@ -23,13 +28,35 @@ import (
 	userUtil "lmm/api/service/user/port/adapter/service"
 
 	// article
+	articleApp "lmm/api/service/article/application"
+	articleMarkdown "lmm/api/service/article/port/adapter/markdown"
+	articleMessaging "lmm/api/service/article/port/adapter/messaging"
 	articleStorage "lmm/api/service/article/port/adapter/persistence"
 	articleUI "lmm/api/service/article/port/adapter/presentation"
+	articleSearch "lmm/api/service/article/port/adapter/search"
+	articleUtil "lmm/api/service/article/port/adapter/service"
+
+	// comment
+	commentApp "lmm/api/service/comment/application"
+	commentMessaging "lmm/api/service/comment/port/adapter/messaging"
+	commentStorage "lmm/api/service/comment/port/adapter/persistence"
+	commentUI "lmm/api/service/comment/port/adapter/presentation"
+	commentUtil "lmm/api/service/comment/port/adapter/service"
 
 	// asset
 	assetStore "lmm/api/service/asset/port/adapter/persistence"
 	assetUI "lmm/api/service/asset/port/adapter/presentation"
 	assetApp "lmm/api/service/asset/usecase"
+
+	// sitemap
+	sitemapUI "lmm/api/service/sitemap/port/adapter/presentation"
+	sitemapSource "lmm/api/service/sitemap/port/adapter/source"
+	sitemapApp "lmm/api/service/sitemap/usecase"
+
+	// archive
+	archiveUI "lmm/api/service/archive/port/adapter/presentation"
+	archiveSource "lmm/api/service/archive/port/adapter/source"
+	archiveApp "lmm/api/service/archive/usecase"
 )
 
 var (
@@ -39,13 +66,16 @@ var (
 )
 
 var config = struct {
-	APITokenKey        string        `env:"LMM_API_TOKEN_KEY,required"`
+	APITokenKey        string        `env:"LMM_API_TOKEN_KEY"`
+	APITokenKeys       string        `env:"LMM_API_TOKEN_KEYS,required"`
+	ArticleViewWindow  time.Duration `env:"LMM_ARTICLE_VIEW_WINDOW,default=30m"`
 	AuthExpire         time.Duration `env:"LMM_API_AUTH_EXPIRE,default=24h"`
 	AssetBucketName    string        `env:"ASSET_BUCKET_NAME,required"`
 	DataStorePorjectID string        `env:"DATASTORE_PROJECT_ID,required"`
 	Domain             string        `env:"LMM_DOMAIN"`
 	PubsubProjectID    string        `env:"PUBSUB_PROJECT_ID,required"`
 	ProjectID          string        `env:"GCP_PROJECT_ID"`
+	SitemapTTL         time.Duration `env:"LMM_SITEMAP_TTL,default=1h"`
 }{}
 
 func initialze(c context.Context) func() {
@@ -84,20 +114,71 @@ func main() {
 	defer close()
 
 	// user
+	userTokenKeys, err := userUtil.ParseJWTKeys(config.APITokenKeys)
+	if err != nil {
+		panic(err)
+	}
+	// LMM_API_TOKEN_KEY is kept only to accept tokens issued before JWT, unset it after they expired
+	userTokenService, err := userUtil.NewJWTTokenService(userTokenKeys, config.AuthExpire, config.APITokenKey)
+	if err != nil {
+		panic(err)
+	}
 	userRepo := userStorage.NewUserDataStore(dsClient)
 	userPub := userMessaging.NewUserEventPublisher(pubsubClient)
 	userAppService := userApp.NewService(
 		&userUtil.BcryptService{},
-		userUtil.NewCFBTokenService(config.APITokenKey, config.AuthExpire),
+		userTokenService,
 		userRepo,
 		userRepo,
+		userStorage.NewSessionDataStore(dsClient),
 		userPub,
 	)
 	userUI := userUI.NewGinRouterProvider(userAppService)
 
 	// article
 	articleRepo := articleStorage.NewArticleDataStore(dsClient)
-	articleUI := articleUI.NewGinRouterProvider(articleRepo, articleRepo, articleRepo)
+	articlePub := articleMessaging.NewArticleEventPublisher(pubsubClient)
+	articleRenderer := articleMarkdown.NewCachedRenderer(articleMarkdown.NewRenderer(), 256)
+	articleSearchIndex := articleSearch.NewIndex()
+	go func() {
+		if err := articleSearchIndex.Rebuild(context.Background(), articleRepo); err != nil {
+			log.Printf("failed to build article search index: %s", err)
+		}
+	}()
+	indexedArticleRepo := articleSearch.NewIndexedArticleRepository(articleRepo, articleRepo, articleSearchIndex)
+	articleViewCounter := articleApp.NewArticleViewCounter(clock.DefaultClock, articleStorage.NewArticleViewDataStore(dsClient), articleRepo, articleRepo, config.ArticleViewWindow)
+	viewCounterCtx, stopViewCounter := context.WithCancel(context.Background())
+	viewCounterStopped := make(chan struct{}, 1)
+	go func() {
+		articleViewCounter.Run(viewCounterCtx, 30*time.Second)
+		viewCounterStopped <- struct{}{}
+	}()
+	// buffered views are flushed before the instance is shut down
+	exitOnSignal(func() {
+		stopViewCounter()
+		<-viewCounterStopped
+	})
+	articleUI := articleUI.NewGinRouterProvider(articleRepo, indexedArticleRepo, articleStorage.NewSeriesDataStore(dsClient), articlePub, articleRenderer, articleSearchIndex, articleSearchIndex, articleUtil.NewAuthorAdapter(userAppService), indexedArticleRepo, clock.DefaultClock, articleViewCounter, siteURL())
+
+	articleScheduler := articleApp.NewArticlePublishScheduler(clock.DefaultClock, indexedArticleRepo, articlePub, indexedArticleRepo)
+	go articleScheduler.Run(context.Background(), time.Minute)
+
+	// comment
+	commentRepo := commentStorage.NewCommentDataStore(dsClient)
+	commentArticleService := commentUtil.NewArticleAdapter(
+		articleApp.NewArticleQueryService(articleRepo, articleRenderer, articleSearchIndex, articleSearchIndex, articleRepo),
+	)
+	commentPub := commentMessaging.NewCommentEventPublisher(pubsubClient)
+	commentUI := commentUI.NewGinRouterProvider(commentArticleService, commentRepo, commentPub, commentRepo)
+	// comments are owned by the comment service, which deletes them after their article is deleted
+	articleDeletedHandler := commentMessaging.NewArticleDeletedHandler(
+		commentApp.NewCommentCommandService(commentArticleService, commentRepo, commentPub, commentRepo),
+	)
+	go func() {
+		if err := pubsubClient.Subscribe(context.Background(), articleMessaging.TopicArticleDeleted, articleDeletedHandler); err != nil {
+			log.Printf("failed to subscribe %s: %s", articleMessaging.TopicArticleDeleted, err)
+		}
+	}()
 
 	// asset
 	assetRepo, err := assetStore.NewAssetDataStore(initCtx, dsClient, gsClient.Bucket(config.AssetBucketName))
@@ -111,13 +192,64 @@ func main() {
 	assetUsecase := assetApp.New(assetRepo, assetStorage, assetRepo)
 	assetUI := assetUI.NewGinRouterProvider(assetUsecase)
 
+	// sitemap
+	sitemapUsecase := sitemapApp.New(clock.DefaultClock, config.SitemapTTL,
+		sitemapSource.NewArticleURLSource(articleRepo, siteURL()),
+		sitemapSource.NewPhotoURLSource(assetUsecase, siteURL()),
+	)
+	sitemapUI := sitemapUI.NewGinRouterProvider(sitemapUsecase, apiURL(), config.SitemapTTL)
+
+	// archive
+	archiveUsecase := archiveApp.New(clock.DefaultClock,
+		archiveSource.NewUserSource(userRepo),
+		archiveSource.NewArticleSource(articleRepo),
+		archiveSource.NewSeriesSource(articleStorage.NewSeriesDataStore(dsClient)),
+		archiveSource.NewCommentSource(commentRepo),
+		archiveSource.NewPhotoSource(assetUsecase, assetStorage),
+	)
+	archiveUI := archiveUI.NewGinRouterProvider(archiveUsecase, clock.DefaultClock)
+
 	router := gin.New()
 	router.Use(middleware.CORS(config.Domain, config.ProjectID), userUI.BearerAuth)
 
 	userUI.Provide(router)
 	articleUI.Provide(router)
+	commentUI.Provide(router)
 	assetUI.Provide(router)
+	sitemapUI.Provide(router)
+	archiveUI.Provide(router)
 
 	http.Handle("/", router)
 	appengine.Main()
 }
+
+// exitOnSignal runs hooks when the instance is asked to shut down, then exits
+func exitOnSignal(hooks ...func()) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, os.Interrupt)
+
+	go func() {
+		sig := <-sigs
+		log.Printf("%s received, shutting down", sig)
+		for _, hook := range hooks {
+			hook()
+		}
+		os.Exit(0)
+	}()
+}
+
+// siteURL returns the url of the frontend
+func siteURL() string {
+	if config.Domain != "" {
+		return "https://" + config.Domain
+	}
+	return "https://" + config.ProjectID + ".appspot.com"
+}
+
+// apiURL returns the url of this api
+func apiURL() string {
+	if config.Domain != "" {
+		return "https://api." + config.Domain
+	}
+	return "https://api-dot-" + config.ProjectID + ".appspot.com"
+}
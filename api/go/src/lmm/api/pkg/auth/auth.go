@@ -3,11 +3,28 @@ package auth
 import (
 	"context"
 
+	"lmm/api/service/user/domain/model"
 	"lmm/api/service/user/port/adapter/util"
 )
 
 type Auth = util.Auth
 
+// Permission is a capability required by routes, granted by user's role
+type Permission = model.Permission
+
+// permissions shared by all services
+const (
+	PermissionPostArticle      = model.PermissionPostArticle
+	PermissionEditAnyArticle   = model.PermissionEditAnyArticle
+	PermissionDeleteAnyArticle = model.PermissionDeleteAnyArticle
+	PermissionUploadAsset      = model.PermissionUploadAsset
+	PermissionModerateComments = model.PermissionModerateComments
+	PermissionManageTags       = model.PermissionManageTags
+	PermissionManageUsers      = model.PermissionManageUsers
+	PermissionEditAnyAsset     = model.PermissionEditAnyAsset
+	PermissionExportSite       = model.PermissionExportSite
+)
+
 func NewContext(c context.Context, auth *Auth) context.Context {
 	return util.NewContext(c, auth)
 }
@@ -1,9 +1,16 @@
 package datastore
 
 const (
-	ArticleKind    = "Article"
-	AssetKind      = "Asset"
-	ArticleTagKind = "ArticleTag"
-	PhotoTagKind   = "PhotoTag"
-	UserKind       = "User"
+	ArticleKind         = "Article"
+	ArticleLinkNameKind = "ArticleLinkName"
+	ArticleRevisionKind = "ArticleRevision"
+	ArticleSeriesKind   = "ArticleSeries"
+	AssetKind           = "Asset"
+	CommentKind         = "Comment"
+	CommentThreadKind   = "CommentThread"
+	ArticleTagKind      = "ArticleTag"
+	ArticleTagStatKind  = "ArticleTagStat"
+	ArticleViewKind     = "ArticleView"
+	PhotoTagKind        = "PhotoTag"
+	UserKind            = "User"
 )
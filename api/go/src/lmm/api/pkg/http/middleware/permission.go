@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strings"
+
+	"lmm/api/pkg/auth"
+	httpUtil "lmm/api/pkg/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// RequirePermission aborts requests with 401 unless authorized, or with 403 unless the user has the permission
+func RequirePermission(permission auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := httpUtil.AuthFromGinContext(c)
+		if !ok {
+			httpUtil.Unauthorized(c)
+			c.Abort()
+			return
+		}
+
+		if !user.HasPermission(permission) {
+			httpUtil.LogWarn(c, "permission denied", errors.Errorf("%s (%s) requires %s",
+				user.Name, user.Role, strings.Join(permission.Names(), ", "),
+			))
+			httpUtil.Forbidden(c)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lmm/api/pkg/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if role := c.GetHeader("X-Role"); role != "" {
+			c.Request = c.Request.WithContext(auth.NewContext(c.Request.Context(), &auth.Auth{ID: 1, Name: "user", Role: role}))
+		}
+	})
+	router.POST("/articles", RequirePermission(auth.PermissionPostArticle), func(c *gin.Context) {
+		c.String(http.StatusCreated, "posted")
+	})
+	router.PUT("/tags", RequirePermission(auth.PermissionManageTags), func(c *gin.Context) {
+		c.String(http.StatusOK, "renamed")
+	})
+
+	cases := map[string]struct {
+		Method string
+		Path   string
+		Role   string
+		Code   int
+	}{
+		"Unauthorized":      {http.MethodPost, "/articles", "", http.StatusUnauthorized},
+		"Guest":             {http.MethodPost, "/articles", "guest", http.StatusForbidden},
+		"Ordinary":          {http.MethodPost, "/articles", "ordinary", http.StatusCreated},
+		"Admin":             {http.MethodPost, "/articles", "admin", http.StatusCreated},
+		"OrdinaryManageTag": {http.MethodPut, "/tags", "ordinary", http.StatusForbidden},
+		"AdminManageTag":    {http.MethodPut, "/tags", "admin", http.StatusOK},
+	}
+
+	for testName, testCase := range cases {
+		t.Run(testName, func(t *testing.T) {
+			req := httptest.NewRequest(testCase.Method, testCase.Path, nil)
+			if testCase.Role != "" {
+				req.Header.Set("X-Role", testCase.Role)
+			}
+			res := httptest.NewRecorder()
+
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, testCase.Code, res.Code)
+		})
+	}
+}
@@ -18,8 +18,8 @@ import (
 )
 
 var (
-	// TokenService uses CFBTokenService as default
-	TokenService = service.NewCFBTokenService(uuidutil.NewUUID(), 1*time.Minute)
+	// TokenService uses JWTTokenService as default
+	TokenService = newJWTTokenService()
 
 	// PasswordService uses BscryptService as default
 	PasswordService = &service.BcryptService{}
@@ -53,18 +53,13 @@ func NewUser(ctx context.Context, dataStore *datastore.Client) *User {
 	}
 
 	token := uuidutil.NewUUID()
-	accessToken, err := TokenService.Encrypt(token)
-	if err != nil {
-		panic("failed to generate access token: " + err.Error())
-	}
 
 	user := &User{
 		Name:           username,
-		Role:           "Ordinary",
+		Role:           model.Ordinary.Name(),
 		RawPassword:    password,
 		HashedPassword: hashedPassword,
 		RawToken:       token,
-		AccessToken:    accessToken.Hashed(),
 		RegisteredAt:   time.Now(),
 	}
 	key, err := dataStore.Put(ctx, datastore.IncompleteKey("User", nil), user)
@@ -74,6 +69,18 @@ func NewUser(ctx context.Context, dataStore *datastore.Client) *User {
 
 	user.Key = key
 
+	modelUser, err := model.NewUser(model.UserID(key.ID), username, username+"@lmm.local", hashedPassword, token, model.Ordinary, user.RegisteredAt)
+	if err != nil {
+		panic("failed to create user model: " + err.Error())
+	}
+
+	accessToken, err := TokenService.Encrypt(modelUser, model.SessionID(uuidutil.NewUUID()))
+	if err != nil {
+		panic("failed to generate access token: " + err.Error())
+	}
+
+	user.AccessToken = accessToken.Hashed()
+
 	fmt.Printf("INFO: created user: %#v\n", user)
 
 	return user
@@ -101,22 +108,12 @@ func BearerAuth(dataStore *datastore.Client) gin.HandlerFunc {
 			return
 		}
 
-		q := datastore.NewQuery("User").KeysOnly().Filter("Token =", token.Raw())
-		keys, err := dataStore.GetAll(c, q, nil)
-		if err != nil {
+		user := &User{}
+		if err := dataStore.Get(c, datastore.IDKey("User", int64(token.UserID()), nil), user); err != nil {
 			log.Print(err.Error())
 			c.Next()
 			return
 		}
-
-		users := make([]*User, len(keys))
-		if err := dataStore.GetMulti(c, keys, users); err != nil {
-			log.Print(err.Error())
-			c.Next()
-			return
-		}
-
-		user := users[0]
 		ctxWithAuth := auth.NewContext(c.Request.Context(), &auth.Auth{
 			ID:    user.ID(),
 			Name:  user.Name,
@@ -128,3 +125,16 @@ func BearerAuth(dataStore *datastore.Client) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func newJWTTokenService() model.TokenService {
+	key, err := service.NewJWTKey("testing", "HS256", []byte(uuidutil.NewUUID()))
+	if err != nil {
+		panic(err)
+	}
+
+	tokenService, err := service.NewJWTTokenService([]*service.JWTKey{key}, 1*time.Minute, "")
+	if err != nil {
+		panic(err)
+	}
+	return tokenService
+}
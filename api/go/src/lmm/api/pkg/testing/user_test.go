@@ -24,6 +24,6 @@ func TestNewUser(t *testing.T) {
 
 		token, err := TokenService.Decrypt(user.AccessToken)
 		assert.NoError(t, err)
-		assert.Equal(t, user.RawToken, token.Raw())
+		assert.Equal(t, user.ID(), int64(token.UserID()))
 	})
 }
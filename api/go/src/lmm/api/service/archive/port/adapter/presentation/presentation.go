@@ -0,0 +1,71 @@
+package presentation
+
+import (
+	"fmt"
+	"net/http"
+
+	"lmm/api/clock"
+	"lmm/api/pkg/auth"
+	httpUtil "lmm/api/pkg/http"
+	"lmm/api/pkg/http/middleware"
+	"lmm/api/service/archive/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+type GinRouterProvider struct {
+	usecase *usecase.Usecase
+	clock   clock.Clock
+}
+
+func NewGinRouterProvider(app *usecase.Usecase, clock clock.Clock) *GinRouterProvider {
+	return &GinRouterProvider{usecase: app, clock: clock}
+}
+
+func (p *GinRouterProvider) Provide(router *gin.Engine) {
+	router.GET("/v1/export", middleware.RequirePermission(auth.PermissionExportSite), p.GetV1Export)
+}
+
+// GetV1Export handles GET /v1/export, which streams all articles and photos metadata in format of zip or tar.gz
+func (p *GinRouterProvider) GetV1Export(c *gin.Context) {
+	format, err := usecase.FormatFromString(c.DefaultQuery("format", string(usecase.FormatTarGz)))
+	if err != nil {
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, errors.Cause(err).Error())
+		return
+	}
+
+	w := &attachmentWriter{
+		c:           c,
+		filename:    fmt.Sprintf("lmm-export-%s%s", p.clock.Now().UTC().Format("20060102"), format.Extension()),
+		contentType: format.ContentType(),
+	}
+
+	if err := p.usecase.Export(c, w, format); err != nil {
+		if w.started {
+			// the archive is broken in the middle, which clients can tell by failing to open it
+			httpUtil.LogWarn(c, "failed to export", err)
+			return
+		}
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+// attachmentWriter responds headers of the attachment on the first write,
+// so that failure before streaming is still responded as an error
+type attachmentWriter struct {
+	c           *gin.Context
+	filename    string
+	contentType string
+	started     bool
+}
+
+func (w *attachmentWriter) Write(b []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, w.filename))
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(b)
+}
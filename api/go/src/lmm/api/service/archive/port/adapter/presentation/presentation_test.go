@@ -0,0 +1,108 @@
+package presentation
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	clockTesting "lmm/api/clock/testing"
+	authUtil "lmm/api/pkg/auth"
+	"lmm/api/service/archive/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type emptySource struct{}
+
+func (s emptySource) Users(c context.Context) ([]*usecase.User, error) {
+	return nil, nil
+}
+
+func (s emptySource) Series(c context.Context) ([]*usecase.Series, error) {
+	return nil, nil
+}
+
+func (s emptySource) Comments(c context.Context) ([]*usecase.Comment, error) {
+	return nil, nil
+}
+
+func (s emptySource) Photos(c context.Context) ([]*usecase.Photo, error) {
+	return nil, nil
+}
+
+func (s emptySource) PhotoFile(c context.Context, filename string) ([]byte, error) {
+	return nil, nil
+}
+
+type staticArticleSource []*usecase.Article
+
+func (s staticArticleSource) Articles(c context.Context) ([]*usecase.Article, error) {
+	return s, nil
+}
+
+func (s staticArticleSource) Revisions(c context.Context) ([]*usecase.Revision, error) {
+	return nil, nil
+}
+
+func TestGetV1Export(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := clockTesting.NewClock(now)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if role := c.GetHeader("X-Test-Role"); role != "" {
+			c.Request = c.Request.WithContext(authUtil.NewContext(c.Request.Context(), &authUtil.Auth{ID: 1, Name: "user", Role: role}))
+		}
+	})
+	NewGinRouterProvider(usecase.New(clock, emptySource{}, staticArticleSource{
+		{ID: "a", LinkName: "hello", Title: "Hello", Body: "body", Tags: []string{"go"}, Status: "published", CreatedAt: now, LastModified: now},
+	}, emptySource{}, emptySource{}, emptySource{}), clock).Provide(router)
+
+	get := func(path, role string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if role != "" {
+			req.Header.Set("X-Test-Role", role)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	t.Run("Zip", func(t *testing.T) {
+		res := get("/v1/export?format=zip", "admin")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "application/zip", res.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="lmm-export-20200102.zip"`, res.Header().Get("Content-Disposition"))
+
+		archive, err := usecase.Read(bytes.NewReader(res.Body.Bytes()), int64(res.Body.Len()))
+		if assert.NoError(t, err) && assert.Len(t, archive.Articles, 1) {
+			assert.Equal(t, "articles/hello.md", archive.Articles[0].File)
+			assert.Equal(t, "Hello", archive.Articles[0].Matter.Title)
+		}
+	})
+
+	t.Run("TarGzByDefault", func(t *testing.T) {
+		res := get("/v1/export", "admin")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "application/gzip", res.Header().Get("Content-Type"))
+
+		_, err := usecase.Read(bytes.NewReader(res.Body.Bytes()), int64(res.Body.Len()))
+		assert.NoError(t, err)
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/v1/export?format=rar", "admin").Code)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, get("/v1/export", "").Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, get("/v1/export", "ordinary").Code)
+	})
+}
@@ -0,0 +1,91 @@
+package source
+
+import (
+	"context"
+	"sort"
+
+	"lmm/api/service/archive/usecase"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// ArticleFinder finds all articles in any status and their revisions
+type ArticleFinder interface {
+	FindAll(c context.Context) ([]*model.Article, error)
+	FindAllRevisions(c context.Context) ([]*model.ArticleRevision, error)
+}
+
+// ArticleSource provides articles of the article context to export
+type ArticleSource struct {
+	finder ArticleFinder
+}
+
+// NewArticleSource creates an ArticleSource
+func NewArticleSource(finder ArticleFinder) *ArticleSource {
+	return &ArticleSource{finder: finder}
+}
+
+// Articles implements usecase.ArticleSource
+func (s *ArticleSource) Articles(c context.Context) ([]*usecase.Article, error) {
+	articles, err := s.finder.FindAll(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find articles")
+	}
+
+	exported := make([]*usecase.Article, len(articles), len(articles))
+	for i, article := range articles {
+		exported[i] = &usecase.Article{
+			ID:           article.ID().String(),
+			AuthorID:     article.Author().ID(),
+			LinkName:     article.LinkName(),
+			Title:        article.Content().Text().Title(),
+			Body:         article.Content().Text().Body(),
+			Tags:         tagNames(article.Content().Tags()),
+			Status:       article.Status().String(),
+			Version:      article.Version(),
+			CreatedAt:    article.CreatedAt(),
+			LastModified: article.LastModified(),
+			PublishedAt:  article.PublishedAt(),
+		}
+	}
+	return exported, nil
+}
+
+// Revisions implements usecase.ArticleSource
+func (s *ArticleSource) Revisions(c context.Context) ([]*usecase.Revision, error) {
+	revisions, err := s.finder.FindAllRevisions(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find revisions")
+	}
+
+	exported := make([]*usecase.Revision, len(revisions), len(revisions))
+	for i, revision := range revisions {
+		exported[i] = &usecase.Revision{
+			ArticleID: revision.ArticleID().String(),
+			Number:    revision.Number(),
+			Title:     revision.Content().Text().Title(),
+			Body:      revision.Content().Text().Body(),
+			Tags:      tagNames(revision.Content().Tags()),
+			EditorID:  revision.Editor().ID(),
+			CreatedAt: revision.CreatedAt(),
+		}
+	}
+	return exported, nil
+}
+
+// tagNames lists tag names in the order of tags
+func tagNames(tags []*model.Tag) []string {
+	sorted := make([]*model.Tag, len(tags))
+	copy(sorted, tags)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Order() < sorted[j].Order()
+	})
+
+	names := make([]string, len(sorted), len(sorted))
+	for i, tag := range sorted {
+		names[i] = tag.Name()
+	}
+	return names
+}
@@ -0,0 +1,67 @@
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lmm/api/service/archive/usecase"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type allArticles []*model.Article
+
+func (articles allArticles) FindAll(c context.Context) ([]*model.Article, error) {
+	return articles, nil
+}
+
+func (articles allArticles) FindAllRevisions(c context.Context) ([]*model.ArticleRevision, error) {
+	revisions := make([]*model.ArticleRevision, len(articles), len(articles))
+	for i, article := range articles {
+		revisions[i] = model.NewArticleRevision(article.ID(), 1, article.Content(), article.Author(), article.CreatedAt())
+	}
+	return revisions, nil
+}
+
+func TestArticleSource(t *testing.T) {
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastModified := createdAt.Add(time.Hour)
+
+	content, err := model.NewContent("title", "body", []string{"web", "go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	article := model.NewArticle(model.NewArticleID("a"), model.NewAuthor(1), content, model.ArticleStatusDraft, 3, createdAt, lastModified, time.Time{})
+	if err := article.ChangeLinkName("title"); err != nil {
+		t.Fatal(err)
+	}
+
+	articles, err := NewArticleSource(allArticles{article}).Articles(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*usecase.Article{{
+		ID:           "a",
+		AuthorID:     1,
+		LinkName:     "title",
+		Title:        "title",
+		Body:         "body",
+		Tags:         []string{"web", "go"},
+		Status:       "draft",
+		Version:      3,
+		CreatedAt:    createdAt,
+		LastModified: lastModified,
+	}}, articles)
+
+	revisions, err := NewArticleSource(allArticles{article}).Revisions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*usecase.Revision{{
+		ArticleID: "a",
+		Number:    1,
+		Title:     "title",
+		Body:      "body",
+		Tags:      []string{"web", "go"},
+		EditorID:  1,
+		CreatedAt: createdAt,
+	}}, revisions)
+}
@@ -0,0 +1,54 @@
+package source
+
+import (
+	"context"
+
+	"lmm/api/service/archive/usecase"
+	"lmm/api/service/comment/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// CommentFinder finds comments on all articles
+type CommentFinder interface {
+	FindAll(c context.Context) ([]*model.Comment, error)
+}
+
+// CommentSource provides comments of the comment context to export
+type CommentSource struct {
+	finder CommentFinder
+}
+
+// NewCommentSource creates a CommentSource
+func NewCommentSource(finder CommentFinder) *CommentSource {
+	return &CommentSource{finder: finder}
+}
+
+// Comments implements usecase.CommentSource
+func (s *CommentSource) Comments(c context.Context) ([]*usecase.Comment, error) {
+	comments, err := s.finder.FindAll(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find comments")
+	}
+
+	exported := make([]*usecase.Comment, len(comments), len(comments))
+	for i, comment := range comments {
+		parentID := ""
+		if comment.ParentID() != nil {
+			parentID = comment.ParentID().String()
+		}
+
+		exported[i] = &usecase.Comment{
+			ID:          comment.ID().String(),
+			ArticleID:   comment.ArticleID(),
+			ParentID:    parentID,
+			UserID:      comment.Commenter().UserID(),
+			Name:        comment.Commenter().Name(),
+			Body:        comment.Body(),
+			Status:      comment.Status().String(),
+			CreatedAt:   comment.CreatedAt(),
+			ModeratedAt: comment.ModeratedAt(),
+		}
+	}
+	return exported, nil
+}
@@ -0,0 +1,80 @@
+package source
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"lmm/api/service/archive/usecase"
+	assetUsecase "lmm/api/service/asset/usecase"
+
+	"github.com/pkg/errors"
+)
+
+var photoPageSize = 100
+
+// PhotoLister lists photos page by page
+type PhotoLister interface {
+	ListPhotos(c context.Context, countStr, cursor string) ([]*assetUsecase.Photo, string, error)
+}
+
+// PhotoDownloader opens uploaded photo files
+type PhotoDownloader interface {
+	Download(c context.Context, filename string) (io.ReadCloser, error)
+}
+
+// PhotoSource provides photos of the asset context to export
+type PhotoSource struct {
+	lister     PhotoLister
+	downloader PhotoDownloader
+}
+
+// NewPhotoSource creates a PhotoSource
+func NewPhotoSource(lister PhotoLister, downloader PhotoDownloader) *PhotoSource {
+	return &PhotoSource{lister: lister, downloader: downloader}
+}
+
+// Photos implements usecase.PhotoSource
+func (s *PhotoSource) Photos(c context.Context) ([]*usecase.Photo, error) {
+	exported := make([]*usecase.Photo, 0)
+
+	cursor := ""
+	for {
+		photos, next, err := s.lister.ListPhotos(c, strconv.Itoa(photoPageSize), cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list photos")
+		}
+
+		for _, photo := range photos {
+			tags := photo.Tags
+			if tags == nil {
+				tags = []string{}
+			}
+			exported = append(exported, &usecase.Photo{
+				ID:         photo.ID,
+				UserID:     photo.UserID,
+				Filename:   photo.Filename,
+				URL:        photo.URL,
+				Tags:       tags,
+				UploadedAt: photo.UploadedAt,
+			})
+		}
+
+		if len(photos) < photoPageSize || next == "" || next == cursor {
+			return exported, nil
+		}
+		cursor = next
+	}
+}
+
+// PhotoFile implements usecase.PhotoSource
+func (s *PhotoSource) PhotoFile(c context.Context, filename string) ([]byte, error) {
+	r, err := s.downloader.Download(c, filename)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,52 @@
+package source
+
+import (
+	"context"
+
+	"lmm/api/service/archive/usecase"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// SeriesFinder finds all series
+type SeriesFinder interface {
+	FindAll(c context.Context) ([]*model.Series, error)
+}
+
+// SeriesSource provides series of the article context to export
+type SeriesSource struct {
+	finder SeriesFinder
+}
+
+// NewSeriesSource creates a SeriesSource
+func NewSeriesSource(finder SeriesFinder) *SeriesSource {
+	return &SeriesSource{finder: finder}
+}
+
+// Series implements usecase.SeriesSource
+func (s *SeriesSource) Series(c context.Context) ([]*usecase.Series, error) {
+	series, err := s.finder.FindAll(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find series")
+	}
+
+	exported := make([]*usecase.Series, len(series), len(series))
+	for i, one := range series {
+		articles := make([]string, len(one.Articles()), len(one.Articles()))
+		for j, id := range one.Articles() {
+			articles[j] = id.String()
+		}
+
+		exported[i] = &usecase.Series{
+			ID:           one.ID().String(),
+			OwnerID:      one.Owner().ID(),
+			Title:        one.Title(),
+			Description:  one.Description(),
+			Articles:     articles,
+			CreatedAt:    one.CreatedAt(),
+			LastModified: one.LastModified(),
+		}
+	}
+	return exported, nil
+}
@@ -0,0 +1,50 @@
+package source
+
+import (
+	"context"
+
+	"lmm/api/service/archive/usecase"
+	"lmm/api/service/user/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// UserFinder finds all users
+type UserFinder interface {
+	FindAll(c context.Context) ([]*model.User, error)
+}
+
+// UserSource provides users of the user context to export
+type UserSource struct {
+	finder UserFinder
+}
+
+// NewUserSource creates a UserSource
+func NewUserSource(finder UserFinder) *UserSource {
+	return &UserSource{finder: finder}
+}
+
+// Users implements usecase.UserSource
+func (s *UserSource) Users(c context.Context) ([]*usecase.User, error) {
+	users, err := s.finder.FindAll(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find users")
+	}
+
+	exported := make([]*usecase.User, len(users), len(users))
+	for i, user := range users {
+		exported[i] = &usecase.User{
+			ID:            int64(user.ID()),
+			Name:          user.Name(),
+			Email:         user.Email(),
+			Password:      user.Password(),
+			Token:         user.Token(),
+			Role:          user.Role().Name(),
+			RegisteredAt:  user.RegisteredAt(),
+			DisplayName:   user.Profile().DisplayName(),
+			Bio:           user.Profile().Bio(),
+			AvatarAssetID: user.Profile().AvatarAssetID(),
+		}
+	}
+	return exported, nil
+}
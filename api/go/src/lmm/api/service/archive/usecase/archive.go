@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Format is the file format of archives
+type Format string
+
+const (
+	FormatZip   Format = "zip"
+	FormatTarGz Format = "tar.gz"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported archive format")
+
+	// maxArchivedFileSize limits files read from archives, which are articles, metadata and photos
+	maxArchivedFileSize int64 = 32 * 1024 * 1024
+)
+
+// FormatFromString parses the name of format
+func FormatFromString(s string) (Format, error) {
+	switch Format(s) {
+	case FormatZip:
+		return FormatZip, nil
+	case FormatTarGz, "tgz":
+		return FormatTarGz, nil
+	default:
+		return "", errors.Wrap(ErrUnsupportedFormat, s)
+	}
+}
+
+// Extension gets the file extension including the leading dot
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// ContentType gets the media type of the format
+func (f Format) ContentType() string {
+	if f == FormatZip {
+		return "application/zip"
+	}
+	return "application/gzip"
+}
+
+// archiveWriter writes files into an archive one by one
+type archiveWriter interface {
+	WriteFile(name string, modTime time.Time, data []byte) error
+	Close() error
+}
+
+func newArchiveWriter(w io.Writer, format Format) (archiveWriter, error) {
+	switch format {
+	case FormatZip:
+		return &zipArchiveWriter{w: zip.NewWriter(w)}, nil
+	case FormatTarGz:
+		gw := gzip.NewWriter(w)
+		return &tarGzArchiveWriter{gw: gw, tw: tar.NewWriter(gw)}, nil
+	default:
+		return nil, errors.Wrap(ErrUnsupportedFormat, string(format))
+	}
+}
+
+type zipArchiveWriter struct {
+	w *zip.Writer
+}
+
+func (aw *zipArchiveWriter) WriteFile(name string, modTime time.Time, data []byte) error {
+	f, err := aw.w.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+func (aw *zipArchiveWriter) Close() error {
+	return aw.w.Close()
+}
+
+type tarGzArchiveWriter struct {
+	gw *gzip.Writer
+	tw *tar.Writer
+}
+
+func (aw *tarGzArchiveWriter) WriteFile(name string, modTime time.Time, data []byte) error {
+	if err := aw.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := aw.tw.Write(data)
+	return err
+}
+
+func (aw *tarGzArchiveWriter) Close() error {
+	if err := aw.tw.Close(); err != nil {
+		return err
+	}
+	return aw.gw.Close()
+}
+
+// readArchiveFiles reads all regular files in an archive of either format by name
+func readArchiveFiles(r io.ReaderAt, size int64) (map[string][]byte, error) {
+	magic := make([]byte, 4)
+	if _, err := r.ReadAt(magic, 0); err != nil {
+		return nil, errors.Wrap(ErrUnsupportedFormat, err.Error())
+	}
+
+	switch {
+	case bytes.Equal(magic, []byte("PK\x03\x04")):
+		return readZipFiles(r, size)
+	case bytes.Equal(magic[:2], []byte("\x1f\x8b")):
+		return readTarGzFiles(io.NewSectionReader(r, 0, size))
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func readZipFiles(r io.ReaderAt, size int64) (map[string][]byte, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid zip archive")
+	}
+
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if f.UncompressedSize64 > uint64(maxArchivedFileSize) {
+			return nil, errors.Errorf("%s is too large", f.Name)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open %s", f.Name)
+		}
+		files[f.Name], err = ioutil.ReadAll(io.LimitReader(rc, maxArchivedFileSize))
+		rc.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", f.Name)
+		}
+	}
+	return files, nil
+}
+
+func readTarGzFiles(r io.Reader) (map[string][]byte, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid gzip archive")
+	}
+	defer gr.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid tar archive")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxArchivedFileSize {
+			return nil, errors.Errorf("%s is too large", header.Name)
+		}
+
+		files[header.Name], err = ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", header.Name)
+		}
+	}
+}
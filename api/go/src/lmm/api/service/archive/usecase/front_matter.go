@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const frontMatterDelimiter = "---"
+
+var (
+	ErrNoFrontMatter       = errors.New("no front matter")
+	ErrUnclosedFrontMatter = errors.New("front matter is not closed")
+	ErrDateRequired        = errors.New("date required")
+	ErrInvalidDate         = errors.New("invalid date")
+
+	// frontMatterDateLayouts are tried in order, dates without time zone are in UTC
+	frontMatterDateLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+)
+
+// FrontMatter is the YAML header of a Markdown article,
+// in the format most static site generators share
+type FrontMatter struct {
+	Title   string   `yaml:"title"`
+	Tags    []string `yaml:"tags"`
+	Date    string   `yaml:"date"`
+	Updated string   `yaml:"updated,omitempty"`
+	Slug    string   `yaml:"slug,omitempty"`
+
+	// Author and Published are only written by exports, Published is when the article was or will be published
+	Author    int64  `yaml:"author,omitempty"`
+	Published string `yaml:"published,omitempty"`
+
+	// Status is one of the article statuses, which takes precedence over Draft
+	Status string `yaml:"status,omitempty"`
+	Draft  bool   `yaml:"draft,omitempty"`
+}
+
+// CreatedAt parses the date
+func (m *FrontMatter) CreatedAt() (time.Time, error) {
+	if m.Date == "" {
+		return time.Time{}, ErrDateRequired
+	}
+	return parseFrontMatterDate(m.Date)
+}
+
+// UpdatedAt parses the updated date, which is the date if not specified
+func (m *FrontMatter) UpdatedAt() (time.Time, error) {
+	if m.Updated == "" {
+		return m.CreatedAt()
+	}
+	return parseFrontMatterDate(m.Updated)
+}
+
+// PublishedAt parses the published date, which is zero if not specified
+func (m *FrontMatter) PublishedAt() (time.Time, error) {
+	if m.Published == "" {
+		return time.Time{}, nil
+	}
+	return parseFrontMatterDate(m.Published)
+}
+
+func parseFrontMatterDate(s string) (time.Time, error) {
+	for _, layout := range frontMatterDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Wrap(ErrInvalidDate, s)
+}
+
+// ArticleStatus gets the status to post the article with, empty if not specified
+func (m *FrontMatter) ArticleStatus() string {
+	if m.Status != "" {
+		return m.Status
+	}
+	if m.Draft {
+		return "draft"
+	}
+	return ""
+}
+
+// ParseMarkdownArticle splits a Markdown file into its front matter and body
+func ParseMarkdownArticle(r io.Reader) (*FrontMatter, string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() || strings.TrimRight(scanner.Text(), " \r") != frontMatterDelimiter {
+		if err := scanner.Err(); err != nil {
+			return nil, "", err
+		}
+		return nil, "", ErrNoFrontMatter
+	}
+
+	header := new(bytes.Buffer)
+	closed := false
+	for scanner.Scan() {
+		if strings.TrimRight(scanner.Text(), " \r") == frontMatterDelimiter {
+			closed = true
+			break
+		}
+		header.WriteString(scanner.Text())
+		header.WriteByte('\n')
+	}
+	if !closed {
+		if err := scanner.Err(); err != nil {
+			return nil, "", err
+		}
+		return nil, "", ErrUnclosedFrontMatter
+	}
+
+	matter := FrontMatter{}
+	if err := yaml.Unmarshal(header.Bytes(), &matter); err != nil {
+		return nil, "", errors.Wrap(err, "invalid front matter")
+	}
+
+	body := new(strings.Builder)
+	for scanner.Scan() {
+		body.WriteString(strings.TrimRight(scanner.Text(), "\r"))
+		body.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return &matter, strings.Trim(body.String(), "\n"), nil
+}
+
+// WriteMarkdownArticle writes a Markdown file which ParseMarkdownArticle reads back
+func WriteMarkdownArticle(w io.Writer, matter *FrontMatter, body string) error {
+	header, err := yaml.Marshal(matter)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal front matter")
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(frontMatterDelimiter + "\n")
+	buf.Write(header)
+	buf.WriteString(frontMatterDelimiter + "\n\n")
+	buf.WriteString(body)
+	buf.WriteByte('\n')
+
+	_, err = buf.WriteTo(w)
+	return err
+}
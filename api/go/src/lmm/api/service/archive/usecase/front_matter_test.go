@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMarkdownArticle(t *testing.T) {
+	matter, body, err := ParseMarkdownArticle(strings.NewReader(`---
+title: "Hello: World"
+tags: [go, datastore]
+date: 2016-04-01 09:30
+slug: hello-world
+---
+
+# Hello
+
+body
+---
+`))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, &FrontMatter{
+		Title: "Hello: World",
+		Tags:  []string{"go", "datastore"},
+		Date:  "2016-04-01 09:30",
+		Slug:  "hello-world",
+	}, matter)
+	assert.Equal(t, "# Hello\n\nbody\n---", body)
+
+	createdAt, err := matter.CreatedAt()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2016, 4, 1, 9, 30, 0, 0, time.UTC), createdAt)
+
+	t.Run("Fail", func(t *testing.T) {
+		_, _, err := ParseMarkdownArticle(strings.NewReader("# Hello\n"))
+		assert.Equal(t, ErrNoFrontMatter, err)
+
+		_, _, err = ParseMarkdownArticle(strings.NewReader("---\ntitle: hello\n"))
+		assert.Equal(t, ErrUnclosedFrontMatter, err)
+
+		_, _, err = ParseMarkdownArticle(strings.NewReader("---\ntags: {\n---\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestFrontMatterCreatedAt(t *testing.T) {
+	jst := time.FixedZone("", 9*60*60)
+
+	cases := map[string]struct {
+		Date     string
+		Expected time.Time
+		Err      error
+	}{
+		"RFC3339":  {"2016-04-01T09:30:00+09:00", time.Date(2016, 4, 1, 9, 30, 0, 0, jst), nil},
+		"DateOnly": {"2016-04-01", time.Date(2016, 4, 1, 0, 0, 0, 0, time.UTC), nil},
+		"Empty":    {"", time.Time{}, ErrDateRequired},
+		"Invalid":  {"April 1st", time.Time{}, ErrInvalidDate},
+	}
+
+	for testName, testCase := range cases {
+		t.Run(testName, func(t *testing.T) {
+			createdAt, err := (&FrontMatter{Date: testCase.Date}).CreatedAt()
+			assert.Equal(t, testCase.Err, errors.Cause(err))
+			assert.True(t, testCase.Expected.Equal(createdAt))
+		})
+	}
+}
+
+func TestFrontMatterUpdatedAndPublishedAt(t *testing.T) {
+	matter := &FrontMatter{Date: "2016-04-01"}
+
+	updatedAt, err := matter.UpdatedAt()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2016, 4, 1, 0, 0, 0, 0, time.UTC), updatedAt)
+
+	publishedAt, err := matter.PublishedAt()
+	assert.NoError(t, err)
+	assert.True(t, publishedAt.IsZero())
+
+	matter.Updated = "2016-04-02"
+	matter.Published = "2016-04-03T09:30:00Z"
+
+	updatedAt, err = matter.UpdatedAt()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2016, 4, 2, 0, 0, 0, 0, time.UTC), updatedAt)
+
+	publishedAt, err = matter.PublishedAt()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2016, 4, 3, 9, 30, 0, 0, time.UTC), publishedAt)
+
+	matter.Published = "soon"
+	_, err = matter.PublishedAt()
+	assert.Equal(t, ErrInvalidDate, errors.Cause(err))
+}
+
+func TestWriteMarkdownArticle(t *testing.T) {
+	matter := &FrontMatter{
+		Title:   "date: 2016-04-01",
+		Tags:    []string{"go", "yes"},
+		Date:    "2016-04-01T09:30:00Z",
+		Updated: "2017-05-02T10:00:00Z",
+		Slug:    "hello",
+		Author:  1,
+		Status:  "unlisted",
+	}
+	body := "# Hello\n\n---\n\nbody"
+
+	buf := new(strings.Builder)
+	if !assert.NoError(t, WriteMarkdownArticle(buf, matter, body)) {
+		t.FailNow()
+	}
+
+	matterRead, bodyRead, err := ParseMarkdownArticle(strings.NewReader(buf.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, matter, matterRead)
+	assert.Equal(t, body, bodyRead)
+	assert.Equal(t, "unlisted", matterRead.ArticleStatus())
+
+	assert.Equal(t, "draft", (&FrontMatter{Draft: true}).ArticleStatus())
+	assert.Equal(t, "", (&FrontMatter{}).ArticleStatus())
+}
@@ -0,0 +1,434 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"lmm/api/clock"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ManifestVersion is increased on incompatible changes of the archive layout
+	ManifestVersion = 2
+
+	manifestFile  = "manifest.json"
+	usersFile     = "users.json"
+	revisionsFile = "revisions.json"
+	seriesFile    = "series.json"
+	commentsFile  = "comments.json"
+	photosFile    = "photos.json"
+	articlesDir   = "articles"
+	photosDir     = "photos"
+)
+
+var (
+	ErrInvalidArchive            = errors.New("invalid archive")
+	ErrUnsupportedArchiveVersion = errors.New("unsupported archive version")
+)
+
+// User is a user with the profile, the password hash and the token are kept so that users can sign in after restored
+type User struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	Password      string    `json:"password"`
+	Token         string    `json:"token"`
+	Role          string    `json:"role"`
+	RegisteredAt  time.Time `json:"registeredAt"`
+	DisplayName   string    `json:"displayName"`
+	Bio           string    `json:"bio"`
+	AvatarAssetID string    `json:"avatarAssetId"`
+}
+
+// Article is an article to export, in whatever status
+type Article struct {
+	ID           string
+	AuthorID     int64
+	LinkName     string
+	Title        string
+	Body         string
+	Tags         []string
+	Status       string
+	Version      uint
+	CreatedAt    time.Time
+	LastModified time.Time
+
+	// PublishedAt is when the article was or will be published, zero if it's a draft
+	PublishedAt time.Time
+}
+
+// Revision is a snapshot of an article's content
+type Revision struct {
+	ArticleID string    `json:"articleId"`
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Tags      []string  `json:"tags"`
+	EditorID  int64     `json:"editorId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Series is an ordered list of articles by the owner
+type Series struct {
+	ID           string    `json:"id"`
+	OwnerID      int64     `json:"ownerId"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Articles     []string  `json:"articles"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// Comment is a comment on an article, UserID is 0 if posted anonymously
+type Comment struct {
+	ID          string    `json:"id"`
+	ArticleID   string    `json:"articleId"`
+	ParentID    string    `json:"parentId,omitempty"`
+	UserID      int64     `json:"userId"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ModeratedAt time.Time `json:"moderatedAt"`
+}
+
+// Photo is the metadata of a photo, File is where the photo file is in the archive
+type Photo struct {
+	ID         string    `json:"id"`
+	UserID     int64     `json:"userId"`
+	Filename   string    `json:"filename"`
+	File       string    `json:"file"`
+	URL        string    `json:"url"`
+	Tags       []string  `json:"tags"`
+	UploadedAt time.Time `json:"uploadedAt"`
+}
+
+// UserSource provides all users to export
+type UserSource interface {
+	Users(c context.Context) ([]*User, error)
+}
+
+// ArticleSource provides all articles and their revisions to export
+type ArticleSource interface {
+	Articles(c context.Context) ([]*Article, error)
+	Revisions(c context.Context) ([]*Revision, error)
+}
+
+// SeriesSource provides all series to export
+type SeriesSource interface {
+	Series(c context.Context) ([]*Series, error)
+}
+
+// CommentSource provides all comments to export
+type CommentSource interface {
+	Comments(c context.Context) ([]*Comment, error)
+}
+
+// PhotoSource provides all photos and their files to export
+type PhotoSource interface {
+	Photos(c context.Context) ([]*Photo, error)
+	PhotoFile(c context.Context, filename string) ([]byte, error)
+}
+
+// Manifest describes the contents of an archive
+type Manifest struct {
+	Version    int                `json:"version"`
+	ExportedAt time.Time          `json:"exportedAt"`
+	Users      string             `json:"users"`
+	Articles   []*ManifestArticle `json:"articles"`
+	Revisions  string             `json:"revisions"`
+	Series     string             `json:"series"`
+	Comments   string             `json:"comments"`
+	Photos     string             `json:"photos"`
+	PhotoCount int                `json:"photoCount"`
+}
+
+// ManifestArticle locates an article in an archive
+type ManifestArticle struct {
+	ID      string `json:"id"`
+	File    string `json:"file"`
+	Status  string `json:"status"`
+	Version uint   `json:"version"`
+}
+
+// ArchivedArticle is an article read from an archive
+type ArchivedArticle struct {
+	ID      string
+	File    string
+	Matter  *FrontMatter
+	Body    string
+	Version uint
+}
+
+// Archive is the contents read from an archive
+type Archive struct {
+	Manifest  *Manifest
+	Users     []*User
+	Articles  []*ArchivedArticle
+	Revisions []*Revision
+	Series    []*Series
+	Comments  []*Comment
+	Photos    []*Photo
+
+	photoFiles map[string][]byte
+}
+
+// PhotoFile gets the file of the photo in the archive
+func (archive *Archive) PhotoFile(photo *Photo) []byte {
+	return archive.photoFiles[photo.File]
+}
+
+// Usecase exports the whole site into archives
+type Usecase struct {
+	clock    clock.Clock
+	users    UserSource
+	articles ArticleSource
+	series   SeriesSource
+	comments CommentSource
+	photos   PhotoSource
+}
+
+func New(clock clock.Clock, users UserSource, articles ArticleSource, series SeriesSource, comments CommentSource, photos PhotoSource) *Usecase {
+	return &Usecase{clock: clock, users: users, articles: articles, series: series, comments: comments, photos: photos}
+}
+
+// Export writes users, articles as Markdown files with front matter, revisions, series, comments,
+// photos and the manifest into w. Nothing is written if failed to load any of them but photo files
+func (uc *Usecase) Export(c context.Context, w io.Writer, format Format) error {
+	users, err := uc.users.Users(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to load users")
+	}
+
+	articles, err := uc.articles.Articles(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to load articles")
+	}
+
+	revisions, err := uc.articles.Revisions(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to load revisions")
+	}
+
+	series, err := uc.series.Series(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to load series")
+	}
+
+	comments, err := uc.comments.Comments(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to load comments")
+	}
+
+	photos, err := uc.photos.Photos(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to load photos")
+	}
+
+	aw, err := newArchiveWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	now := uc.clock.Now()
+
+	sort.SliceStable(articles, func(i, j int) bool {
+		return articles[i].CreatedAt.Before(articles[j].CreatedAt)
+	})
+
+	manifest := &Manifest{
+		Version:    ManifestVersion,
+		ExportedAt: now,
+		Users:      usersFile,
+		Articles:   make([]*ManifestArticle, len(articles), len(articles)),
+		Revisions:  revisionsFile,
+		Series:     seriesFile,
+		Comments:   commentsFile,
+		Photos:     photosFile,
+		PhotoCount: len(photos),
+	}
+
+	for i, article := range articles {
+		name := article.LinkName
+		if name == "" {
+			name = article.ID
+		}
+		file := path.Join(articlesDir, name+".md")
+
+		buf := new(bytes.Buffer)
+		if err := WriteMarkdownArticle(buf, articleFrontMatter(article), article.Body); err != nil {
+			return errors.Wrapf(err, "failed to write article %s", article.ID)
+		}
+		if err := aw.WriteFile(file, article.LastModified, buf.Bytes()); err != nil {
+			return errors.Wrapf(err, "failed to archive article %s", article.ID)
+		}
+
+		manifest.Articles[i] = &ManifestArticle{ID: article.ID, File: file, Status: article.Status, Version: article.Version}
+	}
+
+	for _, photo := range photos {
+		data, err := uc.photos.PhotoFile(c, photo.Filename)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load photo file %s", photo.Filename)
+		}
+
+		photo.File = path.Join(photosDir, path.Base(photo.Filename))
+		if err := aw.WriteFile(photo.File, photo.UploadedAt, data); err != nil {
+			return errors.Wrapf(err, "failed to archive photo file %s", photo.Filename)
+		}
+	}
+
+	if users == nil {
+		users = []*User{}
+	}
+	if revisions == nil {
+		revisions = []*Revision{}
+	}
+	if series == nil {
+		series = []*Series{}
+	}
+	if comments == nil {
+		comments = []*Comment{}
+	}
+	if photos == nil {
+		photos = []*Photo{}
+	}
+
+	// the manifest is the last one so that an archive broken in the middle is never read
+	for _, file := range []struct {
+		name string
+		v    interface{}
+	}{
+		{usersFile, users},
+		{revisionsFile, revisions},
+		{seriesFile, series},
+		{commentsFile, comments},
+		{photosFile, photos},
+		{manifestFile, manifest},
+	} {
+		if err := writeJSONFile(aw, file.name, now, file.v); err != nil {
+			return err
+		}
+	}
+
+	return aw.Close()
+}
+
+// articleFrontMatter keeps everything of the article but the id and the version, which are in the manifest
+func articleFrontMatter(article *Article) *FrontMatter {
+	tags := article.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	matter := &FrontMatter{
+		Title:   article.Title,
+		Author:  article.AuthorID,
+		Tags:    tags,
+		Date:    article.CreatedAt.Format(time.RFC3339),
+		Updated: article.LastModified.Format(time.RFC3339),
+		Slug:    article.LinkName,
+		Status:  article.Status,
+	}
+	if !article.PublishedAt.IsZero() {
+		matter.Published = article.PublishedAt.Format(time.RFC3339)
+	}
+
+	return matter
+}
+
+func writeJSONFile(aw archiveWriter, name string, modTime time.Time, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", name)
+	}
+	if err := aw.WriteFile(name, modTime, b); err != nil {
+		return errors.Wrapf(err, "failed to archive %s", name)
+	}
+	return nil
+}
+
+// Read reads an exported archive of either format, articles are in the order of the manifest
+func Read(r io.ReaderAt, size int64) (*Archive, error) {
+	files, err := readArchiveFiles(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := readJSONFile(files, manifestFile, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, errors.Wrapf(ErrUnsupportedArchiveVersion, "%d", manifest.Version)
+	}
+
+	archive := &Archive{
+		Manifest:   manifest,
+		Users:      make([]*User, 0),
+		Articles:   make([]*ArchivedArticle, len(manifest.Articles), len(manifest.Articles)),
+		Revisions:  make([]*Revision, 0),
+		Series:     make([]*Series, 0),
+		Comments:   make([]*Comment, 0),
+		Photos:     make([]*Photo, 0),
+		photoFiles: files,
+	}
+
+	for i, entry := range manifest.Articles {
+		data, ok := files[entry.File]
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidArchive, "missing %s", entry.File)
+		}
+
+		matter, body, err := ParseMarkdownArticle(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrap(err, entry.File)
+		}
+
+		archive.Articles[i] = &ArchivedArticle{ID: entry.ID, File: entry.File, Matter: matter, Body: body, Version: entry.Version}
+	}
+
+	for _, file := range []struct {
+		name string
+		v    interface{}
+	}{
+		{manifest.Users, &archive.Users},
+		{manifest.Revisions, &archive.Revisions},
+		{manifest.Series, &archive.Series},
+		{manifest.Comments, &archive.Comments},
+		{manifest.Photos, &archive.Photos},
+	} {
+		if err := readJSONFile(files, file.name, file.v); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(archive.Photos) != manifest.PhotoCount {
+		return nil, errors.Wrapf(ErrInvalidArchive, "expect %d photos but got %d", manifest.PhotoCount, len(archive.Photos))
+	}
+	for _, photo := range archive.Photos {
+		if _, ok := files[photo.File]; !ok {
+			return nil, errors.Wrapf(ErrInvalidArchive, "missing %s", photo.File)
+		}
+	}
+
+	return archive, nil
+}
+
+func readJSONFile(files map[string][]byte, name string, v interface{}) error {
+	data, ok := files[name]
+	if !ok {
+		return errors.Wrapf(ErrInvalidArchive, "missing %s", name)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Wrapf(ErrInvalidArchive, "invalid %s: %s", name, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	clockTesting "lmm/api/clock/testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticUserSource []*User
+
+func (s staticUserSource) Users(c context.Context) ([]*User, error) {
+	return s, nil
+}
+
+type staticArticleSource struct {
+	articles  []*Article
+	revisions []*Revision
+}
+
+func (s staticArticleSource) Articles(c context.Context) ([]*Article, error) {
+	return s.articles, nil
+}
+
+func (s staticArticleSource) Revisions(c context.Context) ([]*Revision, error) {
+	return s.revisions, nil
+}
+
+type staticSeriesSource []*Series
+
+func (s staticSeriesSource) Series(c context.Context) ([]*Series, error) {
+	return s, nil
+}
+
+type staticCommentSource []*Comment
+
+func (s staticCommentSource) Comments(c context.Context) ([]*Comment, error) {
+	return s, nil
+}
+
+type staticPhotoSource map[*Photo][]byte
+
+func (s staticPhotoSource) Photos(c context.Context) ([]*Photo, error) {
+	photos := make([]*Photo, 0, len(s))
+	for photo := range s {
+		photos = append(photos, photo)
+	}
+	return photos, nil
+}
+
+func (s staticPhotoSource) PhotoFile(c context.Context, filename string) ([]byte, error) {
+	for photo, data := range s {
+		if photo.Filename == filename {
+			return data, nil
+		}
+	}
+	return nil, errors.New("no such file")
+}
+
+type failingPhotoSource struct {
+	staticPhotoSource
+}
+
+func (s failingPhotoSource) Photos(c context.Context) ([]*Photo, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestExport(t *testing.T) {
+	c := context.Background()
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	createdAt := time.Date(2016, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	users := staticUserSource{
+		{ID: 1, Name: "alice", Email: "alice@example.com", Password: "hash", Token: "token", Role: "admin", RegisteredAt: createdAt, DisplayName: "Alice"},
+	}
+	articles := staticArticleSource{
+		articles: []*Article{
+			{
+				ID:           "id2",
+				AuthorID:     1,
+				LinkName:     "second",
+				Title:        "Second",
+				Body:         "# Second\n\nbody",
+				Tags:         []string{"go", "datastore"},
+				Status:       "published",
+				Version:      2,
+				CreatedAt:    createdAt.Add(24 * time.Hour),
+				LastModified: createdAt.Add(48 * time.Hour),
+				PublishedAt:  createdAt.Add(24 * time.Hour),
+			},
+			{
+				ID:           "id1",
+				AuthorID:     1,
+				Title:        "First",
+				Body:         "first",
+				Status:       "scheduled",
+				CreatedAt:    createdAt,
+				LastModified: createdAt,
+				PublishedAt:  createdAt.Add(365 * 24 * time.Hour),
+			},
+		},
+		revisions: []*Revision{
+			{ArticleID: "id2", Number: 1, Title: "Second", Body: "body", Tags: []string{"go"}, EditorID: 1, CreatedAt: createdAt.Add(24 * time.Hour)},
+		},
+	}
+	series := staticSeriesSource{
+		{ID: "series1", OwnerID: 1, Title: "Series", Articles: []string{"id1", "id2"}, CreatedAt: createdAt, LastModified: createdAt},
+	}
+	comments := staticCommentSource{
+		{ID: "comment1", ArticleID: "id2", Name: "reader", Body: "nice", Status: "approved", CreatedAt: createdAt},
+		{ID: "comment2", ArticleID: "id2", ParentID: "comment1", UserID: 1, Name: "alice", Body: "thanks", Status: "approved", CreatedAt: createdAt},
+	}
+	photos := staticPhotoSource{
+		{ID: "photo1", UserID: 1, Filename: "a.jpg", URL: "https://storage.local/a.jpg", Tags: []string{"sea"}, UploadedAt: createdAt}: []byte("jpeg"),
+	}
+
+	uc := New(clockTesting.NewClock(now), users, articles, series, comments, photos)
+
+	for _, format := range []Format{FormatZip, FormatTarGz} {
+		t.Run(string(format), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if !assert.NoError(t, uc.Export(c, buf, format)) {
+				t.FailNow()
+			}
+
+			archive, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, ManifestVersion, archive.Manifest.Version)
+			assert.True(t, now.Equal(archive.Manifest.ExportedAt))
+			assert.Equal(t, []*ManifestArticle{
+				{ID: "id1", File: "articles/id1.md", Status: "scheduled"},
+				{ID: "id2", File: "articles/second.md", Status: "published", Version: 2},
+			}, archive.Manifest.Articles)
+
+			assert.Equal(t, []*ArchivedArticle{
+				{
+					ID:   "id1",
+					File: "articles/id1.md",
+					Matter: &FrontMatter{
+						Title:     "First",
+						Tags:      []string{},
+						Date:      "2016-04-01T09:30:00Z",
+						Updated:   "2016-04-01T09:30:00Z",
+						Author:    1,
+						Published: "2017-04-01T09:30:00Z",
+						Status:    "scheduled",
+					},
+					Body: "first",
+				},
+				{
+					ID:   "id2",
+					File: "articles/second.md",
+					Matter: &FrontMatter{
+						Title:     "Second",
+						Tags:      []string{"go", "datastore"},
+						Date:      "2016-04-02T09:30:00Z",
+						Updated:   "2016-04-03T09:30:00Z",
+						Slug:      "second",
+						Author:    1,
+						Published: "2016-04-02T09:30:00Z",
+						Status:    "published",
+					},
+					Body:    "# Second\n\nbody",
+					Version: 2,
+				},
+			}, archive.Articles)
+
+			if assert.Len(t, archive.Users, 1) {
+				assert.Equal(t, "alice", archive.Users[0].Name)
+				assert.Equal(t, "hash", archive.Users[0].Password)
+				assert.Equal(t, "Alice", archive.Users[0].DisplayName)
+			}
+
+			if assert.Len(t, archive.Revisions, 1) {
+				assert.Equal(t, "id2", archive.Revisions[0].ArticleID)
+				assert.Equal(t, int64(1), archive.Revisions[0].EditorID)
+			}
+
+			if assert.Len(t, archive.Series, 1) {
+				assert.Equal(t, []string{"id1", "id2"}, archive.Series[0].Articles)
+			}
+
+			if assert.Len(t, archive.Comments, 2) {
+				assert.Equal(t, "comment1", archive.Comments[1].ParentID)
+			}
+
+			if assert.Len(t, archive.Photos, 1) {
+				assert.Equal(t, "a.jpg", archive.Photos[0].Filename)
+				assert.Equal(t, int64(1), archive.Photos[0].UserID)
+				assert.Equal(t, []string{"sea"}, archive.Photos[0].Tags)
+				assert.True(t, createdAt.Equal(archive.Photos[0].UploadedAt))
+				assert.Equal(t, []byte("jpeg"), archive.PhotoFile(archive.Photos[0]))
+			}
+		})
+	}
+
+	t.Run("SourceFailed", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		assert.Error(t, New(clockTesting.NewClock(now), users, articles, series, comments, failingPhotoSource{photos}).Export(c, buf, FormatZip))
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		assert.Equal(t, ErrUnsupportedFormat, errors.Cause(uc.Export(c, new(bytes.Buffer), Format("rar"))))
+
+		_, err := Read(bytes.NewReader([]byte("plain text")), 10)
+		assert.Equal(t, ErrUnsupportedFormat, errors.Cause(err))
+	})
+}
+
+func TestFormatFromString(t *testing.T) {
+	format, err := FormatFromString("tgz")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatTarGz, format)
+	assert.Equal(t, ".tar.gz", format.Extension())
+	assert.Equal(t, "application/gzip", format.ContentType())
+
+	format, err = FormatFromString("zip")
+	assert.NoError(t, err)
+	assert.Equal(t, "application/zip", format.ContentType())
+
+	_, err = FormatFromString("rar")
+	assert.Equal(t, ErrUnsupportedFormat, errors.Cause(err))
+}
@@ -4,13 +4,28 @@ package application
 type Service struct {
 	articleCommandService *ArticleCommandService
 	articleQueryService   *ArticleQueryService
+	seriesCommandService  *SeriesCommandService
+	seriesQueryService    *SeriesQueryService
+	articleViewCounter    *ArticleViewCounter
+	authorQueryService    *AuthorQueryService
 }
 
 // NewService is a constructor of Service
-func NewService(articleCommandService *ArticleCommandService, articleQueryService *ArticleQueryService) *Service {
+func NewService(
+	articleCommandService *ArticleCommandService,
+	articleQueryService *ArticleQueryService,
+	seriesCommandService *SeriesCommandService,
+	seriesQueryService *SeriesQueryService,
+	articleViewCounter *ArticleViewCounter,
+	authorQueryService *AuthorQueryService,
+) *Service {
 	return &Service{
 		articleCommandService: articleCommandService,
 		articleQueryService:   articleQueryService,
+		seriesCommandService:  seriesCommandService,
+		seriesQueryService:    seriesQueryService,
+		articleViewCounter:    articleViewCounter,
+		authorQueryService:    authorQueryService,
 	}
 }
 
@@ -23,3 +38,23 @@ func (s *Service) Command() *ArticleCommandService {
 func (s *Service) Query() *ArticleQueryService {
 	return s.articleQueryService
 }
+
+// SeriesCommand service
+func (s *Service) SeriesCommand() *SeriesCommandService {
+	return s.seriesCommandService
+}
+
+// SeriesQuery service
+func (s *Service) SeriesQuery() *SeriesQueryService {
+	return s.seriesQueryService
+}
+
+// ViewCounter service
+func (s *Service) ViewCounter() *ArticleViewCounter {
+	return s.articleViewCounter
+}
+
+// AuthorQuery service
+func (s *Service) AuthorQuery() *AuthorQueryService {
+	return s.authorQueryService
+}
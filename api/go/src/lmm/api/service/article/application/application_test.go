@@ -0,0 +1,241 @@
+package application
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+)
+
+type InmemoryArticleRepository struct {
+	sync.RWMutex
+	memory    map[model.ArticleID]*model.Article
+	linkNames map[string]model.ArticleID
+	revisions map[model.ArticleID][]*model.ArticleRevision
+	nextID    int
+}
+
+func NewInmemoryArticleRepository() *InmemoryArticleRepository {
+	return &InmemoryArticleRepository{
+		memory:    make(map[model.ArticleID]*model.Article),
+		linkNames: make(map[string]model.ArticleID),
+		revisions: make(map[model.ArticleID][]*model.ArticleRevision),
+	}
+}
+
+func (repo *InmemoryArticleRepository) NextID(tx transaction.Transaction, authorID int64) (*model.ArticleID, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.nextID++
+	return model.NewArticleID(strconv.Itoa(repo.nextID)), nil
+}
+
+func (repo *InmemoryArticleRepository) Save(tx transaction.Transaction, article *model.Article) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.memory[*article.ID()] = article
+	if article.LinkName() != "" {
+		repo.linkNames[article.LinkName()] = *article.ID()
+	}
+	return nil
+}
+
+func (repo *InmemoryArticleRepository) Remove(tx transaction.Transaction, id *model.ArticleID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	delete(repo.memory, *id)
+	delete(repo.revisions, *id)
+	return nil
+}
+
+func (repo *InmemoryArticleRepository) FindByID(tx transaction.Transaction, id *model.ArticleID) (*model.Article, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	article, ok := repo.memory[*id]
+	if !ok {
+		return nil, domain.ErrNoSuchArticle
+	}
+	return article, nil
+}
+
+func (repo *InmemoryArticleRepository) FindByLinkName(tx transaction.Transaction, linkName string) (*model.Article, error) {
+	repo.RLock()
+	id, ok := repo.linkNames[linkName]
+	repo.RUnlock()
+
+	if !ok {
+		return nil, domain.ErrNoSuchArticle
+	}
+	return repo.FindByID(tx, &id)
+}
+
+func (repo *InmemoryArticleRepository) FindScheduledBefore(tx transaction.Transaction, t time.Time) ([]*model.ArticleID, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	ids := make([]*model.ArticleID, 0)
+	for _, article := range repo.memory {
+		if article.Status() == model.ArticleStatusScheduled && !article.PublishedAt().After(t) {
+			ids = append(ids, article.ID())
+		}
+	}
+	return ids, nil
+}
+
+func (repo *InmemoryArticleRepository) FindIDsByTag(tx transaction.Transaction, tag string) ([]*model.ArticleID, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	ids := make([]*model.ArticleID, 0)
+	for _, article := range repo.memory {
+		if article.Content().HasTag(tag) {
+			ids = append(ids, article.ID())
+		}
+	}
+	return ids, nil
+}
+
+func (repo *InmemoryArticleRepository) NextRevisionNumber(tx transaction.Transaction, id *model.ArticleID) (int, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	return len(repo.revisions[*id]) + 1, nil
+}
+
+func (repo *InmemoryArticleRepository) SaveRevision(tx transaction.Transaction, revision *model.ArticleRevision) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	id := *revision.ArticleID()
+	repo.revisions[id] = append(repo.revisions[id], revision)
+	return nil
+}
+
+func (repo *InmemoryArticleRepository) FindRevision(tx transaction.Transaction, id *model.ArticleID, number int) (*model.ArticleRevision, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	for _, revision := range repo.revisions[*id] {
+		if revision.Number() == number {
+			return revision, nil
+		}
+	}
+	return nil, domain.ErrNoSuchArticleRevision
+}
+
+func (repo *InmemoryArticleRepository) Begin(c context.Context, opts *transaction.Option) (transaction.Transaction, error) {
+	return transaction.Nop(), nil
+}
+
+func (repo *InmemoryArticleRepository) RunInTransaction(c context.Context, f func(tx transaction.Transaction) error, opts *transaction.Option) error {
+	tx, err := repo.Begin(c, opts)
+	if err != nil {
+		panic("unexpected error: " + err.Error())
+	}
+	defer tx.Commit()
+
+	return f(tx)
+}
+
+// abortingTransactionManager fails the commit with abort after running a transaction if abort is set
+type abortingTransactionManager struct {
+	transaction.Manager
+	abort error
+}
+
+func (m *abortingTransactionManager) RunInTransaction(c context.Context, f func(tx transaction.Transaction) error, opts *transaction.Option) error {
+	if err := m.Manager.RunInTransaction(c, f, opts); err != nil {
+		return err
+	}
+	return m.abort
+}
+
+type recordingArticleEventPublisher struct {
+	sync.Mutex
+	published []*model.ArticleID
+	deleted   []*model.ArticleID
+}
+
+func (pub *recordingArticleEventPublisher) NotifyArticlePublished(c context.Context, article *model.Article) error {
+	pub.Lock()
+	defer pub.Unlock()
+
+	pub.published = append(pub.published, article.ID())
+	return nil
+}
+
+func (pub *recordingArticleEventPublisher) NotifyArticleDeleted(c context.Context, article *model.Article) error {
+	pub.Lock()
+	defer pub.Unlock()
+
+	pub.deleted = append(pub.deleted, article.ID())
+	return nil
+}
+
+type InmemorySeriesRepository struct {
+	sync.RWMutex
+	memory map[model.SeriesID]*model.Series
+	nextID int
+}
+
+func NewInmemorySeriesRepository() *InmemorySeriesRepository {
+	return &InmemorySeriesRepository{memory: make(map[model.SeriesID]*model.Series)}
+}
+
+func (repo *InmemorySeriesRepository) NextID(tx transaction.Transaction, ownerID int64) (*model.SeriesID, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.nextID++
+	return model.NewSeriesID(strconv.Itoa(repo.nextID)), nil
+}
+
+func (repo *InmemorySeriesRepository) Save(tx transaction.Transaction, series *model.Series) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.memory[*series.ID()] = series
+	return nil
+}
+
+func (repo *InmemorySeriesRepository) Remove(tx transaction.Transaction, id *model.SeriesID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if _, ok := repo.memory[*id]; !ok {
+		return domain.ErrNoSuchSeries
+	}
+	delete(repo.memory, *id)
+	return nil
+}
+
+func (repo *InmemorySeriesRepository) FindByID(tx transaction.Transaction, id *model.SeriesID) (*model.Series, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	series, ok := repo.memory[*id]
+	if !ok {
+		return nil, domain.ErrNoSuchSeries
+	}
+	return series, nil
+}
+
+func (repo *InmemorySeriesRepository) FindByArticle(tx transaction.Transaction, id *model.ArticleID) (*model.Series, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	for _, series := range repo.memory {
+		if series.Contains(id) {
+			return series, nil
+		}
+	}
+	return nil, domain.ErrNoSuchSeries
+}
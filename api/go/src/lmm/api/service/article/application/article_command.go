@@ -2,6 +2,8 @@ package application
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"lmm/api/clock"
 	"lmm/api/pkg/transaction"
@@ -14,15 +16,24 @@ import (
 
 // ArticleCommandService is a command side application
 type ArticleCommandService struct {
-	articleRepository  model.ArticleRepository
-	transactionManager transaction.Manager
+	clock                 clock.Clock
+	articleRepository     model.ArticleRepository
+	articleEventPublisher model.ArticleEventPublisher
+	transactionManager    transaction.Manager
 }
 
 // NewArticleCommandService is a constructor of ArticleCommandService
-func NewArticleCommandService(articleRepository model.ArticleRepository, transactionManager transaction.Manager) *ArticleCommandService {
+func NewArticleCommandService(
+	clock clock.Clock,
+	articleRepository model.ArticleRepository,
+	articleEventPublisher model.ArticleEventPublisher,
+	transactionManager transaction.Manager,
+) *ArticleCommandService {
 	return &ArticleCommandService{
-		articleRepository:  articleRepository,
-		transactionManager: transactionManager,
+		clock:                 clock,
+		articleRepository:     articleRepository,
+		articleEventPublisher: articleEventPublisher,
+		transactionManager:    transactionManager,
 	}
 }
 
@@ -33,8 +44,31 @@ func (app *ArticleCommandService) PostNewArticle(c context.Context, cmd command.
 		return nil, errors.Wrap(err, "invalid article content")
 	}
 
+	// articles are published on posting unless a status or a publish time is specified
+	status := model.ArticleStatusPublished
+	if !cmd.PublishAt.IsZero() {
+		status = model.ArticleStatusDraft
+	}
+	if cmd.Status != "" {
+		status, err = model.NewArticleStatus(cmd.Status)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if status == model.ArticleStatusScheduled && cmd.PublishAt.IsZero() {
+		return nil, domain.ErrPublishTimeRequired
+	}
+
+	// published is notified after commit, so an aborted or retried transaction notifies nothing
+	var published *model.Article
 	err = app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
-		now := clock.Now()
+		published = nil
+		now := app.clock.Now()
+
+		createdAt := now
+		if !cmd.CreatedAt.IsZero() {
+			createdAt = cmd.CreatedAt
+		}
 
 		id, err = app.articleRepository.NextID(tx, cmd.AuthorID)
 		if err != nil {
@@ -43,14 +77,95 @@ func (app *ArticleCommandService) PostNewArticle(c context.Context, cmd command.
 
 		author := model.NewAuthor(cmd.AuthorID)
 
-		article := model.NewArticle(id, author, content, now, now)
+		var publishedAt time.Time
+		if status != model.ArticleStatusDraft {
+			publishedAt = createdAt
+		}
 
-		return app.articleRepository.Save(tx, article)
+		article := model.NewArticle(id, author, content, status, 1, createdAt, createdAt, publishedAt)
+
+		linkName := cmd.LinkName
+		if linkName == "" {
+			linkName, err = app.uniqueLinkName(tx, model.LinkNameFromTitle(content.Text().Title()))
+			if err != nil {
+				return err
+			}
+		} else if err := app.checkLinkNameAvailable(tx, article, linkName); err != nil {
+			return err
+		}
+
+		if err := article.ChangeLinkName(linkName); err != nil {
+			return errors.Wrap(err, "invalid article link name")
+		}
+
+		if !cmd.PublishAt.IsZero() {
+			if err := article.Schedule(cmd.PublishAt, now); err != nil {
+				return err
+			}
+		}
+
+		if err := app.articleRepository.Save(tx, article); err != nil {
+			return err
+		}
+
+		revision := model.NewArticleRevision(id, 1, content, author, createdAt)
+		if err := app.articleRepository.SaveRevision(tx, revision); err != nil {
+			return err
+		}
+
+		if article.Status() == model.ArticleStatusPublished {
+			published = article
+		}
+
+		return nil
 	}, nil)
+	if err != nil || published == nil {
+		return
+	}
 
+	if err = app.articleEventPublisher.NotifyArticlePublished(c, published); err != nil {
+		err = errors.Wrap(err, "failed to notify article published")
+	}
 	return
 }
 
+// uniqueLinkName finds an unused link name by adding a number suffix to base
+func (app *ArticleCommandService) uniqueLinkName(tx transaction.Transaction, base string) (string, error) {
+	for i := 1; ; i++ {
+		linkName := base
+		if i > 1 {
+			linkName = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		_, err := app.articleRepository.FindByLinkName(tx, linkName)
+		switch errors.Cause(err) {
+		case domain.ErrNoSuchArticle:
+			return linkName, nil
+		case nil:
+			continue
+		default:
+			return "", errors.Wrap(err, "failed to find article by link name")
+		}
+	}
+}
+
+// checkLinkNameAvailable returns error if linkName is used by another article,
+// including the old link names kept as aliases
+func (app *ArticleCommandService) checkLinkNameAvailable(tx transaction.Transaction, article *model.Article, linkName string) error {
+	found, err := app.articleRepository.FindByLinkName(tx, linkName)
+	switch errors.Cause(err) {
+	case domain.ErrNoSuchArticle:
+		return nil
+	case nil:
+		if found.ID().String() == article.ID().String() {
+			return nil
+		}
+		return domain.ErrArticleLinkNameAlreadyUsed
+	default:
+		return errors.Wrap(err, "failed to find article by link name")
+	}
+}
+
 // EditArticle command
 func (app *ArticleCommandService) EditArticle(c context.Context, cmd command.EditArticle) error {
 	articleID := model.NewArticleID(cmd.ArticleID)
@@ -66,14 +181,187 @@ func (app *ArticleCommandService) EditArticle(c context.Context, cmd command.Edi
 			return errors.Wrap(err, "article not found")
 		}
 
-		if article.Author().ID() != cmd.UserID {
+		if article.Author().ID() != cmd.UserID && !cmd.EditAny {
 			return domain.ErrNotArticleAuthor
 		}
 
-		if err := article.ChangeLinkName(cmd.LinkName); err != nil {
-			return errors.Wrap(err, "invalid article link name")
+		if cmd.Versions != nil {
+			if err := article.CheckVersion(cmd.Versions...); err != nil {
+				return err
+			}
+		}
+
+		if cmd.LinkName != "" && cmd.LinkName != article.LinkName() {
+			if err := app.checkLinkNameAvailable(tx, article, cmd.LinkName); err != nil {
+				return err
+			}
+			if err := article.ChangeLinkName(cmd.LinkName); err != nil {
+				return errors.Wrap(err, "invalid article link name")
+			}
+		}
+		if err := app.editContent(tx, article, content, cmd.UserID); err != nil {
+			return err
+		}
+
+		return app.articleRepository.Save(tx, article)
+	}, nil)
+}
+
+// RestoreArticleRevision command edits the article with the content of the old revision,
+// which is stored as a new revision
+func (app *ArticleCommandService) RestoreArticleRevision(c context.Context, cmd command.RestoreArticleRevision) error {
+	articleID := model.NewArticleID(cmd.ArticleID)
+
+	return app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		article, err := app.articleRepository.FindByID(tx, articleID)
+		if err != nil {
+			return errors.Wrap(err, "article not found")
+		}
+
+		if article.Author().ID() != cmd.UserID && !cmd.EditAny {
+			return domain.ErrNotArticleAuthor
+		}
+
+		revision, err := app.articleRepository.FindRevision(tx, article.ID(), cmd.RevisionNumber)
+		if err != nil {
+			return errors.Wrap(err, "revision not found")
+		}
+
+		if err := app.editContent(tx, article, revision.Content(), cmd.UserID); err != nil {
+			return err
+		}
+
+		return app.articleRepository.Save(tx, article)
+	}, nil)
+}
+
+// editContent edits article's content and stores the new content as a revision.
+// Articles posted before revisions were introduced have no revision,
+// so the content before editing is stored as their first revision
+func (app *ArticleCommandService) editContent(tx transaction.Transaction, article *model.Article, content *model.Content, editorID int64) error {
+	number, err := app.articleRepository.NextRevisionNumber(tx, article.ID())
+	if err != nil {
+		return errors.Wrap(err, "failed to get next revision number")
+	}
+
+	if number == 1 {
+		initial := model.NewArticleRevision(article.ID(), number, article.Content(), article.Author(), article.LastModified())
+		if err := app.articleRepository.SaveRevision(tx, initial); err != nil {
+			return err
+		}
+		number++
+	}
+
+	article.EditContent(content)
+
+	revision := model.NewArticleRevision(article.ID(), number, content, model.NewAuthor(editorID), app.clock.Now())
+
+	return app.articleRepository.SaveRevision(tx, revision)
+}
+
+// DeleteArticle command
+func (app *ArticleCommandService) DeleteArticle(c context.Context, cmd command.DeleteArticle) error {
+	articleID := model.NewArticleID(cmd.ArticleID)
+
+	var article *model.Article
+	err := app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) (err error) {
+		article, err = app.articleRepository.FindByID(tx, articleID)
+		if err != nil {
+			return errors.Wrap(err, "article not found")
+		}
+
+		if article.Author().ID() != cmd.UserID && !cmd.DeleteAny {
+			return domain.ErrNotArticleAuthor
+		}
+
+		return app.articleRepository.Remove(tx, article.ID())
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	// comments of the article are deleted by the comment service on this event
+	return app.articleEventPublisher.NotifyArticleDeleted(c, article)
+}
+
+// PublishArticle command
+func (app *ArticleCommandService) PublishArticle(c context.Context, cmd command.PublishArticle) error {
+	articleID := model.NewArticleID(cmd.ArticleID)
+
+	var published *model.Article
+	err := app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		published = nil
+
+		article, err := app.articleRepository.FindByID(tx, articleID)
+		if err != nil {
+			return errors.Wrap(err, "article not found")
+		}
+
+		if article.Author().ID() != cmd.UserID && !cmd.EditAny {
+			return domain.ErrNotArticleAuthor
+		}
+
+		wasPublic := article.IsReadableBy(0)
+
+		article.Publish(cmd.Unlisted, app.clock.Now())
+
+		if err := app.articleRepository.Save(tx, article); err != nil {
+			return err
+		}
+
+		if !wasPublic {
+			published = article
+		}
+
+		return nil
+	}, nil)
+	if err != nil || published == nil {
+		return err
+	}
+
+	if err := app.articleEventPublisher.NotifyArticlePublished(c, published); err != nil {
+		return errors.Wrap(err, "failed to notify article published")
+	}
+	return nil
+}
+
+// UnpublishArticle command
+func (app *ArticleCommandService) UnpublishArticle(c context.Context, cmd command.UnpublishArticle) error {
+	articleID := model.NewArticleID(cmd.ArticleID)
+
+	return app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		article, err := app.articleRepository.FindByID(tx, articleID)
+		if err != nil {
+			return errors.Wrap(err, "article not found")
+		}
+
+		if article.Author().ID() != cmd.UserID && !cmd.EditAny {
+			return domain.ErrNotArticleAuthor
+		}
+
+		article.Unpublish()
+
+		return app.articleRepository.Save(tx, article)
+	}, nil)
+}
+
+// ScheduleArticle command
+func (app *ArticleCommandService) ScheduleArticle(c context.Context, cmd command.ScheduleArticle) error {
+	articleID := model.NewArticleID(cmd.ArticleID)
+
+	return app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		article, err := app.articleRepository.FindByID(tx, articleID)
+		if err != nil {
+			return errors.Wrap(err, "article not found")
+		}
+
+		if article.Author().ID() != cmd.UserID && !cmd.EditAny {
+			return domain.ErrNotArticleAuthor
+		}
+
+		if err := article.Schedule(cmd.PublishAt, app.clock.Now()); err != nil {
+			return err
 		}
-		article.EditContent(content)
 
 		return app.articleRepository.Save(tx, article)
 	}, nil)
@@ -0,0 +1,424 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lmm/api/clock"
+	clockTesting "lmm/api/clock/testing"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleLinkName(t *testing.T) {
+	c := context.Background()
+
+	repo := NewInmemoryArticleRepository()
+	app := NewArticleCommandService(clock.DefaultClock, repo, &recordingArticleEventPublisher{}, repo)
+
+	postArticle := func(title, linkName string) string {
+		id, err := app.PostNewArticle(c, command.PostArticle{
+			AuthorID: 1,
+			LinkName: linkName,
+			Title:    title,
+			Body:     "body",
+			Tags:     []string{},
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		article, err := repo.FindByID(nil, id)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return article.LinkName()
+	}
+
+	t.Run("GeneratedFromTitle", func(t *testing.T) {
+		assert.Equal(t, "hello-world", postArticle("Hello, World!", ""))
+		assert.Equal(t, "hello-world-2", postArticle("Hello World", ""))
+		assert.Equal(t, "article", postArticle("_-_", ""))
+		assert.Equal(t, "search-article", postArticle("Search", ""))
+	})
+
+	t.Run("Specified", func(t *testing.T) {
+		assert.Equal(t, "my-article", postArticle("title", "my-article"))
+
+		_, err := app.PostNewArticle(c, command.PostArticle{
+			AuthorID: 1,
+			LinkName: "my-article",
+			Title:    "title",
+			Tags:     []string{},
+		})
+		assert.Equal(t, domain.ErrArticleLinkNameAlreadyUsed, errors.Cause(err))
+
+		_, err = app.PostNewArticle(c, command.PostArticle{
+			AuthorID: 1,
+			LinkName: "My Article",
+			Title:    "title",
+			Tags:     []string{},
+		})
+		assert.Equal(t, domain.ErrInvalidAliasArticleID, errors.Cause(err))
+
+		// reserved for GET /v1/articles/search
+		_, err = app.PostNewArticle(c, command.PostArticle{
+			AuthorID: 1,
+			LinkName: "search",
+			Title:    "title",
+			Tags:     []string{},
+		})
+		assert.Equal(t, domain.ErrInvalidAliasArticleID, errors.Cause(err))
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		old, err := repo.FindByLinkName(nil, "hello-world")
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		edit := command.EditArticle{
+			UserID:    1,
+			ArticleID: old.ID().String(),
+			LinkName:  "hello-again",
+			Title:     "title",
+			Tags:      []string{},
+		}
+		assert.NoError(t, app.EditArticle(c, edit))
+
+		article, err := repo.FindByLinkName(nil, "hello-again")
+		assert.NoError(t, err)
+		assert.Equal(t, old.ID(), article.ID())
+
+		// the old link name is kept as an alias
+		alias, err := repo.FindByLinkName(nil, "hello-world")
+		assert.NoError(t, err)
+		assert.Equal(t, old.ID(), alias.ID())
+
+		edit.LinkName = "my-article"
+		assert.Equal(t, domain.ErrArticleLinkNameAlreadyUsed, errors.Cause(app.EditArticle(c, edit)))
+	})
+}
+
+func TestArticleRevisions(t *testing.T) {
+	c := context.Background()
+
+	repo := NewInmemoryArticleRepository()
+	app := NewArticleCommandService(clock.DefaultClock, repo, &recordingArticleEventPublisher{}, repo)
+
+	id, err := app.PostNewArticle(c, command.PostArticle{
+		AuthorID: 1,
+		Title:    "first",
+		Body:     "body",
+		Tags:     []string{"tag"},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.NoError(t, app.EditArticle(c, command.EditArticle{
+		UserID:    1,
+		ArticleID: id.String(),
+		Title:     "second",
+		Body:      "edited body",
+		Tags:      []string{},
+	}))
+
+	revision, err := repo.FindRevision(nil, id, 2)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "second", revision.Content().Text().Title())
+	assert.Equal(t, int64(1), revision.Editor().ID())
+
+	t.Run("Restore", func(t *testing.T) {
+		assert.NoError(t, app.RestoreArticleRevision(c, command.RestoreArticleRevision{
+			UserID:         1,
+			ArticleID:      id.String(),
+			RevisionNumber: 1,
+		}))
+
+		article, err := repo.FindByID(nil, id)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Equal(t, "first", article.Content().Text().Title())
+		assert.Equal(t, "body", article.Content().Text().Body())
+		assert.Len(t, article.Content().Tags(), 1)
+
+		number, err := repo.NextRevisionNumber(nil, id)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, number)
+	})
+
+	t.Run("NoSuchRevision", func(t *testing.T) {
+		err := app.RestoreArticleRevision(c, command.RestoreArticleRevision{
+			UserID:         1,
+			ArticleID:      id.String(),
+			RevisionNumber: 10,
+		})
+		assert.Equal(t, domain.ErrNoSuchArticleRevision, errors.Cause(err))
+	})
+
+	t.Run("NotAuthor", func(t *testing.T) {
+		err := app.RestoreArticleRevision(c, command.RestoreArticleRevision{
+			UserID:         2,
+			ArticleID:      id.String(),
+			RevisionNumber: 1,
+		})
+		assert.Equal(t, domain.ErrNotArticleAuthor, errors.Cause(err))
+	})
+}
+
+func TestEditAndDeleteAnyArticle(t *testing.T) {
+	c := context.Background()
+
+	repo := NewInmemoryArticleRepository()
+	pub := &recordingArticleEventPublisher{}
+	app := NewArticleCommandService(clock.DefaultClock, repo, pub, repo)
+
+	id, err := app.PostNewArticle(c, command.PostArticle{
+		AuthorID: 1,
+		Title:    "title",
+		Body:     "body",
+		Tags:     []string{},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	edit := command.EditArticle{
+		UserID:    2,
+		ArticleID: id.String(),
+		Title:     "edited",
+		Body:      "edited by an editor",
+		Tags:      []string{},
+	}
+	assert.Equal(t, domain.ErrNotArticleAuthor, errors.Cause(app.EditArticle(c, edit)))
+
+	edit.EditAny = true
+	assert.NoError(t, app.EditArticle(c, edit))
+
+	article, err := repo.FindByID(nil, id)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "edited", article.Content().Text().Title())
+	assert.Equal(t, int64(1), article.Author().ID())
+
+	revision, err := repo.FindRevision(nil, id, 2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), revision.Editor().ID())
+	}
+
+	unpublish := command.UnpublishArticle{UserID: 2, ArticleID: id.String()}
+	assert.Equal(t, domain.ErrNotArticleAuthor, errors.Cause(app.UnpublishArticle(c, unpublish)))
+
+	unpublish.EditAny = true
+	assert.NoError(t, app.UnpublishArticle(c, unpublish))
+
+	schedule := command.ScheduleArticle{UserID: 2, ArticleID: id.String(), PublishAt: time.Now().Add(time.Hour)}
+	assert.Equal(t, domain.ErrNotArticleAuthor, errors.Cause(app.ScheduleArticle(c, schedule)))
+
+	schedule.EditAny = true
+	assert.NoError(t, app.ScheduleArticle(c, schedule))
+
+	publish := command.PublishArticle{UserID: 2, ArticleID: id.String()}
+	assert.Equal(t, domain.ErrNotArticleAuthor, errors.Cause(app.PublishArticle(c, publish)))
+
+	publish.EditAny = true
+	assert.NoError(t, app.PublishArticle(c, publish))
+
+	remove := command.DeleteArticle{UserID: 2, ArticleID: id.String()}
+	assert.Equal(t, domain.ErrNotArticleAuthor, errors.Cause(app.DeleteArticle(c, remove)))
+	assert.Empty(t, pub.deleted)
+
+	remove.DeleteAny = true
+	assert.NoError(t, app.DeleteArticle(c, remove))
+	assert.Equal(t, []*model.ArticleID{id}, pub.deleted)
+
+	_, err = repo.FindByID(nil, id)
+	assert.Equal(t, domain.ErrNoSuchArticle, errors.Cause(err))
+}
+
+func TestEditArticleVersion(t *testing.T) {
+	c := context.Background()
+
+	repo := NewInmemoryArticleRepository()
+	app := NewArticleCommandService(clock.DefaultClock, repo, &recordingArticleEventPublisher{}, repo)
+
+	id, err := app.PostNewArticle(c, command.PostArticle{
+		AuthorID: 1,
+		Title:    "title",
+		Body:     "body",
+		Tags:     []string{},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	article, err := repo.FindByID(nil, id)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	version := article.Version()
+
+	edit := func(versions []uint) error {
+		return app.EditArticle(c, command.EditArticle{
+			UserID:    1,
+			ArticleID: id.String(),
+			Title:     "title",
+			Body:      "edited",
+			Tags:      []string{},
+			Versions:  versions,
+		})
+	}
+
+	assert.NoError(t, edit([]uint{version}))
+
+	t.Run("Conflict", func(t *testing.T) {
+		assert.Equal(t, domain.ErrArticleVersionConflict, errors.Cause(edit([]uint{version})))
+		assert.Equal(t, domain.ErrArticleVersionConflict, errors.Cause(edit([]uint{})))
+	})
+
+	t.Run("AnyOf", func(t *testing.T) {
+		assert.NoError(t, edit([]uint{version, version + 1}))
+	})
+
+	t.Run("NotChecked", func(t *testing.T) {
+		assert.NoError(t, edit(nil))
+	})
+}
+
+func TestPostNewArticleCreatedAt(t *testing.T) {
+	c := context.Background()
+
+	repo := NewInmemoryArticleRepository()
+	app := NewArticleCommandService(clock.DefaultClock, repo, &recordingArticleEventPublisher{}, repo)
+
+	createdAt := time.Date(2016, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	id, err := app.PostNewArticle(c, command.PostArticle{
+		AuthorID:  1,
+		Title:     "imported",
+		Body:      "body",
+		Tags:      []string{"old"},
+		CreatedAt: createdAt,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	article, err := repo.FindByID(nil, id)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.True(t, createdAt.Equal(article.CreatedAt()))
+	assert.True(t, createdAt.Equal(article.LastModified()))
+	assert.True(t, createdAt.Equal(article.PublishedAt()))
+
+	revision, err := repo.FindRevision(nil, id, 1)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.True(t, createdAt.Equal(revision.CreatedAt()))
+}
+
+func TestPostScheduledArticle(t *testing.T) {
+	c := context.Background()
+
+	now := time.Date(2019, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	repo := NewInmemoryArticleRepository()
+	app := NewArticleCommandService(clockTesting.NewClock(now), repo, &recordingArticleEventPublisher{}, repo)
+
+	post := func(publishAt time.Time) (*model.ArticleID, error) {
+		return app.PostNewArticle(c, command.PostArticle{
+			AuthorID:  1,
+			Title:     "scheduled",
+			Body:      "body",
+			Tags:      []string{},
+			Status:    "scheduled",
+			PublishAt: publishAt,
+		})
+	}
+
+	t.Run("WithoutPublishTime", func(t *testing.T) {
+		_, err := post(time.Time{})
+		assert.Equal(t, domain.ErrPublishTimeRequired, errors.Cause(err))
+	})
+
+	t.Run("PastPublishTime", func(t *testing.T) {
+		_, err := post(now)
+		assert.Equal(t, domain.ErrInvalidPublishTime, errors.Cause(err))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		id, err := post(now.Add(time.Hour))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		article, err := repo.FindByID(nil, id)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Equal(t, model.ArticleStatusScheduled, article.Status())
+		assert.Equal(t, now.Add(time.Hour), article.PublishedAt())
+		assert.Equal(t, now, article.CreatedAt())
+	})
+}
+
+func TestNotifyArticlePublishedAfterCommit(t *testing.T) {
+	c := context.Background()
+
+	repo := NewInmemoryArticleRepository()
+	txManager := &abortingTransactionManager{Manager: repo}
+	pub := &recordingArticleEventPublisher{}
+	app := NewArticleCommandService(clock.DefaultClock, repo, pub, txManager)
+
+	post := command.PostArticle{AuthorID: 1, Title: "title", Body: "body", Tags: []string{}}
+
+	t.Run("Aborted", func(t *testing.T) {
+		txManager.abort = errors.New("aborted")
+		defer func() { txManager.abort = nil }()
+
+		_, err := app.PostNewArticle(c, post)
+		assert.Equal(t, txManager.abort, err)
+
+		post.Status = model.ArticleStatusDraft.String()
+		txManager.abort = nil
+		id, err := app.PostNewArticle(c, post)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		txManager.abort = errors.New("aborted")
+		err = app.PublishArticle(c, command.PublishArticle{UserID: 1, ArticleID: id.String()})
+		assert.Equal(t, txManager.abort, err)
+
+		assert.Empty(t, pub.published)
+	})
+
+	t.Run("Committed", func(t *testing.T) {
+		post.Status = ""
+		published, err := app.PostNewArticle(c, post)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		post.Status = model.ArticleStatusDraft.String()
+		draft, err := app.PostNewArticle(c, post)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.NoError(t, app.PublishArticle(c, command.PublishArticle{UserID: 1, ArticleID: draft.String()}))
+
+		assert.Equal(t, []*model.ArticleID{published, draft}, pub.published)
+	})
+}
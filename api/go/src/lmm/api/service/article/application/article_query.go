@@ -7,25 +7,37 @@ import (
 
 	"lmm/api/pkg/transaction"
 	"lmm/api/service/article/application/query"
+	"lmm/api/service/article/domain"
 	"lmm/api/service/article/domain/model"
 )
 
 // ArticleQueryService is a query side application
 type ArticleQueryService struct {
-	viewer    model.ArticleViewer
-	txManager transaction.Manager
+	viewer      model.ArticleViewer
+	renderer    model.ArticleRenderer
+	searcher    model.ArticleSearcher
+	recommender model.ArticleRecommender
+	txManager   transaction.Manager
 }
 
 // NewArticleQueryService is a constructor of ArticleQueryService
-func NewArticleQueryService(viewer model.ArticleViewer, txManager transaction.Manager) *ArticleQueryService {
-	return &ArticleQueryService{viewer: viewer, txManager: txManager}
+func NewArticleQueryService(
+	viewer model.ArticleViewer,
+	renderer model.ArticleRenderer,
+	searcher model.ArticleSearcher,
+	recommender model.ArticleRecommender,
+	txManager transaction.Manager,
+) *ArticleQueryService {
+	return &ArticleQueryService{viewer: viewer, renderer: renderer, searcher: searcher, recommender: recommender, txManager: txManager}
 }
 
 // ListArticlesByPage is used for listing articles on article index page
 func (app *ArticleQueryService) ListArticlesByPage(c context.Context, q query.ListArticleQuery) (articles *model.ArticleListView, err error) {
 	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
 		articles, err = app.viewer.ViewArticles(tx, q.PerPage, q.Page, &model.ArticlesFilter{
-			Tag: q.Tag,
+			Tag:           q.Tag,
+			AuthorID:      q.AuthorID,
+			PublishedOnly: q.PublishedOnly,
 		})
 
 		return err
@@ -34,15 +46,167 @@ func (app *ArticleQueryService) ListArticlesByPage(c context.Context, q query.Li
 	return
 }
 
-func (app *ArticleQueryService) ArticleByID(c context.Context, linkName string) (article *model.Article, err error) {
+// ListArticlesByCursor is used for listing articles page by page from the cursor
+func (app *ArticleQueryService) ListArticlesByCursor(c context.Context, q query.ListArticleQuery) (articles *model.ArticleListView, err error) {
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		articles, err = app.viewer.ViewArticlesByCursor(tx, q.PerPage, q.Cursor, &model.ArticlesFilter{
+			Tag:           q.Tag,
+			AuthorID:      q.AuthorID,
+			PublishedOnly: q.PublishedOnly,
+		})
+
+		return err
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
+
+// LatestArticles gets the latest published articles with their contents, filtered by tag if not empty
+func (app *ArticleQueryService) LatestArticles(c context.Context, tag string, count int) (articles []*model.Article, err error) {
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		view, err := app.viewer.ViewArticles(tx, count, 1, &model.ArticlesFilter{Tag: tag})
+		if err != nil {
+			return err
+		}
+
+		articles = make([]*model.Article, len(view.Items()), len(view.Items()))
+		for i, item := range view.Items() {
+			articles[i], err = app.viewer.ViewArticle(tx, item.ID().String())
+			if err != nil {
+				return errors.Wrap(err, "failed to view listed article")
+			}
+		}
+
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
+
+// SearchArticles searches published articles by full text
+func (app *ArticleQueryService) SearchArticles(c context.Context, q query.SearchArticleQuery) (*model.ArticleSearchView, error) {
+	view, err := app.searcher.SearchArticles(q.Query, q.PerPage, q.Page)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search articles")
+	}
+	return view, nil
+}
+
+// RelatedArticles lists published articles related to the article readable by the reader
+func (app *ArticleQueryService) RelatedArticles(c context.Context, linkName string, readerID int64, count int) ([]*model.ArticleListViewItem, error) {
+	article, err := app.ArticleByID(c, linkName, readerID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := app.recommender.RelatedArticles(article, count)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recommend related articles")
+	}
+	return items, nil
+}
+
+// ArticleByID gets the article readable by the reader, readerID is 0 if the reader is anonymous
+func (app *ArticleQueryService) ArticleByID(c context.Context, linkName string, readerID int64) (article *model.Article, err error) {
 	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
 		article, err = app.viewer.ViewArticle(tx, linkName)
+		if err != nil {
+			return err
+		}
+
+		if !article.IsReadableBy(readerID) {
+			article = nil
+			return domain.ErrNoSuchArticle
+		}
+
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
+
+// RenderArticle renders the article's body into HTML with its table of contents
+func (app *ArticleQueryService) RenderArticle(article *model.Article) (*model.RenderedText, error) {
+	rendered, err := app.renderer.Render(article)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render article")
+	}
+	return rendered, nil
+}
+
+// ArticleRevisions lists all revisions of the article from the latest one,
+// only the author is allowed to view them unless editAny
+func (app *ArticleQueryService) ArticleRevisions(c context.Context, articleID string, readerID int64, editAny bool) (revisions []*model.ArticleRevision, err error) {
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		article, err := app.authorArticle(tx, articleID, readerID, editAny)
+		if err != nil {
+			return err
+		}
+
+		revisions, err = app.viewer.ViewArticleRevisions(tx, article.ID())
+
+		return err
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
+
+// ArticleRevision gets the revision of the article, only the author is allowed to view it unless editAny
+func (app *ArticleQueryService) ArticleRevision(c context.Context, articleID string, number int, readerID int64, editAny bool) (revision *model.ArticleRevision, err error) {
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		article, err := app.authorArticle(tx, articleID, readerID, editAny)
+		if err != nil {
+			return err
+		}
+
+		revision, err = app.viewer.ViewArticleRevision(tx, article.ID(), number)
+
 		return err
 	}, &transaction.Option{ReadOnly: true})
 
 	return
 }
 
+// ArticleRevisionDiff computes the line diff from one revision to another of the article
+func (app *ArticleQueryService) ArticleRevisionDiff(c context.Context, articleID string, from, to int, readerID int64, editAny bool) (lines []*model.DiffLine, err error) {
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		article, err := app.authorArticle(tx, articleID, readerID, editAny)
+		if err != nil {
+			return err
+		}
+
+		fromRevision, err := app.viewer.ViewArticleRevision(tx, article.ID(), from)
+		if err != nil {
+			return err
+		}
+
+		toRevision, err := app.viewer.ViewArticleRevision(tx, article.ID(), to)
+		if err != nil {
+			return err
+		}
+
+		lines = model.DiffArticleRevisions(fromRevision, toRevision)
+
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
+
+// authorArticle gets the article only if the reader is its author or allowed to edit any article
+func (app *ArticleQueryService) authorArticle(tx transaction.Transaction, articleID string, readerID int64, editAny bool) (*model.Article, error) {
+	article, err := app.viewer.ViewArticle(tx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.Author().ID() != readerID && !editAny {
+		return nil, domain.ErrNotArticleAuthor
+	}
+
+	return article, nil
+}
+
 // AllArticleTags gets all article tags
 func (app *ArticleQueryService) AllArticleTags(c context.Context) (tags []*model.TagView, err error) {
 	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"lmm/api/clock"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// ArticlePublishScheduler publishes scheduled articles when their publish time comes
+type ArticlePublishScheduler struct {
+	clock                 clock.Clock
+	articleRepository     model.ArticleRepository
+	articleEventPublisher model.ArticleEventPublisher
+	transactionManager    transaction.Manager
+}
+
+// NewArticlePublishScheduler is a constructor of ArticlePublishScheduler
+func NewArticlePublishScheduler(
+	clock clock.Clock,
+	articleRepository model.ArticleRepository,
+	articleEventPublisher model.ArticleEventPublisher,
+	transactionManager transaction.Manager,
+) *ArticlePublishScheduler {
+	return &ArticlePublishScheduler{
+		clock:                 clock,
+		articleRepository:     articleRepository,
+		articleEventPublisher: articleEventPublisher,
+		transactionManager:    transactionManager,
+	}
+}
+
+// Run publishes due articles every interval until c is done
+func (s *ArticlePublishScheduler) Run(c context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Done():
+			return c.Err()
+		case <-ticker.C:
+			if _, err := s.PublishDueArticles(c); err != nil {
+				log.Printf("failed to publish scheduled articles: %s", err)
+			}
+		}
+	}
+}
+
+// PublishDueArticles publishes all scheduled articles whose publish time has passed,
+// returns the number of articles published
+func (s *ArticlePublishScheduler) PublishDueArticles(c context.Context) (int, error) {
+	now := s.clock.Now()
+
+	var ids []*model.ArticleID
+	err := s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) (err error) {
+		ids, err = s.articleRepository.FindScheduledBefore(tx, now)
+		return err
+	}, &transaction.Option{ReadOnly: true})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find scheduled articles")
+	}
+
+	published := 0
+	for _, id := range ids {
+		ok, err := s.publishIfDue(c, id, now)
+		if err != nil {
+			return published, errors.Wrapf(err, "failed to publish article %s", id.String())
+		}
+		if ok {
+			published++
+		}
+	}
+
+	return published, nil
+}
+
+func (s *ArticlePublishScheduler) publishIfDue(c context.Context, id *model.ArticleID, now time.Time) (ok bool, err error) {
+	var article *model.Article
+	err = s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) (err error) {
+		article, err = s.articleRepository.FindByID(tx, id)
+		if err != nil {
+			return err
+		}
+
+		// the article may have been rescheduled or unpublished since found
+		if ok = article.PublishIfDue(now); !ok {
+			return nil
+		}
+
+		return s.articleRepository.Save(tx, article)
+	}, nil)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	// notified after commit, so a rolled back publish is never seen by subscribers
+	return true, s.articleEventPublisher.NotifyArticlePublished(c, article)
+}
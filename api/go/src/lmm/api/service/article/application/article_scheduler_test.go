@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clockTesting "lmm/api/clock/testing"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticlePublishScheduler(t *testing.T) {
+	c := context.Background()
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clockTesting.NewClock(now)
+
+	repo := NewInmemoryArticleRepository()
+	pub := &recordingArticleEventPublisher{}
+	scheduler := NewArticlePublishScheduler(clock, repo, pub, repo)
+
+	newScheduledArticle := func(publishAt time.Time) *model.Article {
+		id, _ := repo.NextID(nil, 1)
+		content, err := model.NewContent("title", "body", []string{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		article := model.NewArticle(id, model.NewAuthor(1), content, model.ArticleStatusDraft, 1, now, now, time.Time{})
+		if err := article.Schedule(publishAt, now); err != nil {
+			t.Fatal(err)
+		}
+		repo.Save(nil, article)
+		return article
+	}
+
+	soon := newScheduledArticle(now.Add(time.Hour))
+	later := newScheduledArticle(now.Add(24 * time.Hour))
+
+	t.Run("NothingDue", func(t *testing.T) {
+		n, err := scheduler.PublishDueArticles(c)
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Empty(t, pub.published)
+	})
+
+	t.Run("OneDue", func(t *testing.T) {
+		clock.Add(time.Hour)
+
+		n, err := scheduler.PublishDueArticles(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, []*model.ArticleID{soon.ID()}, pub.published)
+
+		assert.Equal(t, model.ArticleStatusPublished, soon.Status())
+		assert.Equal(t, now.Add(time.Hour), soon.PublishedAt())
+		assert.Equal(t, model.ArticleStatusScheduled, later.Status())
+	})
+
+	t.Run("Unpublished", func(t *testing.T) {
+		later.Unpublish()
+		clock.Add(24 * time.Hour)
+
+		n, err := scheduler.PublishDueArticles(c)
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Equal(t, model.ArticleStatusDraft, later.Status())
+	})
+}
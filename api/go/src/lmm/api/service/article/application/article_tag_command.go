@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// tagBatchSize is the number of articles rewritten in a transaction,
+// which is kept small because each article is an entity group
+var tagBatchSize = 10
+
+// RenameArticleTag command renames the tag on all articles,
+// the new name must not be used by any article
+func (app *ArticleCommandService) RenameArticleTag(c context.Context, cmd command.RenameArticleTag) error {
+	newTag, err := model.NewTag(cmd.NewName, 1)
+	if err != nil {
+		return err
+	}
+	if newTag.Name() == cmd.Tag {
+		return domain.ErrSameArticleTag
+	}
+
+	var used []*model.ArticleID
+	if err := app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) (err error) {
+		used, err = app.articleRepository.FindIDsByTag(tx, newTag.Name())
+		return err
+	}, &transaction.Option{ReadOnly: true}); err != nil {
+		return err
+	}
+	if len(used) > 0 {
+		return domain.ErrArticleTagAlreadyUsed
+	}
+
+	return app.replaceArticleTag(c, cmd.UserID, cmd.Tag, newTag.Name())
+}
+
+// MergeArticleTag command replaces the tag with another one on all articles,
+// articles tagged by both of them keep only the latter one
+func (app *ArticleCommandService) MergeArticleTag(c context.Context, cmd command.MergeArticleTag) error {
+	into, err := model.NewTag(cmd.Into, 1)
+	if err != nil {
+		return err
+	}
+	if into.Name() == cmd.Tag {
+		return domain.ErrSameArticleTag
+	}
+
+	return app.replaceArticleTag(c, cmd.UserID, cmd.Tag, into.Name())
+}
+
+// DeleteArticleTag command removes the tag from all articles
+func (app *ArticleCommandService) DeleteArticleTag(c context.Context, cmd command.DeleteArticleTag) error {
+	return app.replaceArticleTag(c, cmd.UserID, cmd.Tag, "")
+}
+
+// replaceArticleTag replaces tag with newName, or removes it if newName is empty, on all articles tagged by it.
+// Articles are rewritten in batches of transactions, so it can be run again to finish the rest if failed halfway
+func (app *ArticleCommandService) replaceArticleTag(c context.Context, editorID int64, tag, newName string) error {
+	var ids []*model.ArticleID
+	if err := app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) (err error) {
+		ids, err = app.articleRepository.FindIDsByTag(tx, tag)
+		return err
+	}, &transaction.Option{ReadOnly: true}); err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return domain.ErrNoSuchArticleTag
+	}
+
+	for begin := 0; begin < len(ids); begin += tagBatchSize {
+		end := begin + tagBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		if err := app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+			for _, id := range ids[begin:end] {
+				article, err := app.articleRepository.FindByID(tx, id)
+				if err != nil {
+					return errors.Wrap(err, "article not found")
+				}
+
+				// the article may have been edited since its id was found
+				if !article.Content().HasTag(tag) {
+					continue
+				}
+
+				content, err := article.Content().ReplaceTag(tag, newName)
+				if err != nil {
+					return errors.Wrap(err, "invalid article content")
+				}
+
+				if err := app.editContent(tx, article, content, editorID); err != nil {
+					return err
+				}
+
+				if err := app.articleRepository.Save(tx, article); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil); err != nil {
+			return errors.Wrapf(err, "failed to replace tag %s", tag)
+		}
+	}
+
+	return nil
+}
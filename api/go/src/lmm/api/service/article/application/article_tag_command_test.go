@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"lmm/api/clock"
+	_ "lmm/api/clock/testing"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleTagCommands(t *testing.T) {
+	c := context.Background()
+
+	defer func(size int) { tagBatchSize = size }(tagBatchSize)
+	tagBatchSize = 2
+
+	repo := NewInmemoryArticleRepository()
+	app := NewArticleCommandService(clock.DefaultClock, repo, &recordingArticleEventPublisher{}, repo)
+
+	postArticle := func(tags ...string) *model.ArticleID {
+		id, err := app.PostNewArticle(c, command.PostArticle{
+			AuthorID: 1,
+			Title:    "title",
+			Body:     "body",
+			Tags:     tags,
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return id
+	}
+
+	tagsOf := func(id *model.ArticleID) []string {
+		article, err := repo.FindByID(nil, id)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		names := make([]string, 0)
+		for _, tag := range article.Content().Tags() {
+			names = append(names, tag.Name())
+		}
+		return names
+	}
+
+	a := postArticle("golang", "web")
+	b := postArticle("web", "go")
+	d := postArticle("golang")
+
+	t.Run("Rename", func(t *testing.T) {
+		assert.NoError(t, app.RenameArticleTag(c, command.RenameArticleTag{UserID: 2, Tag: "golang", NewName: "Golang"}))
+		assert.Equal(t, []string{"Golang", "web"}, tagsOf(a))
+		assert.Equal(t, []string{"Golang"}, tagsOf(d))
+
+		revisions := repo.revisions[*a]
+		if assert.Len(t, revisions, 2) {
+			assert.Equal(t, int64(2), revisions[1].Editor().ID())
+		}
+
+		err := app.RenameArticleTag(c, command.RenameArticleTag{UserID: 2, Tag: "Golang", NewName: "web"})
+		assert.Equal(t, domain.ErrArticleTagAlreadyUsed, errors.Cause(err))
+
+		err = app.RenameArticleTag(c, command.RenameArticleTag{UserID: 2, Tag: "Golang", NewName: "go/lang"})
+		assert.Equal(t, domain.ErrInvalidTagName, errors.Cause(err))
+
+		err = app.RenameArticleTag(c, command.RenameArticleTag{UserID: 2, Tag: "nothing", NewName: "something"})
+		assert.Equal(t, domain.ErrNoSuchArticleTag, errors.Cause(err))
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		assert.NoError(t, app.MergeArticleTag(c, command.MergeArticleTag{UserID: 2, Tag: "go", Into: "Golang"}))
+		assert.Equal(t, []string{"web", "Golang"}, tagsOf(b))
+
+		assert.NoError(t, app.MergeArticleTag(c, command.MergeArticleTag{UserID: 2, Tag: "web", Into: "Golang"}))
+		assert.Equal(t, []string{"Golang"}, tagsOf(a))
+		assert.Equal(t, []string{"Golang"}, tagsOf(b))
+
+		err := app.MergeArticleTag(c, command.MergeArticleTag{UserID: 2, Tag: "Golang", Into: "Golang"})
+		assert.Equal(t, domain.ErrSameArticleTag, errors.Cause(err))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, app.DeleteArticleTag(c, command.DeleteArticleTag{UserID: 2, Tag: "Golang"}))
+		assert.Empty(t, tagsOf(a))
+		assert.Empty(t, tagsOf(b))
+		assert.Empty(t, tagsOf(d))
+
+		err := app.DeleteArticleTag(c, command.DeleteArticleTag{UserID: 2, Tag: "Golang"})
+		assert.Equal(t, domain.ErrNoSuchArticleTag, errors.Cause(err))
+	})
+}
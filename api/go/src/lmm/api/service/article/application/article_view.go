@@ -0,0 +1,252 @@
+package application
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"lmm/api/clock"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// maxPopularWindow is how far views are looked back for the ranking
+	maxPopularWindow = 90 * 24 * time.Hour
+
+	// popularTotalsTTL is how long total views are reused for the ranking,
+	// since summing them up reads every counter shard in the window
+	popularTotalsTTL = 10 * time.Minute
+
+	// finalFlushTimeout limits the flush on stopping
+	finalFlushTimeout = 10 * time.Second
+)
+
+type viewTotals struct {
+	views    map[model.ArticleID]int64
+	expireAt time.Time
+}
+
+type articleViewKey struct {
+	articleID model.ArticleID
+	day       time.Time
+}
+
+// ArticleViewCounter counts views of articles.
+// Views are buffered in memory and flushed to the repository in the background so that reading is not slowed down
+type ArticleViewCounter struct {
+	clock              clock.Clock
+	viewRepository     model.ArticleViewRepository
+	viewer             model.ArticleViewer
+	transactionManager transaction.Manager
+
+	// window is the period in which views of an article by the same client are counted once
+	window time.Duration
+
+	mutex   sync.Mutex
+	seen    map[string]time.Time
+	pending map[articleViewKey]int64
+
+	totalsMutex sync.Mutex
+	totals      map[time.Time]*viewTotals
+}
+
+// NewArticleViewCounter is a constructor of ArticleViewCounter
+func NewArticleViewCounter(
+	clock clock.Clock,
+	viewRepository model.ArticleViewRepository,
+	viewer model.ArticleViewer,
+	transactionManager transaction.Manager,
+	window time.Duration,
+) *ArticleViewCounter {
+	return &ArticleViewCounter{
+		clock:              clock,
+		viewRepository:     viewRepository,
+		viewer:             viewer,
+		transactionManager: transactionManager,
+		window:             window,
+		seen:               make(map[string]time.Time),
+		pending:            make(map[articleViewKey]int64),
+		totals:             make(map[time.Time]*viewTotals),
+	}
+}
+
+// RecordView buffers a view of the public article by the client, returns false if the view is not counted.
+// Views by the author and views by the same client in the window are not counted
+func (s *ArticleViewCounter) RecordView(article *model.Article, readerID int64, clientID string) bool {
+	if article.Author().ID() == readerID || !article.IsReadableBy(0) {
+		return false
+	}
+
+	now := s.clock.Now()
+	seenKey := clientID + "\n" + article.ID().String()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if last, ok := s.seen[seenKey]; ok && now.Sub(last) < s.window {
+		return false
+	}
+	s.seen[seenKey] = now
+	s.pending[articleViewKey{articleID: *article.ID(), day: model.ViewDay(now)}]++
+
+	return true
+}
+
+// Run flushes buffered views every interval until c is done, then flushes the rest
+func (s *ArticleViewCounter) Run(c context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			if _, err := s.Flush(flushCtx); err != nil {
+				log.Printf("failed to flush article views on stopping: %s", err)
+			}
+			cancel()
+			return c.Err()
+		case <-ticker.C:
+			if _, err := s.Flush(c); err != nil {
+				log.Printf("failed to flush article views: %s", err)
+			}
+		}
+	}
+}
+
+// Flush writes buffered views into the repository, returns the number of views written.
+// Views failed to be written are kept to be retried by the next flush
+func (s *ArticleViewCounter) Flush(c context.Context) (int64, error) {
+	now := s.clock.Now()
+
+	s.mutex.Lock()
+	pending := s.pending
+	s.pending = make(map[articleViewKey]int64)
+	for key, last := range s.seen {
+		if now.Sub(last) >= s.window {
+			delete(s.seen, key)
+		}
+	}
+	s.mutex.Unlock()
+
+	var flushed int64
+	for key, count := range pending {
+		articleID := key.articleID
+		err := s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+			return s.viewRepository.AddViews(tx, model.NewArticleViewCount(&articleID, key.day, count))
+		}, nil)
+		if err != nil {
+			s.restore(pending)
+			return flushed, errors.Wrapf(err, "failed to add views of article %s", articleID.String())
+		}
+		delete(pending, key)
+		flushed += count
+	}
+
+	return flushed, nil
+}
+
+func (s *ArticleViewCounter) restore(pending map[articleViewKey]int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key, count := range pending {
+		s.pending[key] += count
+	}
+}
+
+// PopularArticles ranks published articles by views in the window, which is rounded up to days
+func (s *ArticleViewCounter) PopularArticles(c context.Context, window time.Duration, count int) ([]*model.PopularArticle, error) {
+	if window <= 0 || window > maxPopularWindow {
+		return nil, domain.ErrInvalidViewWindow
+	}
+
+	days := int((window + 24*time.Hour - 1) / (24 * time.Hour))
+	since := model.ViewDay(s.clock.Now()).AddDate(0, 0, 1-days)
+
+	var articles []*model.PopularArticle
+	err := s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		views, err := s.totalViewsSince(tx, since)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]model.ArticleID, 0, len(views))
+		for id := range views {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			if views[ids[i]] != views[ids[j]] {
+				return views[ids[i]] > views[ids[j]]
+			}
+			return ids[i] < ids[j]
+		})
+
+		articles = make([]*model.PopularArticle, 0, count)
+		for _, id := range ids {
+			if len(articles) >= count {
+				break
+			}
+
+			article, err := s.viewer.ViewArticle(tx, id.String())
+			switch errors.Cause(err) {
+			case nil:
+			case domain.ErrNoSuchArticle, domain.ErrInvalidArticleID:
+				continue
+			default:
+				return err
+			}
+
+			// unlisted articles are readable but not listed
+			if article.Status() != model.ArticleStatusPublished {
+				continue
+			}
+
+			item, err := model.NewArticleListViewItem(article.ID(), article.Content().Text().Title(), article.Status(), article.CreatedAt())
+			if err != nil {
+				return err
+			}
+			articles = append(articles, model.NewPopularArticle(item, views[id]))
+		}
+
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+
+	return articles, err
+}
+
+// totalViewsSince sums up views since the day, reusing the totals for popularTotalsTTL
+func (s *ArticleViewCounter) totalViewsSince(tx transaction.Transaction, since time.Time) (map[model.ArticleID]int64, error) {
+	now := s.clock.Now()
+
+	s.totalsMutex.Lock()
+	cached, ok := s.totals[since]
+	s.totalsMutex.Unlock()
+
+	if ok && now.Before(cached.expireAt) {
+		return cached.views, nil
+	}
+
+	views, err := s.viewRepository.TotalViewsSince(tx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	s.totalsMutex.Lock()
+	defer s.totalsMutex.Unlock()
+
+	for key, totals := range s.totals {
+		if !now.Before(totals.expireAt) {
+			delete(s.totals, key)
+		}
+	}
+	s.totals[since] = &viewTotals{views: views, expireAt: now.Add(popularTotalsTTL)}
+
+	return views, nil
+}
@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	clockTesting "lmm/api/clock/testing"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type inmemoryArticleViewRepository struct {
+	sync.Mutex
+	views []*model.ArticleViewCount
+}
+
+func (repo *inmemoryArticleViewRepository) AddViews(tx transaction.Transaction, views *model.ArticleViewCount) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.views = append(repo.views, views)
+	return nil
+}
+
+func (repo *inmemoryArticleViewRepository) TotalViewsSince(tx transaction.Transaction, day time.Time) (map[model.ArticleID]int64, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	total := make(map[model.ArticleID]int64)
+	for _, views := range repo.views {
+		if !views.Day().Before(day) {
+			total[*views.ArticleID()] += views.Count()
+		}
+	}
+	return total, nil
+}
+
+// inmemoryArticleViewer only views articles by id
+type inmemoryArticleViewer struct {
+	model.ArticleViewer
+	repo *InmemoryArticleRepository
+}
+
+func (viewer *inmemoryArticleViewer) ViewArticle(tx transaction.Transaction, id string) (*model.Article, error) {
+	return viewer.repo.FindByID(tx, model.NewArticleID(id))
+}
+
+func TestArticleViewCounter(t *testing.T) {
+	c := context.Background()
+
+	now := time.Date(2019, 1, 10, 12, 0, 0, 0, time.UTC)
+	clock := clockTesting.NewClock(now)
+
+	repo := NewInmemoryArticleRepository()
+	viewRepo := &inmemoryArticleViewRepository{}
+	counter := NewArticleViewCounter(clock, viewRepo, &inmemoryArticleViewer{repo: repo}, repo, 30*time.Minute)
+
+	app := NewArticleCommandService(clock, repo, &recordingArticleEventPublisher{}, repo)
+	postArticle := func(status string) *model.Article {
+		id, err := app.PostNewArticle(c, command.PostArticle{
+			AuthorID: 1,
+			Title:    "title",
+			Body:     "body",
+			Tags:     []string{},
+			Status:   status,
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		article, err := repo.FindByID(nil, id)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return article
+	}
+
+	popular := postArticle("published")
+	other := postArticle("published")
+	unlisted := postArticle("unlisted")
+	draft := postArticle("draft")
+
+	t.Run("RecordView", func(t *testing.T) {
+		assert.True(t, counter.RecordView(popular, 0, "a"))
+		assert.False(t, counter.RecordView(popular, 0, "a"), "deduplicated in the window")
+		assert.True(t, counter.RecordView(popular, 2, "b"))
+		assert.False(t, counter.RecordView(popular, 1, "author"), "author")
+		assert.False(t, counter.RecordView(draft, 2, "b"), "not public")
+		assert.True(t, counter.RecordView(unlisted, 0, "a"))
+		assert.True(t, counter.RecordView(other, 0, "a"))
+
+		clock.Add(30 * time.Minute)
+		assert.True(t, counter.RecordView(popular, 0, "a"), "counted again after the window")
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		flushed, err := counter.Flush(c)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), flushed)
+
+		flushed, err = counter.Flush(c)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), flushed)
+	})
+
+	t.Run("PopularArticles", func(t *testing.T) {
+		articles, err := counter.PopularArticles(c, 24*time.Hour, 10)
+		assert.NoError(t, err)
+		if assert.Len(t, articles, 2) {
+			assert.Equal(t, popular.ID(), articles[0].Item().ID())
+			assert.Equal(t, int64(3), articles[0].Views())
+			assert.Equal(t, other.ID(), articles[1].Item().ID())
+			assert.Equal(t, int64(1), articles[1].Views())
+		}
+
+		articles, err = counter.PopularArticles(c, 24*time.Hour, 1)
+		assert.NoError(t, err)
+		assert.Len(t, articles, 1)
+
+		clock.Add(24 * time.Hour)
+
+		articles, err = counter.PopularArticles(c, 24*time.Hour, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, articles)
+
+		articles, err = counter.PopularArticles(c, 48*time.Hour, 10)
+		assert.NoError(t, err)
+		assert.Len(t, articles, 2)
+
+		_, err = counter.PopularArticles(c, 0, 10)
+		assert.Equal(t, domain.ErrInvalidViewWindow, errors.Cause(err))
+	})
+
+	t.Run("FlushedOnStop", func(t *testing.T) {
+		assert.True(t, counter.RecordView(other, 0, "c"))
+
+		ctx, cancel := context.WithCancel(c)
+		cancel()
+		assert.Equal(t, context.Canceled, counter.Run(ctx, time.Hour))
+
+		views, err := viewRepo.TotalViewsSince(nil, model.ViewDay(clock.Now()))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), views[*other.ID()])
+	})
+
+	t.Run("TotalsCached", func(t *testing.T) {
+		articles, err := counter.PopularArticles(c, 24*time.Hour, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, articles, "totals before the view flushed on stop are reused")
+
+		clock.Add(popularTotalsTTL)
+
+		articles, err = counter.PopularArticles(c, 24*time.Hour, 10)
+		assert.NoError(t, err)
+		if assert.Len(t, articles, 1) {
+			assert.Equal(t, other.ID(), articles[0].Item().ID())
+		}
+	})
+}
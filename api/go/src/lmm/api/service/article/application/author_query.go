@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/application/query"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// AuthorQueryService is a query side application of authors and their articles
+type AuthorQueryService struct {
+	authorService model.AuthorService
+	viewer        model.ArticleViewer
+	txManager     transaction.Manager
+}
+
+// NewAuthorQueryService is a constructor of AuthorQueryService
+func NewAuthorQueryService(
+	authorService model.AuthorService,
+	viewer model.ArticleViewer,
+	txManager transaction.Manager,
+) *AuthorQueryService {
+	return &AuthorQueryService{authorService: authorService, viewer: viewer, txManager: txManager}
+}
+
+// ArticleAuthor gets the profile of the article's author, returns nil if the author no longer exists
+func (app *AuthorQueryService) ArticleAuthor(c context.Context, article *model.Article) (*model.AuthorProfile, error) {
+	authorID := article.Author().ID()
+
+	profiles, err := app.authorService.AuthorProfiles(c, []int64{authorID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve author")
+	}
+
+	return profiles[authorID], nil
+}
+
+// ListAuthorArticles lists published articles written by the author who has the name,
+// articles are paged by q.Cursor instead of q.Page if byCursor
+func (app *AuthorQueryService) ListAuthorArticles(c context.Context, name string, q query.ListArticleQuery, byCursor bool) (author *model.AuthorProfile, articles *model.ArticleListView, err error) {
+	author, err = app.authorService.AuthorByName(c, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := &model.ArticlesFilter{Tag: q.Tag, AuthorID: author.ID(), PublishedOnly: true}
+
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		if byCursor {
+			articles, err = app.viewer.ViewArticlesByCursor(tx, q.PerPage, q.Cursor, filter)
+		} else {
+			articles, err = app.viewer.ViewArticles(tx, q.PerPage, q.Page, filter)
+		}
+		return err
+	}, &transaction.Option{ReadOnly: true})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return author, articles, nil
+}
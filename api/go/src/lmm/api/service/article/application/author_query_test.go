@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"lmm/api/clock"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/application/query"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubAuthorService struct {
+	profiles map[int64]*model.AuthorProfile
+}
+
+func (s *stubAuthorService) AuthorProfiles(c context.Context, ids []int64) (map[int64]*model.AuthorProfile, error) {
+	profiles := make(map[int64]*model.AuthorProfile)
+	for _, id := range ids {
+		if profile, ok := s.profiles[id]; ok {
+			profiles[id] = profile
+		}
+	}
+	return profiles, nil
+}
+
+func (s *stubAuthorService) AuthorByName(c context.Context, name string) (*model.AuthorProfile, error) {
+	for _, profile := range s.profiles {
+		if profile.Name() == name {
+			return profile, nil
+		}
+	}
+	return nil, domain.ErrNoSuchUser
+}
+
+// filterRecordingArticleViewer records the filter of the last listing
+type filterRecordingArticleViewer struct {
+	model.ArticleViewer
+	filter *model.ArticlesFilter
+	cursor bool
+}
+
+func (viewer *filterRecordingArticleViewer) ViewArticles(tx transaction.Transaction, count, page int, filter *model.ArticlesFilter) (*model.ArticleListView, error) {
+	viewer.filter, viewer.cursor = filter, false
+	return model.NewArticleListView(nil, filter.Tag, page, count, 0, false), nil
+}
+
+func (viewer *filterRecordingArticleViewer) ViewArticlesByCursor(tx transaction.Transaction, count int, cursor string, filter *model.ArticlesFilter) (*model.ArticleListView, error) {
+	viewer.filter, viewer.cursor = filter, true
+	return model.NewArticleCursorListView(nil, filter.Tag, count, ""), nil
+}
+
+func TestAuthorQueryService(t *testing.T) {
+	c := context.Background()
+
+	repo := NewInmemoryArticleRepository()
+	viewer := &filterRecordingArticleViewer{}
+	authors := &stubAuthorService{profiles: map[int64]*model.AuthorProfile{
+		1: model.NewAuthorProfile(1, "writer", "Writer", "bio", "avatar.png"),
+	}}
+	app := NewAuthorQueryService(authors, viewer, repo)
+
+	postArticle := func(authorID int64) *model.Article {
+		id, err := NewArticleCommandService(clock.DefaultClock, repo, &recordingArticleEventPublisher{}, repo).PostNewArticle(c, command.PostArticle{
+			AuthorID: authorID,
+			Title:    "title",
+			Body:     "body",
+			Tags:     []string{},
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		article, err := repo.FindByID(nil, id)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return article
+	}
+
+	t.Run("ArticleAuthor", func(t *testing.T) {
+		author, err := app.ArticleAuthor(c, postArticle(1))
+		assert.NoError(t, err)
+		if assert.NotNil(t, author) {
+			assert.Equal(t, "Writer", author.DisplayName())
+			assert.Equal(t, "avatar.png", author.AvatarAssetID())
+		}
+
+		author, err = app.ArticleAuthor(c, postArticle(2))
+		assert.NoError(t, err)
+		assert.Nil(t, author)
+	})
+
+	t.Run("ListAuthorArticles", func(t *testing.T) {
+		author, articles, err := app.ListAuthorArticles(c, "writer", query.ListArticleQuery{Page: 1, PerPage: 5, Tag: "go"}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), author.ID())
+		assert.NotNil(t, articles)
+		assert.Equal(t, &model.ArticlesFilter{Tag: "go", AuthorID: 1, PublishedOnly: true}, viewer.filter)
+		assert.False(t, viewer.cursor)
+
+		_, _, err = app.ListAuthorArticles(c, "writer", query.ListArticleQuery{PerPage: 5}, true)
+		assert.NoError(t, err)
+		assert.True(t, viewer.cursor)
+	})
+
+	t.Run("NoSuchUser", func(t *testing.T) {
+		_, _, err := app.ListAuthorArticles(c, "nobody", query.ListArticleQuery{Page: 1, PerPage: 5}, false)
+		assert.Equal(t, domain.ErrNoSuchUser, errors.Cause(err))
+	})
+}
@@ -1,11 +1,19 @@
 package command
 
+import "time"
+
 // PostArticle Command
 type PostArticle struct {
-	AuthorID int64
-	Title    string
-	Body     string
-	Tags     []string
+	AuthorID  int64
+	LinkName  string
+	Title     string
+	Body      string
+	Tags      []string
+	Status    string
+	PublishAt time.Time
+
+	// CreatedAt keeps the original posting time of imported articles, the article is posted now if zero
+	CreatedAt time.Time
 }
 
 // EditArticle command
@@ -16,4 +24,101 @@ type EditArticle struct {
 	Title     string
 	Body      string
 	Tags      []string
+
+	// Versions are the versions of the article which the edit can be based on, not checked if nil
+	Versions []uint
+
+	// EditAny allows the user to edit the article even if not its author
+	EditAny bool
+}
+
+// DeleteArticle command
+type DeleteArticle struct {
+	UserID    int64
+	ArticleID string
+
+	// DeleteAny allows the user to delete the article even if not its author
+	DeleteAny bool
+}
+
+// PublishArticle command
+type PublishArticle struct {
+	UserID    int64
+	ArticleID string
+	Unlisted  bool
+
+	// EditAny allows the user to publish the article even if not its author
+	EditAny bool
+}
+
+// UnpublishArticle command
+type UnpublishArticle struct {
+	UserID    int64
+	ArticleID string
+
+	// EditAny allows the user to unpublish the article even if not its author
+	EditAny bool
+}
+
+// ScheduleArticle command
+type ScheduleArticle struct {
+	UserID    int64
+	ArticleID string
+	PublishAt time.Time
+
+	// EditAny allows the user to schedule the article even if not its author
+	EditAny bool
+}
+
+// RestoreArticleRevision command
+type RestoreArticleRevision struct {
+	UserID         int64
+	ArticleID      string
+	RevisionNumber int
+
+	// EditAny allows the user to restore the article even if not its author
+	EditAny bool
+}
+
+// RenameArticleTag command renames the tag on all articles
+type RenameArticleTag struct {
+	UserID  int64
+	Tag     string
+	NewName string
+}
+
+// MergeArticleTag command replaces the tag with another one on all articles
+type MergeArticleTag struct {
+	UserID int64
+	Tag    string
+	Into   string
+}
+
+// DeleteArticleTag command removes the tag from all articles
+type DeleteArticleTag struct {
+	UserID int64
+	Tag    string
+}
+
+// PostSeries command
+type PostSeries struct {
+	UserID      int64
+	Title       string
+	Description string
+	Articles    []string
+}
+
+// EditSeries command replaces the series' title, description and articles in order
+type EditSeries struct {
+	UserID      int64
+	SeriesID    string
+	Title       string
+	Description string
+	Articles    []string
+}
+
+// DeleteSeries command
+type DeleteSeries struct {
+	UserID   int64
+	SeriesID string
 }
@@ -8,6 +8,16 @@ type ListArticleQuery struct {
 	Page    int    `form:"page,default=1" binding:"min=1"`
 	PerPage int    `form:"perPage,default=5" binding:"min=1"`
 	Tag     string `form:"tag"`
+	Drafts  bool   `form:"drafts"`
+
+	// Cursor is the opaque position to list articles from, Page is ignored if paging by cursor
+	Cursor string `form:"cursor"`
+
+	// AuthorID lists the author's own articles including drafts if not zero
+	AuthorID int64 `form:"-"`
+
+	// PublishedOnly lists only published articles of the author even if AuthorID is not zero
+	PublishedOnly bool `form:"-"`
 }
 
 func (q *ListArticleQuery) ValidateErrors(err error) []string {
@@ -30,3 +40,32 @@ func (q *ListArticleQuery) ValidateErrors(err error) []string {
 
 	return errStrings
 }
+
+type SearchArticleQuery struct {
+	Query   string `form:"q" binding:"required"`
+	Page    int    `form:"page,default=1" binding:"min=1"`
+	PerPage int    `form:"perPage,default=5" binding:"min=1"`
+}
+
+func (q *SearchArticleQuery) ValidateErrors(err error) []string {
+	errors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+
+	errStrings := make([]string, len(errors))
+	i := 0
+	for _, err := range errors {
+		switch err.Field {
+		case "Query":
+			errStrings[i] = "empty query"
+		case "Page":
+			errStrings[i] = "invalid page"
+		case "PerPage":
+			errStrings[i] = "invalid perPage"
+		}
+		i++
+	}
+
+	return errStrings
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+
+	"lmm/api/clock"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// SeriesCommandService is a command side application of series
+type SeriesCommandService struct {
+	articleRepository  model.ArticleRepository
+	seriesRepository   model.SeriesRepository
+	transactionManager transaction.Manager
+}
+
+// NewSeriesCommandService is a constructor of SeriesCommandService
+func NewSeriesCommandService(
+	articleRepository model.ArticleRepository,
+	seriesRepository model.SeriesRepository,
+	transactionManager transaction.Manager,
+) *SeriesCommandService {
+	return &SeriesCommandService{
+		articleRepository:  articleRepository,
+		seriesRepository:   seriesRepository,
+		transactionManager: transactionManager,
+	}
+}
+
+// PostNewSeries is used for creating a new series of the user's articles
+func (app *SeriesCommandService) PostNewSeries(c context.Context, cmd command.PostSeries) (id *model.SeriesID, err error) {
+	articles := seriesArticleIDs(cmd.Articles)
+
+	err = app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		id, err = app.seriesRepository.NextID(tx, cmd.UserID)
+		if err != nil {
+			return err
+		}
+
+		now := clock.Now()
+		series, err := model.NewSeries(id, model.NewAuthor(cmd.UserID), cmd.Title, cmd.Description, articles, now, now)
+		if err != nil {
+			return err
+		}
+
+		if err := app.checkSeriesArticles(tx, series); err != nil {
+			return err
+		}
+
+		return app.seriesRepository.Save(tx, series)
+	}, nil)
+
+	return
+}
+
+// EditSeries command, only the owner is allowed to edit the series
+func (app *SeriesCommandService) EditSeries(c context.Context, cmd command.EditSeries) error {
+	seriesID := model.NewSeriesID(cmd.SeriesID)
+	articles := seriesArticleIDs(cmd.Articles)
+
+	return app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		series, err := app.seriesRepository.FindByID(tx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		if err := series.CheckOwner(cmd.UserID); err != nil {
+			return err
+		}
+
+		if err := series.Edit(cmd.Title, cmd.Description, articles); err != nil {
+			return err
+		}
+
+		if err := app.checkSeriesArticles(tx, series); err != nil {
+			return err
+		}
+
+		return app.seriesRepository.Save(tx, series)
+	}, nil)
+}
+
+// DeleteSeries command, articles in the series are kept
+func (app *SeriesCommandService) DeleteSeries(c context.Context, cmd command.DeleteSeries) error {
+	seriesID := model.NewSeriesID(cmd.SeriesID)
+
+	return app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		series, err := app.seriesRepository.FindByID(tx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		if err := series.CheckOwner(cmd.UserID); err != nil {
+			return err
+		}
+
+		return app.seriesRepository.Remove(tx, series.ID())
+	}, nil)
+}
+
+// checkSeriesArticles checks all articles in the series are written by the owner and not in other series
+func (app *SeriesCommandService) checkSeriesArticles(tx transaction.Transaction, series *model.Series) error {
+	for _, id := range series.Articles() {
+		article, err := app.articleRepository.FindByID(tx, id)
+		if err != nil {
+			return errors.Wrap(err, "article not found")
+		}
+
+		if article.Author().ID() != series.Owner().ID() {
+			return domain.ErrNotArticleAuthor
+		}
+
+		other, err := app.seriesRepository.FindByArticle(tx, id)
+		switch errors.Cause(err) {
+		case nil:
+			if *other.ID() != *series.ID() {
+				return domain.ErrArticleAlreadyInSeries
+			}
+		case domain.ErrNoSuchSeries:
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func seriesArticleIDs(ids []string) []*model.ArticleID {
+	articles := make([]*model.ArticleID, len(ids), len(ids))
+	for i, id := range ids {
+		articles[i] = model.NewArticleID(id)
+	}
+	return articles
+}
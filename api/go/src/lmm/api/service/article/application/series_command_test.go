@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"lmm/api/clock"
+	_ "lmm/api/clock/testing"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeriesCommands(t *testing.T) {
+	c := context.Background()
+
+	articleRepo := NewInmemoryArticleRepository()
+	seriesRepo := NewInmemorySeriesRepository()
+	articleApp := NewArticleCommandService(clock.DefaultClock, articleRepo, &recordingArticleEventPublisher{}, articleRepo)
+	app := NewSeriesCommandService(articleRepo, seriesRepo, articleRepo)
+
+	postArticle := func(authorID int64) string {
+		id, err := articleApp.PostNewArticle(c, command.PostArticle{
+			AuthorID: authorID,
+			Title:    "title",
+			Body:     "body",
+			Tags:     []string{},
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return id.String()
+	}
+
+	a, b, other := postArticle(1), postArticle(1), postArticle(2)
+
+	seriesID, err := app.PostNewSeries(c, command.PostSeries{UserID: 1, Title: "series", Articles: []string{a, b}})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	t.Run("NotArticleAuthor", func(t *testing.T) {
+		_, err := app.PostNewSeries(c, command.PostSeries{UserID: 1, Title: "series", Articles: []string{other}})
+		assert.Equal(t, domain.ErrNotArticleAuthor, errors.Cause(err))
+	})
+
+	t.Run("NoSuchArticle", func(t *testing.T) {
+		_, err := app.PostNewSeries(c, command.PostSeries{UserID: 1, Title: "series", Articles: []string{"404"}})
+		assert.Equal(t, domain.ErrNoSuchArticle, errors.Cause(err))
+	})
+
+	t.Run("AlreadyInSeries", func(t *testing.T) {
+		_, err := app.PostNewSeries(c, command.PostSeries{UserID: 1, Title: "series", Articles: []string{b}})
+		assert.Equal(t, domain.ErrArticleAlreadyInSeries, errors.Cause(err))
+	})
+
+	t.Run("Edit", func(t *testing.T) {
+		err := app.EditSeries(c, command.EditSeries{UserID: 2, SeriesID: seriesID.String(), Title: "edited"})
+		assert.Equal(t, domain.ErrNotSeriesOwner, errors.Cause(err))
+
+		err = app.EditSeries(c, command.EditSeries{UserID: 1, SeriesID: seriesID.String(), Title: "edited", Articles: []string{b, a}})
+		assert.NoError(t, err)
+
+		series, err := seriesRepo.FindByID(nil, seriesID)
+		assert.NoError(t, err)
+		assert.Equal(t, "edited", series.Title())
+		assert.Equal(t, []*model.ArticleID{model.NewArticleID(b), model.NewArticleID(a)}, series.Articles())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := app.DeleteSeries(c, command.DeleteSeries{UserID: 2, SeriesID: seriesID.String()})
+		assert.Equal(t, domain.ErrNotSeriesOwner, errors.Cause(err))
+
+		assert.NoError(t, app.DeleteSeries(c, command.DeleteSeries{UserID: 1, SeriesID: seriesID.String()}))
+
+		err = app.DeleteSeries(c, command.DeleteSeries{UserID: 1, SeriesID: seriesID.String()})
+		assert.Equal(t, domain.ErrNoSuchSeries, errors.Cause(err))
+
+		_, err = app.PostNewSeries(c, command.PostSeries{UserID: 1, Title: "series", Articles: []string{b}})
+		assert.NoError(t, err)
+	})
+}
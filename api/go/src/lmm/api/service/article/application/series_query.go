@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// SeriesQueryService is a query side application of series
+type SeriesQueryService struct {
+	viewer           model.ArticleViewer
+	seriesRepository model.SeriesRepository
+	txManager        transaction.Manager
+}
+
+// NewSeriesQueryService is a constructor of SeriesQueryService
+func NewSeriesQueryService(
+	viewer model.ArticleViewer,
+	seriesRepository model.SeriesRepository,
+	txManager transaction.Manager,
+) *SeriesQueryService {
+	return &SeriesQueryService{viewer: viewer, seriesRepository: seriesRepository, txManager: txManager}
+}
+
+// SeriesByID gets the series with its articles readable by the reader in order
+func (app *SeriesQueryService) SeriesByID(c context.Context, id string, readerID int64) (series *model.Series, articles []*model.Article, err error) {
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		series, err = app.seriesRepository.FindByID(tx, model.NewSeriesID(id))
+		if err != nil {
+			return err
+		}
+
+		articles = make([]*model.Article, 0, len(series.Articles()))
+		for _, articleID := range series.Articles() {
+			article, err := app.readableArticle(tx, articleID, readerID)
+			if err != nil {
+				return err
+			}
+			if article != nil {
+				articles = append(articles, article)
+			}
+		}
+
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
+
+// ArticleSeriesNavigation gets the series of the article with the previous and next articles readable by the reader,
+// returns nil if the article is not in any series
+func (app *SeriesQueryService) ArticleSeriesNavigation(c context.Context, article *model.Article, readerID int64) (navigation *model.SeriesNavigation, err error) {
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		series, err := app.seriesRepository.FindByArticle(tx, article.ID())
+		if errors.Cause(err) == domain.ErrNoSuchSeries {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		previous, err := app.firstReadableArticle(tx, series.ArticlesBefore(article.ID()), readerID)
+		if err != nil {
+			return err
+		}
+
+		next, err := app.firstReadableArticle(tx, series.ArticlesAfter(article.ID()), readerID)
+		if err != nil {
+			return err
+		}
+
+		navigation = model.NewSeriesNavigation(series, previous, next)
+
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
+
+func (app *SeriesQueryService) firstReadableArticle(tx transaction.Transaction, ids []*model.ArticleID, readerID int64) (*model.Article, error) {
+	for _, id := range ids {
+		article, err := app.readableArticle(tx, id, readerID)
+		if err != nil || article != nil {
+			return article, err
+		}
+	}
+	return nil, nil
+}
+
+// readableArticle returns nil if the article is not readable by the reader or has been deleted
+func (app *SeriesQueryService) readableArticle(tx transaction.Transaction, id *model.ArticleID, readerID int64) (*model.Article, error) {
+	article, err := app.viewer.ViewArticle(tx, id.String())
+	switch errors.Cause(err) {
+	case nil:
+	case domain.ErrNoSuchArticle, domain.ErrInvalidArticleID:
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	if !article.IsReadableBy(readerID) {
+		return nil, nil
+	}
+	return article, nil
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"lmm/api/clock"
+	"lmm/api/service/article/domain"
 )
 
 type ArticleID string
@@ -23,18 +24,31 @@ type Article struct {
 	author       *Author
 	linkName     string
 	content      *Content
+	status       ArticleStatus
+	version      uint
 	createdAt    time.Time
 	lastModified time.Time
+	publishedAt  time.Time
 }
 
 // NewArticle is a article constructor
-func NewArticle(articleID *ArticleID, author *Author, content *Content, createdAt, lastModified time.Time) *Article {
+func NewArticle(
+	articleID *ArticleID,
+	author *Author,
+	content *Content,
+	status ArticleStatus,
+	version uint,
+	createdAt, lastModified, publishedAt time.Time,
+) *Article {
 	article := &Article{
 		id:           articleID,
 		author:       author,
 		content:      content,
+		status:       status,
+		version:      version,
 		createdAt:    createdAt,
 		lastModified: lastModified,
+		publishedAt:  publishedAt,
 	}
 	return article
 }
@@ -48,9 +62,17 @@ func (a *Article) Author() *Author {
 	return a.author
 }
 
+// LinkName returns the human readable name used to link to the article, empty if not named yet
+func (a *Article) LinkName() string {
+	return a.linkName
+}
+
 // ChangeLinkName changed a's LinkName to newLinkName
-// TODO: validate newLinkName
 func (a *Article) ChangeLinkName(newLinkName string) error {
+	if err := ValidateLinkName(newLinkName); err != nil {
+		return err
+	}
+
 	a.linkName = newLinkName
 
 	return nil
@@ -67,6 +89,90 @@ func (a *Article) EditContent(content *Content) {
 		a.lastModified = clock.Now()
 	}
 	a.content = content
+	a.version++
+}
+
+// Status returns the publication state of the article
+func (a *Article) Status() ArticleStatus {
+	return a.status
+}
+
+// Publish makes the article visible to everyone, unlisted means it won't be listed.
+// publishedAt is only set on the first publication
+func (a *Article) Publish(unlisted bool, at time.Time) {
+	if a.publishedAt.IsZero() || a.status == ArticleStatusScheduled {
+		a.publishedAt = at
+	}
+	if unlisted {
+		a.status = ArticleStatusUnlisted
+	} else {
+		a.status = ArticleStatusPublished
+	}
+	a.version++
+}
+
+// Unpublish turns the article back into a draft
+func (a *Article) Unpublish() {
+	if a.status == ArticleStatusScheduled {
+		a.publishedAt = time.Time{}
+	}
+	a.status = ArticleStatusDraft
+	a.version++
+}
+
+// Schedule makes the article published at the given time in the future
+func (a *Article) Schedule(at, now time.Time) error {
+	if a.status == ArticleStatusPublished || a.status == ArticleStatusUnlisted {
+		return domain.ErrArticleAlreadyPublished
+	}
+	if !at.After(now) {
+		return domain.ErrInvalidPublishTime
+	}
+
+	a.status = ArticleStatusScheduled
+	a.publishedAt = at
+	a.version++
+
+	return nil
+}
+
+// PublishIfDue publishes the scheduled article if its publish time has passed,
+// returns true if the article got published
+func (a *Article) PublishIfDue(now time.Time) bool {
+	if a.status != ArticleStatusScheduled || a.publishedAt.After(now) {
+		return false
+	}
+
+	a.status = ArticleStatusPublished
+	a.version++
+
+	return true
+}
+
+// Version returns the version of the article, which increases every time the article changes.
+// It's used to detect conflicting edits
+func (a *Article) Version() uint {
+	return a.version
+}
+
+// CheckVersion returns error unless the article is at one of the expected versions
+func (a *Article) CheckVersion(expected ...uint) error {
+	for _, version := range expected {
+		if a.version == version {
+			return nil
+		}
+	}
+	return domain.ErrArticleVersionConflict
+}
+
+// IsReadableBy returns true if the user is allowed to read the article
+func (a *Article) IsReadableBy(userID int64) bool {
+	switch a.status {
+	case ArticleStatusDraft, ArticleStatusScheduled:
+		return a.author.ID() == userID
+	default:
+		return true
+	}
 }
 
 // CreatedAt time
@@ -78,3 +184,9 @@ func (a *Article) CreatedAt() time.Time {
 func (a *Article) LastModified() time.Time {
 	return a.lastModified
 }
+
+// PublishedAt time, zero if the article has never been published.
+// It's the time to be published if the article is scheduled
+func (a *Article) PublishedAt() time.Time {
+	return a.publishedAt
+}
@@ -8,6 +8,7 @@ type ArticleListView struct {
 	tagFilter   string
 	total       int
 	hasNextPage bool
+	nextCursor  string
 }
 
 // NewArticleListView constructs a new ArticleListView
@@ -22,6 +23,18 @@ func NewArticleListView(items []*ArticleListViewItem, tagFilter string, page, pe
 	}
 }
 
+// NewArticleCursorListView constructs a new ArticleListView paged by cursor,
+// which has no page number and total, nextCursor is empty if there is no next page
+func NewArticleCursorListView(items []*ArticleListViewItem, tagFilter string, perPage int, nextCursor string) *ArticleListView {
+	return &ArticleListView{
+		items:       items,
+		perPage:     perPage,
+		tagFilter:   tagFilter,
+		hasNextPage: nextCursor != "",
+		nextCursor:  nextCursor,
+	}
+}
+
 // Items gets items of article list view
 func (v *ArticleListView) Items() []*ArticleListViewItem {
 	return v.items
@@ -48,3 +61,8 @@ func (v *ArticleListView) Total() int {
 func (v *ArticleListView) HasNextPage() bool {
 	return v.hasNextPage
 }
+
+// NextCursor returns the cursor to the next page, empty if there is no next page or the view is paged by page number
+func (v *ArticleListView) NextCursor() string {
+	return v.nextCursor
+}
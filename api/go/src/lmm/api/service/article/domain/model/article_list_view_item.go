@@ -8,14 +8,16 @@ import (
 type ArticleListViewItem struct {
 	id     *ArticleID
 	title  string
+	status ArticleStatus
 	postAt time.Time
 }
 
 // NewArticleListViewItem creates a new item ArticleListViewItem
-func NewArticleListViewItem(id *ArticleID, title string, postAt time.Time) (*ArticleListViewItem, error) {
+func NewArticleListViewItem(id *ArticleID, title string, status ArticleStatus, postAt time.Time) (*ArticleListViewItem, error) {
 	return &ArticleListViewItem{
 		id:     id,
 		title:  title,
+		status: status,
 		postAt: postAt,
 	}, nil
 }
@@ -30,6 +32,11 @@ func (i *ArticleListViewItem) Title() string {
 	return i.title
 }
 
+// Status gets article's publication state
+func (i *ArticleListViewItem) Status() ArticleStatus {
+	return i.status
+}
+
 // PostAt gets article's post time
 func (i *ArticleListViewItem) PostAt() time.Time {
 	return i.postAt
@@ -0,0 +1,7 @@
+package model
+
+// ArticleRecommender recommends published articles related to an article
+type ArticleRecommender interface {
+	// RelatedArticles returns at most count articles related to article from the most related one
+	RelatedArticles(article *Article, count int) ([]*ArticleListViewItem, error)
+}
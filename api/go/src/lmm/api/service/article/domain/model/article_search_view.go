@@ -0,0 +1,94 @@
+package model
+
+import "time"
+
+// ArticleSearcher searches published articles by full text
+type ArticleSearcher interface {
+	SearchArticles(query string, count, page int) (*ArticleSearchView, error)
+}
+
+// ArticleSearchView is the model used to view search results ranked by relevance
+type ArticleSearchView struct {
+	items       []*ArticleSearchViewItem
+	query       string
+	page        int
+	perPage     int
+	total       int
+	hasNextPage bool
+}
+
+// NewArticleSearchView constructs a new ArticleSearchView
+func NewArticleSearchView(items []*ArticleSearchViewItem, query string, page, perPage, total int, hasNextPage bool) *ArticleSearchView {
+	return &ArticleSearchView{
+		items:       items,
+		query:       query,
+		page:        page,
+		perPage:     perPage,
+		total:       total,
+		hasNextPage: hasNextPage,
+	}
+}
+
+// Items gets the results of the page
+func (v *ArticleSearchView) Items() []*ArticleSearchViewItem {
+	return v.items
+}
+
+// Query returns the searched text
+func (v *ArticleSearchView) Query() string {
+	return v.query
+}
+
+func (v *ArticleSearchView) Page() int {
+	return v.page
+}
+
+func (v *ArticleSearchView) PerPage() int {
+	return v.perPage
+}
+
+// Total returns the number of all matched articles
+func (v *ArticleSearchView) Total() int {
+	return v.total
+}
+
+// HasNextPage returns true if there is next page
+func (v *ArticleSearchView) HasNextPage() bool {
+	return v.hasNextPage
+}
+
+// ArticleSearchViewItem is a matched article
+type ArticleSearchViewItem struct {
+	id      *ArticleID
+	title   string
+	snippet string
+	postAt  time.Time
+}
+
+// NewArticleSearchViewItem creates a new ArticleSearchViewItem,
+// snippet is an HTML escaped part of the article with matched words wrapped by <mark>
+func NewArticleSearchViewItem(id *ArticleID, title, snippet string, postAt time.Time) *ArticleSearchViewItem {
+	return &ArticleSearchViewItem{
+		id:      id,
+		title:   title,
+		snippet: snippet,
+		postAt:  postAt,
+	}
+}
+
+func (i *ArticleSearchViewItem) ID() *ArticleID {
+	return i.id
+}
+
+func (i *ArticleSearchViewItem) Title() string {
+	return i.title
+}
+
+// Snippet returns the highlighted part of the article
+func (i *ArticleSearchViewItem) Snippet() string {
+	return i.snippet
+}
+
+func (i *ArticleSearchViewItem) PostAt() time.Time {
+	return i.postAt
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"lmm/api/clock"
+	"lmm/api/service/article/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestArticle(status ArticleStatus) *Article {
+	content, err := NewContent("title", "body", []string{"tag"})
+	if err != nil {
+		panic(err)
+	}
+
+	now := clock.Now()
+	return NewArticle(NewArticleID("article"), NewAuthor(1), content, status, 1, now, now, time.Time{})
+}
+
+func TestArticlePublish(t *testing.T) {
+	t.Run("Published", func(t *testing.T) {
+		article := newTestArticle(ArticleStatusDraft)
+		at := clock.Now()
+
+		article.Publish(false, at)
+		assert.Equal(t, ArticleStatusPublished, article.Status())
+		assert.Equal(t, at, article.PublishedAt())
+	})
+
+	t.Run("Unlisted", func(t *testing.T) {
+		article := newTestArticle(ArticleStatusDraft)
+
+		article.Publish(true, clock.Now())
+		assert.Equal(t, ArticleStatusUnlisted, article.Status())
+	})
+
+	t.Run("Republished", func(t *testing.T) {
+		article := newTestArticle(ArticleStatusDraft)
+		at := clock.Now()
+
+		article.Publish(false, at)
+		article.Unpublish()
+		assert.Equal(t, ArticleStatusDraft, article.Status())
+
+		article.Publish(false, at.Add(time.Hour))
+		assert.Equal(t, at, article.PublishedAt())
+	})
+}
+
+func TestArticleSchedule(t *testing.T) {
+	now := clock.Now()
+
+	t.Run("Scheduled", func(t *testing.T) {
+		article := newTestArticle(ArticleStatusDraft)
+
+		assert.NoError(t, article.Schedule(now.Add(time.Hour), now))
+		assert.Equal(t, ArticleStatusScheduled, article.Status())
+		assert.False(t, article.IsReadableBy(2))
+
+		assert.False(t, article.PublishIfDue(now))
+		assert.True(t, article.PublishIfDue(now.Add(time.Hour)))
+		assert.Equal(t, ArticleStatusPublished, article.Status())
+		assert.Equal(t, now.Add(time.Hour), article.PublishedAt())
+	})
+
+	t.Run("PublishedEarlier", func(t *testing.T) {
+		article := newTestArticle(ArticleStatusDraft)
+
+		assert.NoError(t, article.Schedule(now.Add(time.Hour), now))
+		article.Publish(false, now)
+		assert.Equal(t, now, article.PublishedAt())
+	})
+
+	t.Run("InThePast", func(t *testing.T) {
+		article := newTestArticle(ArticleStatusDraft)
+		assert.Equal(t, domain.ErrInvalidPublishTime, article.Schedule(now, now))
+	})
+
+	t.Run("AlreadyPublished", func(t *testing.T) {
+		article := newTestArticle(ArticleStatusPublished)
+		assert.Equal(t, domain.ErrArticleAlreadyPublished, article.Schedule(now.Add(time.Hour), now))
+	})
+}
+
+func TestArticleIsReadableBy(t *testing.T) {
+	cases := map[string]struct {
+		Status   ArticleStatus
+		ReaderID int64
+		Readable bool
+	}{
+		"DraftByAuthor":      {ArticleStatusDraft, 1, true},
+		"DraftByOther":       {ArticleStatusDraft, 2, false},
+		"DraftByAnonymous":   {ArticleStatusDraft, 0, false},
+		"PublishedByOther":   {ArticleStatusPublished, 2, true},
+		"UnlistedByOther":    {ArticleStatusUnlisted, 2, true},
+		"UnlistedAnonymous":  {ArticleStatusUnlisted, 0, true},
+		"PublishedAnonymous": {ArticleStatusPublished, 0, true},
+	}
+
+	for testName, testCase := range cases {
+		t.Run(testName, func(t *testing.T) {
+			article := newTestArticle(testCase.Status)
+			assert.Equal(t, testCase.Readable, article.IsReadableBy(testCase.ReaderID))
+		})
+	}
+}
+
+func TestNewArticleStatus(t *testing.T) {
+	for _, s := range []string{"draft", "published", "unlisted"} {
+		status, err := NewArticleStatus(s)
+		assert.NoError(t, err)
+		assert.Equal(t, s, status.String())
+	}
+
+	_, err := NewArticleStatus("deleted")
+	assert.Error(t, err)
+}
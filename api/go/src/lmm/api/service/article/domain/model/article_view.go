@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"lmm/api/pkg/transaction"
+)
+
+// ArticleViewCount is the number of views of an article in a day
+type ArticleViewCount struct {
+	articleID *ArticleID
+	day       time.Time
+	count     int64
+}
+
+// NewArticleViewCount creates views of the article in the day which t is in
+func NewArticleViewCount(articleID *ArticleID, t time.Time, count int64) *ArticleViewCount {
+	return &ArticleViewCount{articleID: articleID, day: ViewDay(t), count: count}
+}
+
+// ArticleID returns the id of the viewed article
+func (v *ArticleViewCount) ArticleID() *ArticleID {
+	return v.articleID
+}
+
+// Day returns the beginning of the day when the article is viewed
+func (v *ArticleViewCount) Day() time.Time {
+	return v.day
+}
+
+// Count returns the number of views
+func (v *ArticleViewCount) Count() int64 {
+	return v.count
+}
+
+// ViewDay truncates t into the beginning of the day in UTC, views are counted by day
+func ViewDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// PopularArticle is an article ranked by its views
+type PopularArticle struct {
+	item  *ArticleListViewItem
+	views int64
+}
+
+// NewPopularArticle creates a new PopularArticle
+func NewPopularArticle(item *ArticleListViewItem, views int64) *PopularArticle {
+	return &PopularArticle{item: item, views: views}
+}
+
+// Item returns the article
+func (a *PopularArticle) Item() *ArticleListViewItem {
+	return a.item
+}
+
+// Views returns the number of views in the ranking window
+func (a *PopularArticle) Views() int64 {
+	return a.views
+}
+
+// ArticleViewRepository stores view counts of articles
+type ArticleViewRepository interface {
+	// AddViews adds the views of the article in the day
+	AddViews(tx transaction.Transaction, views *ArticleViewCount) error
+
+	// TotalViewsSince sums up views of each article since the day, which is not transactional
+	TotalViewsSince(tx transaction.Transaction, day time.Time) (map[ArticleID]int64, error)
+}
@@ -1,5 +1,7 @@
 package model
 
+import "context"
+
 type Author struct {
 	id int64
 }
@@ -11,3 +13,57 @@ func NewAuthor(id int64) *Author {
 func (a *Author) ID() int64 {
 	return a.id
 }
+
+// AuthorProfile is how an author is shown to readers of articles
+type AuthorProfile struct {
+	id            int64
+	name          string
+	displayName   string
+	bio           string
+	avatarAssetID string
+}
+
+// NewAuthorProfile creates a new *AuthorProfile
+func NewAuthorProfile(id int64, name, displayName, bio, avatarAssetID string) *AuthorProfile {
+	return &AuthorProfile{
+		id:            id,
+		name:          name,
+		displayName:   displayName,
+		bio:           bio,
+		avatarAssetID: avatarAssetID,
+	}
+}
+
+// ID gets the id of the author
+func (p *AuthorProfile) ID() int64 {
+	return p.id
+}
+
+// Name gets the unique name of the author
+func (p *AuthorProfile) Name() string {
+	return p.name
+}
+
+// DisplayName gets the name shown to readers
+func (p *AuthorProfile) DisplayName() string {
+	return p.displayName
+}
+
+// Bio gets the self introduction of the author
+func (p *AuthorProfile) Bio() string {
+	return p.bio
+}
+
+// AvatarAssetID gets the photo asset shown as the avatar, empty if not set
+func (p *AuthorProfile) AvatarAssetID() string {
+	return p.avatarAssetID
+}
+
+// AuthorService resolves authors from the bounded context which owns users
+type AuthorService interface {
+	// AuthorProfiles gets profiles keyed by author id, authors who no longer exist are left out
+	AuthorProfiles(c context.Context, ids []int64) (map[int64]*AuthorProfile, error)
+
+	// AuthorByName gets the profile of the author, returns domain.ErrNoSuchUser if not found
+	AuthorByName(c context.Context, name string) (*AuthorProfile, error)
+}
@@ -35,3 +35,29 @@ func (c *Content) Tags() []*Tag {
 func (c *Content) Text() Text {
 	return *c.text
 }
+
+// HasTag returns true if the content is tagged by name
+func (c *Content) HasTag(name string) bool {
+	for _, tag := range c.tags {
+		if tag.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceTag returns a copy of the content with the tag named name replaced by newName.
+// The tag is just removed if newName is empty or the content has been tagged by newName
+func (c *Content) ReplaceTag(name, newName string) (*Content, error) {
+	names := make([]string, 0, len(c.tags))
+	for _, tag := range c.tags {
+		switch {
+		case tag.Name() != name:
+			names = append(names, tag.Name())
+		case newName != "" && !c.HasTag(newName):
+			names = append(names, newName)
+		}
+	}
+
+	return NewContent(c.text.Title(), c.text.Body(), names)
+}
@@ -0,0 +1,77 @@
+package model
+
+// DiffOperation tells how a line changed between two revisions
+type DiffOperation string
+
+const (
+	DiffEqual  DiffOperation = "equal"
+	DiffInsert DiffOperation = "insert"
+	DiffDelete DiffOperation = "delete"
+)
+
+// DiffLine is a line of a line diff
+type DiffLine struct {
+	operation DiffOperation
+	text      string
+}
+
+// Operation returns how the line changed
+func (l *DiffLine) Operation() DiffOperation {
+	return l.operation
+}
+
+// Text returns the text of the line
+func (l *DiffLine) Text() string {
+	return l.text
+}
+
+// DiffArticleRevisions computes the line diff which turns from into to
+func DiffArticleRevisions(from, to *ArticleRevision) []*DiffLine {
+	return diffLines(from.lines(), to.lines())
+}
+
+// diffLines computes line diff by the longest common subsequence of a and b
+func diffLines(a, b []string) []*DiffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]*DiffLine, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, &DiffLine{operation: DiffEqual, text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, &DiffLine{operation: DiffDelete, text: a[i]})
+			i++
+		default:
+			lines = append(lines, &DiffLine{operation: DiffInsert, text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, &DiffLine{operation: DiffDelete, text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, &DiffLine{operation: DiffInsert, text: b[j]})
+	}
+
+	return lines
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffArticleRevisions(t *testing.T) {
+	newRevision := func(number int, title, body string, tags []string) *ArticleRevision {
+		content, err := NewContent(title, body, tags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return NewArticleRevision(NewArticleID("article"), number, content, NewAuthor(1), time.Now())
+	}
+
+	from := newRevision(1, "title", "line1\nline2\nline3", []string{"a", "b"})
+	to := newRevision(2, "new title", "line1\nline3\nline4", []string{"a", "b"})
+
+	type line struct {
+		Operation DiffOperation
+		Text      string
+	}
+
+	lines := make([]line, 0)
+	for _, l := range DiffArticleRevisions(from, to) {
+		lines = append(lines, line{Operation: l.Operation(), Text: l.Text()})
+	}
+
+	assert.Equal(t, []line{
+		{DiffDelete, "title"},
+		{DiffInsert, "new title"},
+		{DiffEqual, "a, b"},
+		{DiffEqual, ""},
+		{DiffEqual, "line1"},
+		{DiffDelete, "line2"},
+		{DiffEqual, "line3"},
+		{DiffInsert, "line4"},
+	}, lines)
+
+	for _, l := range DiffArticleRevisions(from, from) {
+		assert.Equal(t, DiffEqual, l.Operation())
+	}
+}
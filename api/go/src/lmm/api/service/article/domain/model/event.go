@@ -0,0 +1,9 @@
+package model
+
+import "context"
+
+// ArticleEventPublisher publishes domain events of article
+type ArticleEventPublisher interface {
+	NotifyArticlePublished(context.Context, *Article) error
+	NotifyArticleDeleted(context.Context, *Article) error
+}
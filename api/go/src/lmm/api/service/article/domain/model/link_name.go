@@ -0,0 +1,51 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"lmm/api/service/article/domain"
+)
+
+var (
+	patternLinkName        = regexp.MustCompile("^[a-z0-9\u4e00-\u9fa5ぁ-んァ-ン]+(-[a-z0-9\u4e00-\u9fa5ぁ-んァ-ン]+)*$")
+	patternLinkNameSkipped = regexp.MustCompile("[^a-z0-9\u4e00-\u9fa5ぁ-んァ-ン]+")
+	linkNameMaxLength      = 80
+	generatedLinkNameMax   = 64
+	defaultLinkName        = "article"
+
+	// reservedLinkNames are used by routes under /v1/articles/
+	reservedLinkNames = map[string]bool{
+		"popular": true,
+		"search":  true,
+	}
+)
+
+// ValidateLinkName returns domain.ErrInvalidAliasArticleID if s can't be used as a link name
+func ValidateLinkName(s string) error {
+	if utf8.RuneCountInString(s) > linkNameMaxLength || !patternLinkName.MatchString(s) || reservedLinkNames[s] {
+		return domain.ErrInvalidAliasArticleID
+	}
+	return nil
+}
+
+// LinkNameFromTitle generates a valid link name from title,
+// characters which are not allowed in link name are replaced with hyphens.
+// It leaves some room to make the link name unique by adding a suffix
+func LinkNameFromTitle(title string) string {
+	s := patternLinkNameSkipped.ReplaceAllString(strings.ToLower(title), "-")
+	s = strings.Trim(s, "-")
+
+	if utf8.RuneCountInString(s) > generatedLinkNameMax {
+		s = strings.TrimRight(string([]rune(s)[:generatedLinkNameMax]), "-")
+	}
+
+	if s == "" {
+		return defaultLinkName
+	}
+	if reservedLinkNames[s] {
+		return s + "-" + defaultLinkName
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// RenderedText is the article text rendered to HTML for reading
+type RenderedText struct {
+	html            string
+	tableOfContents []*Heading
+	readingTime     time.Duration
+}
+
+// NewRenderedText creates a new RenderedText
+func NewRenderedText(html string, tableOfContents []*Heading, readingTime time.Duration) *RenderedText {
+	return &RenderedText{
+		html:            html,
+		tableOfContents: tableOfContents,
+		readingTime:     readingTime,
+	}
+}
+
+// HTML returns the sanitized HTML of the body
+func (t *RenderedText) HTML() string {
+	return t.html
+}
+
+// TableOfContents returns the headings of the body in order
+func (t *RenderedText) TableOfContents() []*Heading {
+	return t.tableOfContents
+}
+
+// ReadingTime returns the estimated time to read the article
+func (t *RenderedText) ReadingTime() time.Duration {
+	return t.readingTime
+}
+
+// Heading is an item of the table of contents
+type Heading struct {
+	level  int
+	anchor string
+	title  string
+}
+
+// NewHeading creates a new Heading
+func NewHeading(level int, anchor, title string) *Heading {
+	return &Heading{level: level, anchor: anchor, title: title}
+}
+
+// Level returns the heading level from 1 to 6
+func (h *Heading) Level() int {
+	return h.level
+}
+
+// Anchor returns the id of the heading element
+func (h *Heading) Anchor() string {
+	return h.anchor
+}
+
+// Title returns the text of the heading
+func (h *Heading) Title() string {
+	return h.title
+}
+
+// ArticleRenderer renders article's body for reading
+type ArticleRenderer interface {
+	Render(article *Article) (*RenderedText, error)
+}
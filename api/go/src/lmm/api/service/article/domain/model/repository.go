@@ -1,6 +1,10 @@
 package model
 
-import "lmm/api/pkg/transaction"
+import (
+	"time"
+
+	"lmm/api/pkg/transaction"
+)
 
 // ArticleRepository interface
 type ArticleRepository interface {
@@ -8,16 +12,42 @@ type ArticleRepository interface {
 	Save(tx transaction.Transaction, article *Article) error
 	Remove(tx transaction.Transaction, id *ArticleID) error
 	FindByID(tx transaction.Transaction, id *ArticleID) (*Article, error)
+	FindByLinkName(tx transaction.Transaction, linkName string) (*Article, error)
+	FindScheduledBefore(tx transaction.Transaction, t time.Time) ([]*ArticleID, error)
+	FindIDsByTag(tx transaction.Transaction, tag string) ([]*ArticleID, error)
+	NextRevisionNumber(tx transaction.Transaction, id *ArticleID) (int, error)
+	SaveRevision(tx transaction.Transaction, revision *ArticleRevision) error
+	FindRevision(tx transaction.Transaction, id *ArticleID, number int) (*ArticleRevision, error)
+}
+
+// SeriesRepository interface
+type SeriesRepository interface {
+	NextID(tx transaction.Transaction, ownerID int64) (*SeriesID, error)
+	Save(tx transaction.Transaction, series *Series) error
+	Remove(tx transaction.Transaction, id *SeriesID) error
+	FindByID(tx transaction.Transaction, id *SeriesID) (*Series, error)
+
+	// FindByArticle finds the series which the article is in, returns ErrNoSuchSeries if not in any series
+	FindByArticle(tx transaction.Transaction, id *ArticleID) (*Series, error)
 }
 
 // ArticleViewer defines an interface to query side
 type ArticleViewer interface {
 	ViewArticle(tx transaction.Transaction, linkName string) (*Article, error)
 	ViewArticles(tx transaction.Transaction, count, page int, filter *ArticlesFilter) (*ArticleListView, error)
+	ViewArticlesByCursor(tx transaction.Transaction, count int, cursor string, filter *ArticlesFilter) (*ArticleListView, error)
 	ViewAllTags(tx transaction.Transaction) ([]*TagView, error)
+	ViewArticleRevisions(tx transaction.Transaction, id *ArticleID) ([]*ArticleRevision, error)
+	ViewArticleRevision(tx transaction.Transaction, id *ArticleID, number int) (*ArticleRevision, error)
 }
 
 // ArticlesFilter filtering articles
 type ArticlesFilter struct {
 	Tag string
+
+	// AuthorID lists all articles of the author including drafts, only published articles are listed if zero
+	AuthorID int64
+
+	// PublishedOnly lists only published articles of the author even if AuthorID is not zero
+	PublishedOnly bool
 }
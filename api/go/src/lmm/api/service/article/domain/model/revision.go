@@ -0,0 +1,69 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// ArticleRevision is an immutable snapshot of an article's content,
+// it's a child entity of the article
+type ArticleRevision struct {
+	articleID *ArticleID
+	number    int
+	content   *Content
+	editor    *Author
+	createdAt time.Time
+}
+
+// NewArticleRevision creates a new revision numbered by number of the article
+func NewArticleRevision(articleID *ArticleID, number int, content *Content, editor *Author, createdAt time.Time) *ArticleRevision {
+	return &ArticleRevision{
+		articleID: articleID,
+		number:    number,
+		content:   content,
+		editor:    editor,
+		createdAt: createdAt,
+	}
+}
+
+// ArticleID returns the id of the article which the revision belongs to
+func (r *ArticleRevision) ArticleID() *ArticleID {
+	return r.articleID
+}
+
+// Number returns the revision number which starts from 1
+func (r *ArticleRevision) Number() int {
+	return r.number
+}
+
+// Content returns the content of the article at the revision
+func (r *ArticleRevision) Content() *Content {
+	return r.content
+}
+
+// Editor returns the user who made the revision
+func (r *ArticleRevision) Editor() *Author {
+	return r.editor
+}
+
+// CreatedAt time
+func (r *ArticleRevision) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// lines renders the revision into lines to be compared,
+// which are the title, the tags, an empty line and the body
+func (r *ArticleRevision) lines() []string {
+	tags := make([]string, len(r.content.Tags()), len(r.content.Tags()))
+	for i, tag := range r.content.Tags() {
+		tags[i] = tag.Name()
+	}
+
+	lines := []string{
+		r.content.Text().Title(),
+		strings.Join(tags, ", "),
+		"",
+	}
+
+	return append(lines, strings.Split(r.content.Text().Body(), "\n")...)
+}
@@ -0,0 +1,228 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"lmm/api/clock"
+	"lmm/api/service/article/domain"
+)
+
+var (
+	seriesTitleMaxLength = 140
+)
+
+type SeriesID string
+
+func NewSeriesID(s string) *SeriesID {
+	id := SeriesID(s)
+	return &id
+}
+
+func (id *SeriesID) String() string {
+	return string(*id)
+}
+
+// Series is an aggregate root model grouping articles of the owner in order
+type Series struct {
+	id           *SeriesID
+	owner        *Author
+	title        string
+	description  string
+	articles     []*ArticleID
+	createdAt    time.Time
+	lastModified time.Time
+}
+
+// NewSeries is a series constructor
+func NewSeries(
+	id *SeriesID,
+	owner *Author,
+	title, description string,
+	articles []*ArticleID,
+	createdAt, lastModified time.Time,
+) (*Series, error) {
+	series := &Series{
+		id:           id,
+		owner:        owner,
+		description:  description,
+		createdAt:    createdAt,
+		lastModified: lastModified,
+	}
+
+	if err := series.setTitle(title); err != nil {
+		return nil, err
+	}
+	if err := series.setArticles(articles); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// ID returns the id of the series
+func (s *Series) ID() *SeriesID {
+	return s.id
+}
+
+// Owner returns the owner of the series
+func (s *Series) Owner() *Author {
+	return s.owner
+}
+
+// Title returns the title of the series
+func (s *Series) Title() string {
+	return s.title
+}
+
+// Description returns the description of the series
+func (s *Series) Description() string {
+	return s.description
+}
+
+// Articles returns articles in the series in order
+func (s *Series) Articles() []*ArticleID {
+	return s.articles
+}
+
+// CreatedAt returns the time when the series is created
+func (s *Series) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// LastModified returns the time when the series is modified
+func (s *Series) LastModified() time.Time {
+	return s.lastModified
+}
+
+// CheckOwner returns ErrNotSeriesOwner unless the user owns the series
+func (s *Series) CheckOwner(userID int64) error {
+	if s.owner.ID() != userID {
+		return domain.ErrNotSeriesOwner
+	}
+	return nil
+}
+
+// Edit changes the title, the description and the ordered articles of the series
+func (s *Series) Edit(title, description string, articles []*ArticleID) error {
+	if err := checkSeriesArticles(articles); err != nil {
+		return err
+	}
+	if err := s.setTitle(title); err != nil {
+		return err
+	}
+	s.articles = articles
+	s.description = description
+	s.lastModified = clock.Now()
+	return nil
+}
+
+// RemoveArticle removes the article from the series if it's in the series
+func (s *Series) RemoveArticle(id *ArticleID) {
+	articles := make([]*ArticleID, 0, len(s.articles))
+	for _, articleID := range s.articles {
+		if *articleID != *id {
+			articles = append(articles, articleID)
+		}
+	}
+	if len(articles) != len(s.articles) {
+		s.articles = articles
+		s.lastModified = clock.Now()
+	}
+}
+
+// Contains returns true if the article is in the series
+func (s *Series) Contains(id *ArticleID) bool {
+	return s.indexOf(id) >= 0
+}
+
+// ArticlesBefore returns articles before the article from the nearest one
+func (s *Series) ArticlesBefore(id *ArticleID) []*ArticleID {
+	i := s.indexOf(id)
+	if i < 0 {
+		return []*ArticleID{}
+	}
+
+	articles := make([]*ArticleID, 0, i)
+	for j := i - 1; j >= 0; j-- {
+		articles = append(articles, s.articles[j])
+	}
+	return articles
+}
+
+// ArticlesAfter returns articles after the article from the nearest one
+func (s *Series) ArticlesAfter(id *ArticleID) []*ArticleID {
+	i := s.indexOf(id)
+	if i < 0 {
+		return []*ArticleID{}
+	}
+	return s.articles[i+1:]
+}
+
+func (s *Series) indexOf(id *ArticleID) int {
+	for i, articleID := range s.articles {
+		if *articleID == *id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Series) setTitle(title string) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return domain.ErrEmptySeriesTitle
+	}
+	if utf8.RuneCountInString(title) > seriesTitleMaxLength {
+		return domain.ErrSeriesTitleTooLong
+	}
+	s.title = title
+	return nil
+}
+
+func (s *Series) setArticles(articles []*ArticleID) error {
+	if err := checkSeriesArticles(articles); err != nil {
+		return err
+	}
+	s.articles = articles
+	return nil
+}
+
+func checkSeriesArticles(articles []*ArticleID) error {
+	seen := make(map[ArticleID]bool, len(articles))
+	for _, id := range articles {
+		if seen[*id] {
+			return domain.ErrDuplicateSeriesArticle
+		}
+		seen[*id] = true
+	}
+	return nil
+}
+
+// SeriesNavigation shows where an article is in its series with the nearest articles readable by the reader
+type SeriesNavigation struct {
+	series   *Series
+	previous *Article
+	next     *Article
+}
+
+// NewSeriesNavigation creates a new SeriesNavigation, previous or next is nil if there is no such article
+func NewSeriesNavigation(series *Series, previous, next *Article) *SeriesNavigation {
+	return &SeriesNavigation{series: series, previous: previous, next: next}
+}
+
+// Series returns the series
+func (n *SeriesNavigation) Series() *Series {
+	return n.series
+}
+
+// Previous returns the previous article, nil if the article is the first one
+func (n *SeriesNavigation) Previous() *Article {
+	return n.previous
+}
+
+// Next returns the next article, nil if the article is the last one
+func (n *SeriesNavigation) Next() *Article {
+	return n.next
+}
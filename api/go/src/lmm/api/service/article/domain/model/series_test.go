@@ -0,0 +1,76 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"lmm/api/clock"
+	"lmm/api/service/article/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSeries(t *testing.T, articles ...string) *Series {
+	ids := make([]*ArticleID, len(articles), len(articles))
+	for i, id := range articles {
+		ids[i] = NewArticleID(id)
+	}
+
+	now := clock.Now()
+	series, err := NewSeries(NewSeriesID("series"), NewAuthor(1), "title", "description", ids, now, now)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return series
+}
+
+func articleIDStrings(ids []*ArticleID) []string {
+	s := make([]string, len(ids), len(ids))
+	for i, id := range ids {
+		s[i] = id.String()
+	}
+	return s
+}
+
+func TestNewSeries(t *testing.T) {
+	now := clock.Now()
+
+	_, err := NewSeries(NewSeriesID("series"), NewAuthor(1), " ", "", nil, now, now)
+	assert.Equal(t, domain.ErrEmptySeriesTitle, err)
+
+	_, err = NewSeries(NewSeriesID("series"), NewAuthor(1), strings.Repeat("あ", seriesTitleMaxLength+1), "", nil, now, now)
+	assert.Equal(t, domain.ErrSeriesTitleTooLong, err)
+
+	_, err = NewSeries(NewSeriesID("series"), NewAuthor(1), "title", "", []*ArticleID{NewArticleID("a"), NewArticleID("a")}, now, now)
+	assert.Equal(t, domain.ErrDuplicateSeriesArticle, err)
+}
+
+func TestSeriesNeighbors(t *testing.T) {
+	series := newTestSeries(t, "a", "b", "c")
+
+	assert.Equal(t, []string{}, articleIDStrings(series.ArticlesBefore(NewArticleID("a"))))
+	assert.Equal(t, []string{"b", "c"}, articleIDStrings(series.ArticlesAfter(NewArticleID("a"))))
+	assert.Equal(t, []string{"b", "a"}, articleIDStrings(series.ArticlesBefore(NewArticleID("c"))))
+	assert.Equal(t, []string{}, articleIDStrings(series.ArticlesAfter(NewArticleID("c"))))
+	assert.Empty(t, series.ArticlesBefore(NewArticleID("x")))
+	assert.Empty(t, series.ArticlesAfter(NewArticleID("x")))
+
+	series.RemoveArticle(NewArticleID("b"))
+	assert.False(t, series.Contains(NewArticleID("b")))
+	assert.Equal(t, []string{"c"}, articleIDStrings(series.ArticlesAfter(NewArticleID("a"))))
+}
+
+func TestSeriesEdit(t *testing.T) {
+	series := newTestSeries(t, "a", "b")
+
+	assert.NoError(t, series.CheckOwner(1))
+	assert.Equal(t, domain.ErrNotSeriesOwner, series.CheckOwner(2))
+
+	assert.Equal(t, domain.ErrDuplicateSeriesArticle, series.Edit("new title", "", []*ArticleID{NewArticleID("b"), NewArticleID("b")}))
+	assert.Equal(t, "title", series.Title())
+
+	assert.NoError(t, series.Edit(" new title ", "new description", []*ArticleID{NewArticleID("b"), NewArticleID("a")}))
+	assert.Equal(t, "new title", series.Title())
+	assert.Equal(t, "new description", series.Description())
+	assert.Equal(t, []string{"b", "a"}, articleIDStrings(series.Articles()))
+}
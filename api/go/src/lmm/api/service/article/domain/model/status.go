@@ -0,0 +1,34 @@
+package model
+
+import "lmm/api/service/article/domain"
+
+// ArticleStatus shows the publication state of an article
+type ArticleStatus string
+
+const (
+	// ArticleStatusDraft means the article is only visible to its author
+	ArticleStatusDraft ArticleStatus = "draft"
+
+	// ArticleStatusPublished means the article is visible to everyone and listed
+	ArticleStatusPublished ArticleStatus = "published"
+
+	// ArticleStatusUnlisted means the article is visible to everyone who has its link but not listed
+	ArticleStatusUnlisted ArticleStatus = "unlisted"
+
+	// ArticleStatusScheduled means the article is only visible to its author until its publish time
+	ArticleStatusScheduled ArticleStatus = "scheduled"
+)
+
+// NewArticleStatus parses s into ArticleStatus
+func NewArticleStatus(s string) (ArticleStatus, error) {
+	switch status := ArticleStatus(s); status {
+	case ArticleStatusDraft, ArticleStatusPublished, ArticleStatusUnlisted, ArticleStatusScheduled:
+		return status, nil
+	default:
+		return "", domain.ErrInvalidArticleStatus
+	}
+}
+
+func (s ArticleStatus) String() string {
+	return string(s)
+}
@@ -3,14 +3,32 @@ package domain
 import "errors"
 
 var (
+	ErrArticleAlreadyInSeries     = errors.New("article has already been in another series")
+	ErrArticleAlreadyPublished    = errors.New("article has already been published")
+	ErrArticleLinkNameAlreadyUsed = errors.New("article link name has already been used")
+	ErrArticleTagAlreadyUsed      = errors.New("article tag has already been used")
 	ErrArticleTitleTooLong        = errors.New("article title too long")
+	ErrArticleVersionConflict     = errors.New("article has been modified since the given version")
+	ErrDuplicateSeriesArticle     = errors.New("series has duplicate articles")
 	ErrEmptyArticleTitle          = errors.New("empty article title")
+	ErrEmptySeriesTitle           = errors.New("empty series title")
 	ErrInvalidArticleID           = errors.New("invalid article id")
 	ErrInvalidAliasArticleID      = errors.New("invalid alias article id")
+	ErrInvalidArticleCursor       = errors.New("invalid article cursor")
 	ErrInvalidArticleTitle        = errors.New("invalid article title")
+	ErrInvalidArticleStatus       = errors.New("invalid article status")
+	ErrInvalidPublishTime         = errors.New("publish time should be in the future")
+	ErrInvalidViewWindow          = errors.New("invalid view window")
 	ErrInvalidTagName             = errors.New("invalid tag name")
 	ErrNoSuchArticle              = errors.New("no such article")
+	ErrNoSuchArticleRevision      = errors.New("no such article revision")
+	ErrNoSuchArticleTag           = errors.New("no such article tag")
+	ErrNoSuchSeries               = errors.New("no such series")
 	ErrNoSuchUser                 = errors.New("no such user")
 	ErrNotArticleAuthor           = errors.New("only author allowed to edit article")
+	ErrNotSeriesOwner             = errors.New("only owner allowed to edit series")
+	ErrPublishTimeRequired        = errors.New("publish time required for scheduled articles")
+	ErrSameArticleTag             = errors.New("article tag can't be replaced by itself")
+	ErrSeriesTitleTooLong         = errors.New("series title too long")
 	ErrTagsNotBelongToSameArticle = errors.New("tags are not belong to same article")
 )
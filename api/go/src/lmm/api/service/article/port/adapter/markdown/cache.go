@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"lmm/api/service/article/domain/model"
+)
+
+// CachedRenderer caches rendered articles by their id and version,
+// the least recently used one is evicted when the cache is full
+type CachedRenderer struct {
+	sync.Mutex
+	renderer model.ArticleRenderer
+	capacity int
+	entries  *list.List
+	index    map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key      string
+	rendered *model.RenderedText
+}
+
+// NewCachedRenderer wraps renderer with a cache holding at most capacity articles
+func NewCachedRenderer(renderer model.ArticleRenderer, capacity int) *CachedRenderer {
+	return &CachedRenderer{
+		renderer: renderer,
+		capacity: capacity,
+		entries:  list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+// Render implements model.ArticleRenderer
+func (r *CachedRenderer) Render(article *model.Article) (*model.RenderedText, error) {
+	key := fmt.Sprintf("%s@%d", article.ID().String(), article.Version())
+
+	r.Lock()
+	if elem, ok := r.index[key]; ok {
+		r.entries.MoveToFront(elem)
+		r.Unlock()
+		return elem.Value.(*cacheEntry).rendered, nil
+	}
+	r.Unlock()
+
+	rendered, err := r.renderer.Render(article)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.index[key]; !ok {
+		r.index[key] = r.entries.PushFront(&cacheEntry{key: key, rendered: rendered})
+	}
+	for r.entries.Len() > r.capacity {
+		oldest := r.entries.Back()
+		r.entries.Remove(oldest)
+		delete(r.index, oldest.Value.(*cacheEntry).key)
+	}
+
+	return rendered, nil
+}
@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	wordsPerMinute = 200.
+	cjkPerMinute   = 500.
+)
+
+// Renderer renders article body written in markdown into HTML.
+// Raw HTML in the body is omitted and links with dangerous schemes such as javascript: are dropped
+type Renderer struct {
+	markdown goldmark.Markdown
+}
+
+// NewRenderer creates a new Renderer
+func NewRenderer() *Renderer {
+	return &Renderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(extension.GFM),
+			goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		),
+	}
+}
+
+// Render implements model.ArticleRenderer
+func (r *Renderer) Render(article *model.Article) (*model.RenderedText, error) {
+	source := []byte(article.Content().Text().Body())
+
+	doc := r.markdown.Parser().Parse(text.NewReader(source))
+
+	headings := make([]*model.Heading, 0)
+	err := ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		var anchor string
+		if id, ok := heading.AttributeString("id"); ok {
+			if b, ok := id.([]byte); ok {
+				anchor = string(b)
+			}
+		}
+		headings = append(headings, model.NewHeading(heading.Level, anchor, string(heading.Text(source))))
+
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build table of contents")
+	}
+
+	var html bytes.Buffer
+	if err := r.markdown.Renderer().Render(&html, source, doc); err != nil {
+		return nil, errors.Wrap(err, "failed to render article body")
+	}
+
+	return model.NewRenderedText(html.String(), headings, readingTime(article.Content().Text().Body())), nil
+}
+
+// readingTime estimates reading time by counting words, and characters for CJK which has no spaces between words
+func readingTime(body string) time.Duration {
+	var words, cjk float64
+
+	for _, field := range strings.Fields(body) {
+		inWord := false
+		for _, r := range field {
+			switch {
+			case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+				cjk++
+				inWord = false
+			case unicode.IsLetter(r) || unicode.IsDigit(r):
+				if !inWord {
+					words++
+				}
+				inWord = true
+			}
+		}
+	}
+
+	minutes := math.Ceil(words/wordsPerMinute + cjk/cjkPerMinute)
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lmm/api/service/article/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestArticle(t *testing.T, version uint, body string) *model.Article {
+	content, err := model.NewContent("title", body, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	return model.NewArticle(model.NewArticleID("article"), model.NewAuthor(1), content, model.ArticleStatusPublished, version, now, now, now)
+}
+
+func TestRender(t *testing.T) {
+	body := strings.Join([]string{
+		"# Introduction",
+		"hello **world**",
+		"## Details",
+		"<script>alert(1)</script>",
+		"[link](javascript:alert(1))",
+	}, "\n\n")
+
+	rendered, err := NewRenderer().Render(newTestArticle(t, 1, body))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Contains(t, rendered.HTML(), `<h1 id="introduction">Introduction</h1>`)
+	assert.Contains(t, rendered.HTML(), "<strong>world</strong>")
+	assert.NotContains(t, rendered.HTML(), "<script>")
+	assert.NotContains(t, rendered.HTML(), "javascript:")
+
+	if assert.Len(t, rendered.TableOfContents(), 2) {
+		assert.Equal(t, 1, rendered.TableOfContents()[0].Level())
+		assert.Equal(t, "introduction", rendered.TableOfContents()[0].Anchor())
+		assert.Equal(t, "Introduction", rendered.TableOfContents()[0].Title())
+		assert.Equal(t, 2, rendered.TableOfContents()[1].Level())
+		assert.Equal(t, "Details", rendered.TableOfContents()[1].Title())
+	}
+
+	assert.Equal(t, time.Minute, rendered.ReadingTime())
+}
+
+func TestReadingTime(t *testing.T) {
+	assert.Equal(t, time.Minute, readingTime(""))
+	assert.Equal(t, 2*time.Minute, readingTime(strings.Repeat("word ", 201)))
+	assert.Equal(t, 3*time.Minute, readingTime(strings.Repeat("日本語", 334)))
+}
+
+type countingRenderer struct {
+	count int
+}
+
+func (r *countingRenderer) Render(article *model.Article) (*model.RenderedText, error) {
+	r.count++
+	return model.NewRenderedText(article.Content().Text().Body(), nil, time.Minute), nil
+}
+
+func TestCachedRenderer(t *testing.T) {
+	counting := &countingRenderer{}
+	renderer := NewCachedRenderer(counting, 2)
+
+	render := func(version uint) {
+		_, err := renderer.Render(newTestArticle(t, version, "body"))
+		assert.NoError(t, err)
+	}
+
+	render(1)
+	render(1)
+	assert.Equal(t, 1, counting.count)
+
+	render(2)
+	assert.Equal(t, 2, counting.count)
+
+	// version 1 is evicted
+	render(3)
+	render(1)
+	assert.Equal(t, 4, counting.count)
+}
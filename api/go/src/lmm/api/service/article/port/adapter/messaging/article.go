@@ -0,0 +1,86 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"lmm/api/messaging"
+	"lmm/api/service/article/domain/model"
+)
+
+const (
+	TopicArticlePublished = "ArticlePublished"
+	TopicArticleDeleted   = "ArticleDeleted"
+)
+
+type articleEventPublisher struct {
+	client messaging.Publisher
+}
+
+func NewArticleEventPublisher(pub messaging.Publisher) model.ArticleEventPublisher {
+	return &articleEventPublisher{
+		client: pub,
+	}
+}
+
+type articlePublishedEvent struct {
+	ArticleID          string    `json:"article_id"`
+	AuthorID           int64     `json:"author_id"`
+	Title              string    `json:"title"`
+	ArticlePublishedAt time.Time `json:"article_published_at"`
+
+	topic       string
+	publishedAt time.Time
+}
+
+func (e *articlePublishedEvent) Topic() string {
+	return e.topic
+}
+
+func (e *articlePublishedEvent) PublishedAt() time.Time {
+	return e.publishedAt
+}
+
+func (e *articlePublishedEvent) Message() interface{} {
+	return e
+}
+
+func (p *articleEventPublisher) NotifyArticlePublished(c context.Context, article *model.Article) error {
+	return p.client.Publish(c, &articlePublishedEvent{
+		ArticleID:          article.ID().String(),
+		AuthorID:           article.Author().ID(),
+		Title:              article.Content().Text().Title(),
+		ArticlePublishedAt: article.PublishedAt(),
+		topic:              TopicArticlePublished,
+		publishedAt:        time.Now(),
+	})
+}
+
+type articleDeletedEvent struct {
+	ArticleID string `json:"article_id"`
+	AuthorID  int64  `json:"author_id"`
+
+	topic       string
+	publishedAt time.Time
+}
+
+func (e *articleDeletedEvent) Topic() string {
+	return e.topic
+}
+
+func (e *articleDeletedEvent) PublishedAt() time.Time {
+	return e.publishedAt
+}
+
+func (e *articleDeletedEvent) Message() interface{} {
+	return e
+}
+
+func (p *articleEventPublisher) NotifyArticleDeleted(c context.Context, article *model.Article) error {
+	return p.client.Publish(c, &articleDeletedEvent{
+		ArticleID:   article.ID().String(),
+		AuthorID:    article.Author().ID(),
+		topic:       TopicArticleDeleted,
+		publishedAt: time.Now(),
+	})
+}
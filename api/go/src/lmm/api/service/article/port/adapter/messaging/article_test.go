@@ -0,0 +1,74 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lmm/api/messaging"
+	"lmm/api/pkg/pubsub"
+	"lmm/api/pkg/pubsub/pubsubtest"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleEventPublisher(t *testing.T) {
+	ctx := context.Background()
+
+	content, err := model.NewContent("title", "body", []string{"tag"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	article := model.NewArticle(model.NewArticleID("article"), model.NewAuthor(123), content, model.ArticleStatusPublished, 1, now, now, now)
+
+	t.Run(TopicArticlePublished, func(t *testing.T) {
+		sigChan := make(chan string, 1)
+
+		client := pubsubtest.NewClient()
+		go client.Subscribe(ctx, TopicArticlePublished, func(c context.Context, evt messaging.Event) error {
+			var actual articlePublishedEvent
+
+			assert.Equal(t, TopicArticlePublished, evt.Topic())
+			assert.NoError(t, pubsub.ScanEvent(evt, &actual))
+			assert.Equal(t, "article", actual.ArticleID)
+			assert.Equal(t, int64(123), actual.AuthorID)
+			assert.Equal(t, "title", actual.Title)
+			assert.True(t, now.Equal(actual.ArticlePublishedAt))
+
+			sigChan <- "published"
+			return nil
+		})
+
+		pub := NewArticleEventPublisher(client)
+		assert.NoError(t, pub.NotifyArticlePublished(ctx, article))
+		assert.Equal(t, "published", <-sigChan)
+
+		client.Close()
+	})
+
+	t.Run(TopicArticleDeleted, func(t *testing.T) {
+		sigChan := make(chan string, 1)
+
+		client := pubsubtest.NewClient()
+		go client.Subscribe(ctx, TopicArticleDeleted, func(c context.Context, evt messaging.Event) error {
+			var actual articleDeletedEvent
+
+			assert.Equal(t, TopicArticleDeleted, evt.Topic())
+			assert.NoError(t, pubsub.ScanEvent(evt, &actual))
+			assert.Equal(t, "article", actual.ArticleID)
+			assert.Equal(t, int64(123), actual.AuthorID)
+
+			sigChan <- "deleted"
+			return nil
+		})
+
+		pub := NewArticleEventPublisher(client)
+		assert.NoError(t, pub.NotifyArticleDeleted(ctx, article))
+		assert.Equal(t, "deleted", <-sigChan)
+
+		client.Close()
+	})
+}
@@ -1,7 +1,12 @@
 package persistence
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	dsUtil "lmm/api/pkg/datastore"
@@ -44,24 +49,39 @@ func (s *ArticleDataStore) NextID(tx transaction.Transaction, authorID int64) (*
 }
 
 // Save saves article into datastore
-func (s *ArticleDataStore) Save(tx transaction.Transaction, model *model.Article) error {
-	articleKey := dsUtil.MustKey(model.ID().String())
+func (s *ArticleDataStore) Save(tx transaction.Transaction, article *model.Article) error {
+	articleKey := dsUtil.MustKey(article.ID().String())
 
 	dstx := dsUtil.MustTransaction(tx)
 
 	// save article
 	if _, err := dstx.Mutate(datastore.NewUpsert(articleKey, &dsEntity.Article{
-		Title:        model.Content().Text().Title(),
-		Body:         model.Content().Text().Body(),
-		CreatedAt:    model.CreatedAt(),
-		LastModified: model.LastModified(),
+		LinkName:     article.LinkName(),
+		Title:        article.Content().Text().Title(),
+		Body:         article.Content().Text().Body(),
+		Status:       article.Status().String(),
+		Version:      int64(article.Version()),
+		CreatedAt:    article.CreatedAt(),
+		LastModified: article.LastModified(),
+		PublishedAt:  article.PublishedAt(),
 	})); err != nil {
 		return errors.Wrap(err, "failed to put article into datastore")
 	}
 
-	// get all tag keys by article
-	q := datastore.NewQuery(dsUtil.ArticleTagKind).Ancestor(articleKey).KeysOnly().Transaction(dstx)
-	tagKeys, err := s.dataStore.GetAll(tx, q, nil)
+	// save link name, the old one is kept as an alias
+	if article.LinkName() != "" {
+		linkNameKey := datastore.NameKey(dsUtil.ArticleLinkNameKind, article.LinkName(), nil)
+		if _, err := dstx.Mutate(datastore.NewUpsert(linkNameKey, &dsEntity.LinkName{
+			Article: articleKey,
+		})); err != nil {
+			return errors.Wrap(err, "failed to put article link name into datastore")
+		}
+	}
+
+	// get all tags by article
+	var oldTags []*dsEntity.Tag
+	q := datastore.NewQuery(dsUtil.ArticleTagKind).Ancestor(articleKey).Transaction(dstx)
+	tagKeys, err := s.dataStore.GetAll(tx, q, &oldTags)
 	if err != nil {
 		return errors.Wrap(err, "failed to get article's tags")
 	}
@@ -71,13 +91,17 @@ func (s *ArticleDataStore) Save(tx transaction.Transaction, model *model.Article
 		return errors.Wrap(err, "failed to clear article tags")
 	}
 
+	deltas := make(map[string]int64)
+	countPublishedTags(deltas, oldTags, -1)
+
 	tagKeys = tagKeys[:0]
-	tags := make([]*dsEntity.Tag, len(model.Content().Tags()), len(model.Content().Tags()))
-	for i, model := range model.Content().Tags() {
+	tags := make([]*dsEntity.Tag, len(article.Content().Tags()), len(article.Content().Tags()))
+	for i, model := range article.Content().Tags() {
 		tagKeys = append(tagKeys, datastore.IncompleteKey(dsUtil.ArticleTagKind, articleKey))
 		tags[i] = &dsEntity.Tag{
 			Name:      model.Name(),
 			Order:     int(model.Order()),
+			Status:    article.Status().String(),
 			CreatedAt: time.Now(),
 		}
 	}
@@ -87,7 +111,9 @@ func (s *ArticleDataStore) Save(tx transaction.Transaction, model *model.Article
 		return errors.Wrap(err, "failed to put tags into datastore")
 	}
 
-	return nil
+	countPublishedTags(deltas, tags, 1)
+
+	return updateTagStats(dstx, deltas)
 }
 
 func (s *ArticleDataStore) FindByID(tx transaction.Transaction, id *model.ArticleID) (*model.Article, error) {
@@ -125,28 +151,342 @@ func (s *ArticleDataStore) FindByID(tx transaction.Transaction, id *model.Articl
 
 	author := model.NewAuthor(articleKey.Parent.ID)
 
-	return model.NewArticle(id, author, content, data.CreatedAt, data.LastModified), nil
+	status, publishedAt := articleStatus(&data)
+
+	article := model.NewArticle(id, author, content, status, uint(data.Version), data.CreatedAt, data.LastModified, publishedAt)
+	if data.LinkName != "" {
+		if err := article.ChangeLinkName(data.LinkName); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+	}
+
+	return article, nil
+}
+
+// FindByLinkName finds article by its link name or one of its old link names
+func (s *ArticleDataStore) FindByLinkName(tx transaction.Transaction, linkName string) (*model.Article, error) {
+	linkNameKey := datastore.NameKey(dsUtil.ArticleLinkNameKind, linkName, nil)
+
+	var data dsEntity.LinkName
+	if err := dsUtil.MustTransaction(tx).Get(linkNameKey, &data); err != nil {
+		return nil, errors.Wrapf(domain.ErrNoSuchArticle, "%s: %s", err.Error(), linkName)
+	}
+
+	return s.FindByID(tx, model.NewArticleID(data.Article.Encode()))
 }
 
+// articleStatus regards articles saved before publication state was introduced as published
+func articleStatus(data *dsEntity.Article) (model.ArticleStatus, time.Time) {
+	if data.Status == "" {
+		return model.ArticleStatusPublished, data.CreatedAt
+	}
+	return model.ArticleStatus(data.Status), data.PublishedAt
+}
+
+// Remove deletes article and its tags and revisions from datastore
 func (s *ArticleDataStore) Remove(tx transaction.Transaction, id *model.ArticleID) error {
-	panic("not implemented")
+	articleKey, err := datastore.DecodeKey(id.String())
+	if err != nil {
+		return errors.Wrapf(domain.ErrNoSuchArticle, "%s: %s", err.Error(), id.String())
+	}
+
+	dstx := dsUtil.MustTransaction(tx)
+
+	// get all tags by article
+	var tags []*dsEntity.Tag
+	q := datastore.NewQuery(dsUtil.ArticleTagKind).Ancestor(articleKey).Transaction(dstx)
+	tagKeys, err := s.dataStore.GetAll(tx, q, &tags)
+	if err != nil {
+		return errors.Wrap(err, "failed to get article's tags")
+	}
+
+	deltas := make(map[string]int64)
+	countPublishedTags(deltas, tags, -1)
+	if err := updateTagStats(dstx, deltas); err != nil {
+		return err
+	}
+
+	// get all revision keys by article
+	q = datastore.NewQuery(dsUtil.ArticleRevisionKind).Ancestor(articleKey).KeysOnly().Transaction(dstx)
+	revisionKeys, err := s.dataStore.GetAll(tx, q, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get article's revisions")
+	}
+	tagKeys = append(tagKeys, revisionKeys...)
+
+	// remove the article from its series, which is in the same entity group
+	var series []*dsEntity.Series
+	q = datastore.NewQuery(dsUtil.ArticleSeriesKind).Ancestor(articleKey.Parent).Filter("Articles =", articleKey).Transaction(dstx)
+	seriesKeys, err := s.dataStore.GetAll(tx, q, &series)
+	if err != nil {
+		return errors.Wrap(err, "failed to get article's series")
+	}
+	for _, entity := range series {
+		articles := make([]*datastore.Key, 0, len(entity.Articles))
+		for _, key := range entity.Articles {
+			if !key.Equal(articleKey) {
+				articles = append(articles, key)
+			}
+		}
+		entity.Articles = articles
+	}
+	if _, err := dstx.PutMulti(seriesKeys, series); err != nil {
+		return errors.Wrap(err, "failed to remove article from series")
+	}
+
+	// link names are not in the article's entity group
+	linkNameKeys, err := s.dataStore.GetAll(tx, datastore.NewQuery(dsUtil.ArticleLinkNameKind).Filter("Article =", articleKey).KeysOnly(), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get article's link names")
+	}
+
+	// delete article with its tags and link names
+	if err := dstx.DeleteMulti(append(append(tagKeys, linkNameKeys...), articleKey)); err != nil {
+		return errors.Wrap(err, "failed to delete article")
+	}
+
+	return nil
+}
+
+// FindAllPublished finds all published articles, used to build the search index
+func (s *ArticleDataStore) FindAllPublished(c context.Context) ([]*model.Article, error) {
+	q := datastore.NewQuery(dsUtil.ArticleKind).KeysOnly().Filter("Status =", model.ArticleStatusPublished.String())
+
+	articles, err := s.findAllByQuery(c, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get published articles")
+	}
+	return articles, nil
+}
+
+// FindAll finds all articles in any status, used to export articles
+func (s *ArticleDataStore) FindAll(c context.Context) ([]*model.Article, error) {
+	articles, err := s.findAllByQuery(c, datastore.NewQuery(dsUtil.ArticleKind).KeysOnly())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get articles")
+	}
+	return articles, nil
+}
+
+func (s *ArticleDataStore) findAllByQuery(c context.Context, q *datastore.Query) ([]*model.Article, error) {
+	keys, err := s.dataStore.GetAll(c, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]*model.Article, len(keys), len(keys))
+	for i, key := range keys {
+		if err := s.RunInTransaction(c, func(tx transaction.Transaction) error {
+			articles[i], err = s.FindByID(tx, model.NewArticleID(key.Encode()))
+			return err
+		}, &transaction.Option{ReadOnly: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	return articles, nil
+}
+
+// FindIDsByTag finds ids of all articles tagged by tag including drafts
+func (s *ArticleDataStore) FindIDsByTag(tx transaction.Transaction, tag string) ([]*model.ArticleID, error) {
+	q := datastore.NewQuery(dsUtil.ArticleTagKind).KeysOnly().Filter("Name =", tag)
+
+	keys, err := s.dataStore.GetAll(tx, q, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tag keys")
+	}
+
+	seen := make(map[string]bool, len(keys))
+	ids := make([]*model.ArticleID, 0, len(keys))
+	for _, key := range keys {
+		encoded := key.Parent.Encode()
+		if !seen[encoded] {
+			seen[encoded] = true
+			ids = append(ids, model.NewArticleID(encoded))
+		}
+	}
+
+	return ids, nil
+}
+
+// FindScheduledBefore finds ids of scheduled articles which should be published before t
+func (s *ArticleDataStore) FindScheduledBefore(tx transaction.Transaction, t time.Time) ([]*model.ArticleID, error) {
+	q := datastore.NewQuery(dsUtil.ArticleKind).KeysOnly().
+		Filter("Status =", model.ArticleStatusScheduled.String()).
+		Filter("PublishedAt <=", t)
+
+	keys, err := s.dataStore.GetAll(tx, q, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get scheduled articles")
+	}
+
+	ids := make([]*model.ArticleID, len(keys), len(keys))
+	for i, key := range keys {
+		ids[i] = model.NewArticleID(key.Encode())
+	}
+
+	return ids, nil
+}
+
+// NextRevisionNumber returns the number of the revision to be saved next
+func (s *ArticleDataStore) NextRevisionNumber(tx transaction.Transaction, id *model.ArticleID) (int, error) {
+	articleKey, err := datastore.DecodeKey(id.String())
+	if err != nil {
+		return 0, errors.Wrapf(domain.ErrNoSuchArticle, "%s: %s", err.Error(), id.String())
+	}
+
+	q := datastore.NewQuery(dsUtil.ArticleRevisionKind).Ancestor(articleKey).KeysOnly().Transaction(dsUtil.MustTransaction(tx))
+	keys, err := s.dataStore.GetAll(tx, q, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get article's revisions")
+	}
+
+	var number int64
+	for _, key := range keys {
+		if key.ID > number {
+			number = key.ID
+		}
+	}
+
+	return int(number) + 1, nil
 }
 
-func (s *ArticleDataStore) ViewArticle(tx transaction.Transaction, id string) (*model.Article, error) {
-	return s.FindByID(tx, model.NewArticleID(id))
+// SaveRevision saves revision as a child of the article
+func (s *ArticleDataStore) SaveRevision(tx transaction.Transaction, revision *model.ArticleRevision) error {
+	articleKey, err := datastore.DecodeKey(revision.ArticleID().String())
+	if err != nil {
+		return errors.Wrapf(domain.ErrNoSuchArticle, "%s: %s", err.Error(), revision.ArticleID().String())
+	}
+
+	tags := make([]string, len(revision.Content().Tags()), len(revision.Content().Tags()))
+	for i, tag := range revision.Content().Tags() {
+		tags[i] = tag.Name()
+	}
+
+	key := datastore.IDKey(dsUtil.ArticleRevisionKind, int64(revision.Number()), articleKey)
+	if _, err := dsUtil.MustTransaction(tx).Put(key, &dsEntity.ArticleRevision{
+		Title:     revision.Content().Text().Title(),
+		Body:      revision.Content().Text().Body(),
+		Tags:      tags,
+		EditorID:  revision.Editor().ID(),
+		CreatedAt: revision.CreatedAt(),
+	}); err != nil {
+		return errors.Wrap(err, "failed to put article revision into datastore")
+	}
+
+	return nil
+}
+
+// FindRevision finds the revision of the article by its number
+func (s *ArticleDataStore) FindRevision(tx transaction.Transaction, id *model.ArticleID, number int) (*model.ArticleRevision, error) {
+	articleKey, err := datastore.DecodeKey(id.String())
+	if err != nil {
+		return nil, errors.Wrapf(domain.ErrNoSuchArticle, "%s: %s", err.Error(), id.String())
+	}
+
+	var data dsEntity.ArticleRevision
+	key := datastore.IDKey(dsUtil.ArticleRevisionKind, int64(number), articleKey)
+	if err := dsUtil.MustTransaction(tx).Get(key, &data); err != nil {
+		return nil, errors.Wrapf(domain.ErrNoSuchArticleRevision, "%s: %d", err.Error(), number)
+	}
+
+	return s.articleRevisionFromEntity(id, number, &data)
+}
+
+func (s *ArticleDataStore) articleRevisionFromEntity(id *model.ArticleID, number int, data *dsEntity.ArticleRevision) (*model.ArticleRevision, error) {
+	content, err := model.NewContent(data.Title, data.Body, data.Tags)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	return model.NewArticleRevision(id, number, content, model.NewAuthor(data.EditorID), data.CreatedAt), nil
+}
+
+// FindAllRevisions finds revisions of all articles, used to export revisions
+func (s *ArticleDataStore) FindAllRevisions(c context.Context) ([]*model.ArticleRevision, error) {
+	var entities []*dsEntity.ArticleRevision
+	keys, err := s.dataStore.GetAll(c, datastore.NewQuery(dsUtil.ArticleRevisionKind), &entities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get revisions")
+	}
+
+	revisions := make([]*model.ArticleRevision, len(entities), len(entities))
+	for i, entity := range entities {
+		revision, err := s.articleRevisionFromEntity(model.NewArticleID(keys[i].Parent.Encode()), int(keys[i].ID), entity)
+		if err != nil {
+			return nil, err
+		}
+		revisions[i] = revision
+	}
+
+	return revisions, nil
+}
+
+// ViewArticleRevisions lists all revisions of the article from the latest one
+func (s *ArticleDataStore) ViewArticleRevisions(tx transaction.Transaction, id *model.ArticleID) ([]*model.ArticleRevision, error) {
+	articleKey, err := datastore.DecodeKey(id.String())
+	if err != nil {
+		return nil, errors.Wrapf(domain.ErrNoSuchArticle, "%s: %s", err.Error(), id.String())
+	}
+
+	var entities []*dsEntity.ArticleRevision
+	q := datastore.NewQuery(dsUtil.ArticleRevisionKind).Ancestor(articleKey).Transaction(dsUtil.MustTransaction(tx))
+	keys, err := s.dataStore.GetAll(tx, q, &entities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get article's revisions")
+	}
+
+	revisions := make([]*model.ArticleRevision, len(entities), len(entities))
+	for i, entity := range entities {
+		revision, err := s.articleRevisionFromEntity(id, int(keys[i].ID), entity)
+		if err != nil {
+			return nil, err
+		}
+		revisions[i] = revision
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number() > revisions[j].Number()
+	})
+
+	return revisions, nil
+}
+
+// ViewArticleRevision finds the revision of the article by its number
+func (s *ArticleDataStore) ViewArticleRevision(tx transaction.Transaction, id *model.ArticleID, number int) (*model.ArticleRevision, error) {
+	return s.FindRevision(tx, id, number)
+}
+
+// ViewArticle finds article by its link name, or id if no article named by it
+func (s *ArticleDataStore) ViewArticle(tx transaction.Transaction, linkNameOrID string) (*model.Article, error) {
+	article, err := s.FindByLinkName(tx, linkNameOrID)
+	if errors.Cause(err) == domain.ErrNoSuchArticle {
+		return s.FindByID(tx, model.NewArticleID(linkNameOrID))
+	}
+	return article, err
 }
 
 func (s *ArticleDataStore) ViewArticles(tx transaction.Transaction, count, page int, filter *model.ArticlesFilter) (*model.ArticleListView, error) {
-	if filter != nil && filter.Tag != "" {
-		return s.viewArticlesFilteredByTag(tx, count, page, filter.Tag)
+	if filter == nil {
+		filter = &model.ArticlesFilter{}
+	}
+
+	if filter.Tag != "" {
+		return s.viewArticlesFilteredByTag(tx, count, page, filter)
+	}
+
+	if filter.AuthorID != 0 {
+		return s.viewArticlesByAuthor(tx, count, page, filter)
 	}
 
 	return s.viewAllArticles(tx, count, page)
 }
 
 func (s *ArticleDataStore) viewAllArticles(tx transaction.Transaction, count, page int) (*model.ArticleListView, error) {
-	counting := datastore.NewQuery(dsUtil.ArticleKind)
-	paging := datastore.NewQuery(dsUtil.ArticleKind).Project("__key__", "Title", "CreatedAt").Order("-CreatedAt").Limit(count + 1).Offset((page - 1) * count)
+	published := model.ArticleStatusPublished.String()
+
+	counting := datastore.NewQuery(dsUtil.ArticleKind).Filter("Status =", published)
+	paging := datastore.NewQuery(dsUtil.ArticleKind).Filter("Status =", published).Project("__key__", "Title", "CreatedAt").Order("-CreatedAt").Limit(count + 1).Offset((page - 1) * count)
 
 	total, err := s.dataStore.Count(tx, counting)
 	if err != nil {
@@ -168,7 +508,7 @@ func (s *ArticleDataStore) viewAllArticles(tx transaction.Transaction, count, pa
 	items := make([]*model.ArticleListViewItem, len(entities), len(entities))
 	for i, entity := range entities {
 		id := model.NewArticleID(keys[i].Encode())
-		item, err := model.NewArticleListViewItem(id, entity.Title, time.Unix(entity.CreatedAt/dsUtil.UnixFactor, 0))
+		item, err := model.NewArticleListViewItem(id, entity.Title, model.ArticleStatusPublished, time.Unix(entity.CreatedAt/dsUtil.UnixFactor, 0))
 		if err != nil {
 			return nil, errors.Wrap(err, "internal error")
 		}
@@ -178,12 +518,62 @@ func (s *ArticleDataStore) viewAllArticles(tx transaction.Transaction, count, pa
 	return model.NewArticleListView(items, "", page, count, total, hasNextPage), nil
 }
 
-func (s *ArticleDataStore) viewArticlesFilteredByTag(tx transaction.Transaction, count, page int, tag string) (*model.ArticleListView, error) {
-	dstx := dsUtil.MustTransaction(tx)
+func (s *ArticleDataStore) viewArticlesByAuthor(tx transaction.Transaction, count, page int, filter *model.ArticlesFilter) (*model.ArticleListView, error) {
+	userKey := datastore.IDKey(dsUtil.UserKind, filter.AuthorID, nil)
+
+	counting := datastore.NewQuery(dsUtil.ArticleKind).Ancestor(userKey).KeysOnly()
+	paging := datastore.NewQuery(dsUtil.ArticleKind).Ancestor(userKey).KeysOnly().Order("-CreatedAt").Limit(count + 1).Offset((page - 1) * count)
+
+	if filter.PublishedOnly {
+		published := model.ArticleStatusPublished.String()
+		counting = counting.Filter("Status =", published)
+		paging = paging.Filter("Status =", published)
+	}
+
+	total, err := s.dataStore.Count(tx, counting)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get total number of articles")
+	}
+
+	articleKeys, err := s.dataStore.GetAll(tx, paging, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get article keys")
+	}
+
+	hasNextPage := false
+	if len(articleKeys) > int(count) {
+		hasNextPage = true
+		articleKeys = articleKeys[:int(count)]
+	}
+
+	items, err := s.viewArticleListItems(tx, articleKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewArticleListView(items, "", page, count, total, hasNextPage), nil
+}
+
+// viewArticlesFilteredByTag lists published articles tagged by tag,
+// or all articles of the author tagged by tag if filter.AuthorID is not zero
+func (s *ArticleDataStore) viewArticlesFilteredByTag(tx transaction.Transaction, count, page int, filter *model.ArticlesFilter) (*model.ArticleListView, error) {
+	tag := filter.Tag
 
 	counting := datastore.NewQuery(dsUtil.ArticleTagKind).Filter("Name =", tag)
 	paging := datastore.NewQuery(dsUtil.ArticleTagKind).Filter("Name =", tag).KeysOnly().Order("-CreatedAt").Limit(count + 1).Offset((page - 1) * count)
 
+	if filter.AuthorID != 0 {
+		userKey := datastore.IDKey(dsUtil.UserKind, filter.AuthorID, nil)
+		counting = counting.Ancestor(userKey)
+		paging = paging.Ancestor(userKey)
+	}
+
+	if filter.AuthorID == 0 || filter.PublishedOnly {
+		published := model.ArticleStatusPublished.String()
+		counting = counting.Filter("Status =", published)
+		paging = paging.Filter("Status =", published)
+	}
+
 	total, err := s.dataStore.Count(tx, counting)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get total number of articles")
@@ -194,11 +584,129 @@ func (s *ArticleDataStore) viewArticlesFilteredByTag(tx transaction.Transaction,
 		return nil, errors.Wrap(err, "failed to count tags")
 	}
 
-	articleKeys := make([]*datastore.Key, len(keys))
-	for i := range keys {
-		articleKeys[i] = keys[i].Parent
+	// trim the extra key before loading articles so that the article of the next page is not fetched
+	hasNextPage := false
+	if len(keys) > int(count) {
+		hasNextPage = true
+		keys = keys[:int(count)]
+	}
+
+	items, err := s.viewArticleListItems(tx, tagParentKeys(keys))
+	if err != nil {
+		return nil, err
 	}
 
+	return model.NewArticleListView(items, tag, page, count, total, hasNextPage), nil
+}
+
+// ViewArticlesByCursor lists articles from the position of cursor, the first page if cursor is empty.
+// Unlike ViewArticles, it neither counts articles nor skips articles by offset
+func (s *ArticleDataStore) ViewArticlesByCursor(tx transaction.Transaction, count int, cursor string, filter *model.ArticlesFilter) (*model.ArticleListView, error) {
+	if filter == nil {
+		filter = &model.ArticlesFilter{}
+	}
+
+	published := model.ArticleStatusPublished.String()
+
+	var q *datastore.Query
+	switch {
+	case filter.Tag != "":
+		q = datastore.NewQuery(dsUtil.ArticleTagKind).Filter("Name =", filter.Tag).KeysOnly().Order("-CreatedAt")
+		if filter.AuthorID != 0 {
+			q = q.Ancestor(datastore.IDKey(dsUtil.UserKind, filter.AuthorID, nil))
+		}
+		if filter.AuthorID == 0 || filter.PublishedOnly {
+			q = q.Filter("Status =", published)
+		}
+	case filter.AuthorID != 0:
+		q = datastore.NewQuery(dsUtil.ArticleKind).Ancestor(datastore.IDKey(dsUtil.UserKind, filter.AuthorID, nil)).KeysOnly().Order("-CreatedAt")
+		if filter.PublishedOnly {
+			q = q.Filter("Status =", published)
+		}
+	default:
+		q = datastore.NewQuery(dsUtil.ArticleKind).Filter("Status =", published).KeysOnly().Order("-CreatedAt")
+	}
+
+	keys, nextCursor, err := s.runKeysByCursor(tx, q, count, cursor, articlesFilterDigest(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Tag != "" {
+		keys = tagParentKeys(keys)
+	}
+
+	items, err := s.viewArticleListItems(tx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewArticleCursorListView(items, filter.Tag, count, nextCursor), nil
+}
+
+// articlesFilterDigest identifies the query made from the filter, cursors are bound to it
+func articlesFilterDigest(filter *model.ArticlesFilter) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s\n%d\n%t\n", filter.Tag, filter.AuthorID, filter.PublishedOnly)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// runKeysByCursor gets at most count keys from the position of cursor,
+// the returned cursor is empty if there are no more keys.
+// Cursors are prefixed with the digest of the query, a cursor of another query is invalid
+func (s *ArticleDataStore) runKeysByCursor(tx transaction.Transaction, q *datastore.Query, count int, cursor, digest string) ([]*datastore.Key, string, error) {
+	if cursor != "" {
+		if !strings.HasPrefix(cursor, digest+".") {
+			return nil, "", errors.Wrap(domain.ErrInvalidArticleCursor, "cursor of another query")
+		}
+		dsCursor, err := datastore.DecodeCursor(strings.TrimPrefix(cursor, digest+"."))
+		if err != nil {
+			return nil, "", errors.Wrap(domain.ErrInvalidArticleCursor, err.Error())
+		}
+		q = q.Start(dsCursor)
+	}
+
+	iter := s.dataStore.Run(tx, q.Limit(count+1))
+
+	keys := make([]*datastore.Key, 0, count)
+	for len(keys) < count {
+		key, err := iter.Next(nil)
+		if err == iterator.Done {
+			return keys, "", nil
+		}
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to get article keys")
+		}
+		keys = append(keys, key)
+	}
+
+	nextCursor, err := iter.Cursor()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to get datastore cursor")
+	}
+
+	// look ahead one more key to know whether there is the next page
+	if _, err := iter.Next(nil); err == iterator.Done {
+		return keys, "", nil
+	} else if err != nil {
+		return nil, "", errors.Wrap(err, "failed to get article keys")
+	}
+
+	return keys, digest + "." + nextCursor.String(), nil
+}
+
+// tagParentKeys returns keys of articles which tags belong to
+func tagParentKeys(tagKeys []*datastore.Key) []*datastore.Key {
+	articleKeys := make([]*datastore.Key, len(tagKeys))
+	for i := range tagKeys {
+		articleKeys[i] = tagKeys[i].Parent
+	}
+	return articleKeys
+}
+
+func (s *ArticleDataStore) viewArticleListItems(tx transaction.Transaction, articleKeys []*datastore.Key) ([]*model.ArticleListViewItem, error) {
+	dstx := dsUtil.MustTransaction(tx)
+
 	articles := make([]*dsEntity.Article, len(articleKeys))
 	if err := dstx.GetMulti(articleKeys, articles); err != nil {
 		return nil, errors.Wrap(err, "failed to get articles")
@@ -207,43 +715,30 @@ func (s *ArticleDataStore) viewArticlesFilteredByTag(tx transaction.Transaction,
 	items := make([]*model.ArticleListViewItem, len(articles), len(articles))
 	for i := range items {
 		id := model.NewArticleID(articleKeys[i].Encode())
-		m, err := model.NewArticleListViewItem(id, articles[i].Title, articles[i].CreatedAt)
+		status, _ := articleStatus(articles[i])
+		m, err := model.NewArticleListViewItem(id, articles[i].Title, status, articles[i].CreatedAt)
 		if err != nil {
 			return nil, errors.Wrap(err, "internal error")
 		}
 		items[i] = m
 	}
 
-	hasNextPage := false
-	if len(items) > int(count) {
-		hasNextPage = true
-		items = items[:int(count)]
-	}
-
-	return model.NewArticleListView(items, tag, page, count, total, hasNextPage), nil
+	return items, nil
 }
 
+// ViewAllTags lists tags of published articles by name with the number of articles tagged
 func (s *ArticleDataStore) ViewAllTags(tx transaction.Transaction) ([]*model.TagView, error) {
-	q := datastore.NewQuery(dsUtil.ArticleTagKind).Project("Name").DistinctOn("Name").Order("Name")
-
-	var t dsEntity.Tag
-	items := make([]*model.TagView, 0)
+	var stats []*dsEntity.TagStat
+	keys, err := s.dataStore.GetAll(tx, datastore.NewQuery(dsUtil.ArticleTagStatKind).Order("__key__"), &stats)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tag stats")
+	}
 
-	iter := s.dataStore.Run(tx, q)
-	for {
-		_, err := iter.Next(&t)
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return nil, errors.Wrap(err, "internal error: invalid tag")
-		}
-		cq := datastore.NewQuery(dsUtil.ArticleTagKind).KeysOnly().Filter("Name =", t.Name)
-		c, err := s.dataStore.Count(tx, cq)
-		if err != nil {
-			return nil, errors.Wrapf(err, "infra: error on counting the number of tag named %s", t.Name)
+	items := make([]*model.TagView, 0, len(keys))
+	for i, key := range keys {
+		if stats[i].Count > 0 {
+			items = append(items, model.NewTagView(key.Name, int(stats[i].Count)))
 		}
-		items = append(items, model.NewTagView(t.Name, c))
 	}
 
 	return items, nil
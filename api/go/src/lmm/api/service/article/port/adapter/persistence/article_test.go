@@ -60,7 +60,7 @@ func TestArticleDataStore(t *testing.T) {
 				}
 
 				now := clock.Now()
-				article = model.NewArticle(articleID, model.NewAuthor(authorID), content, now, now)
+				article = model.NewArticle(articleID, model.NewAuthor(authorID), content, model.ArticleStatusPublished, 1, now, now, now)
 				if !assert.NoError(t, articleDataStore.Save(tx, article)) || !assert.NotNil(t, article) {
 					t.Fatal("failed to save article")
 				}
@@ -119,6 +119,22 @@ func TestArticleDataStore(t *testing.T) {
 				}, &transaction.Option{ReadOnly: true})
 			})
 		})
+
+		t.Run("Remove", func(t *testing.T) {
+			assert.NoError(t, articleDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+				return articleDataStore.Remove(tx, article.ID())
+			}, nil))
+
+			t.Run("FindByID", func(t *testing.T) {
+				articleDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+					articleFound, err := articleDataStore.FindByID(tx, article.ID())
+					assert.Equal(t, domain.ErrNoSuchArticle, errors.Cause(err))
+					assert.Nil(t, articleFound)
+
+					return nil
+				}, nil)
+			})
+		})
 	})
 
 	t.Run("ViewArticle", func(t *testing.T) {
@@ -133,3 +149,83 @@ func TestArticleDataStore(t *testing.T) {
 		})
 	})
 }
+
+func TestViewAllTags(t *testing.T) {
+	ctx := context.Background()
+
+	dataStore, err := datastore.NewClient(context.Background(), "")
+	if err != nil {
+		panic(errors.Wrap(err, "failed to connect to datastore"))
+	}
+
+	articleDataStore := NewArticleDataStore(dataStore)
+
+	tag := "tag" + uuidutil.NewUUID()
+
+	countTag := func() int {
+		var count int
+		assert.NoError(t, articleDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			tags, err := articleDataStore.ViewAllTags(tx)
+			for _, view := range tags {
+				if view.Name() == tag {
+					count = view.Count()
+				}
+			}
+			return err
+		}, &transaction.Option{ReadOnly: true}))
+		return count
+	}
+
+	save := func(article *model.Article) {
+		assert.NoError(t, articleDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			return articleDataStore.Save(tx, article)
+		}, nil))
+	}
+
+	newArticle := func(status model.ArticleStatus) *model.Article {
+		var article *model.Article
+		assert.NoError(t, articleDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			articleID, err := articleDataStore.NextID(tx, 1)
+			if err != nil {
+				return err
+			}
+
+			content, err := model.NewContent(uuidutil.NewUUID(), uuidutil.NewUUID(), []string{tag})
+			if err != nil {
+				return err
+			}
+
+			now := clock.Now()
+			article = model.NewArticle(articleID, model.NewAuthor(1), content, status, 1, now, now, now)
+			return nil
+		}, nil))
+		return article
+	}
+
+	published := newArticle(model.ArticleStatusPublished)
+	save(published)
+	save(newArticle(model.ArticleStatusDraft))
+	assert.Equal(t, 1, countTag())
+
+	t.Run("Resave", func(t *testing.T) {
+		save(published)
+		assert.Equal(t, 1, countTag())
+	})
+
+	t.Run("Unpublish", func(t *testing.T) {
+		published.Unpublish()
+		save(published)
+		assert.Equal(t, 0, countTag())
+
+		published.Publish(false, clock.Now())
+		save(published)
+		assert.Equal(t, 1, countTag())
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(t, articleDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			return articleDataStore.Remove(tx, published.ID())
+		}, nil))
+		assert.Equal(t, 0, countTag())
+	})
+}
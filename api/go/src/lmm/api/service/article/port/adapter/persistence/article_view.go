@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	dsUtil "lmm/api/pkg/datastore"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+	dsEntity "lmm/api/service/article/port/adapter/persistence/internal/datastore"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+var (
+	// articleViewShards is the number of counter shards per article and day,
+	// views are added to one of them so that a hot article doesn't contend on one entity
+	articleViewShards = 8
+)
+
+type ArticleViewDataStore struct {
+	dataStore *datastore.Client
+}
+
+func NewArticleViewDataStore(dataStore *datastore.Client) *ArticleViewDataStore {
+	return &ArticleViewDataStore{dataStore: dataStore}
+}
+
+// AddViews adds views to a random shard of the article's counter in the day
+func (s *ArticleViewDataStore) AddViews(tx transaction.Transaction, views *model.ArticleViewCount) error {
+	articleKey, err := datastore.DecodeKey(views.ArticleID().String())
+	if err != nil {
+		return errors.Wrapf(domain.ErrNoSuchArticle, "%s: %s", err.Error(), views.ArticleID().String())
+	}
+
+	name := fmt.Sprintf("%s:%s:%d", views.ArticleID().String(), views.Day().Format("20060102"), rand.Intn(articleViewShards))
+	key := datastore.NameKey(dsUtil.ArticleViewKind, name, nil)
+
+	dstx := dsUtil.MustTransaction(tx)
+
+	var shard dsEntity.ArticleView
+	if err := dstx.Get(key, &shard); err != nil && err != datastore.ErrNoSuchEntity {
+		return errors.Wrap(err, "failed to get article view counter")
+	}
+
+	shard.Article = articleKey
+	shard.Day = views.Day()
+	shard.Count += views.Count()
+
+	if _, err := dstx.Put(key, &shard); err != nil {
+		return errors.Wrap(err, "failed to put article view counter")
+	}
+
+	return nil
+}
+
+// TotalViewsSince sums up all shards of counters since the day
+func (s *ArticleViewDataStore) TotalViewsSince(tx transaction.Transaction, day time.Time) (map[model.ArticleID]int64, error) {
+	q := datastore.NewQuery(dsUtil.ArticleViewKind).Filter("Day >=", day)
+
+	var shards []*dsEntity.ArticleView
+	if _, err := s.dataStore.GetAll(tx, q, &shards); err != nil {
+		return nil, errors.Wrap(err, "failed to get article view counters")
+	}
+
+	views := make(map[model.ArticleID]int64)
+	for _, shard := range shards {
+		views[model.ArticleID(shard.Article.Encode())] += shard.Count
+	}
+
+	return views, nil
+}
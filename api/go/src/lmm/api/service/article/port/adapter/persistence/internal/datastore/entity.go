@@ -7,20 +7,61 @@ import (
 )
 
 type Article struct {
+	LinkName     string    `datastore:"LinkName,noindex"`
 	Title        string    `datastore:"Title"`
 	Body         string    `datastore:"Body,noindex"`
+	Status       string    `datastore:"Status"`
+	Version      int64     `datastore:"Version,noindex"`
 	CreatedAt    time.Time `datastore:"CreatedAt"`
 	LastModified time.Time `datastore:"LastModified,noindex"`
+	PublishedAt  time.Time `datastore:"PublishedAt"`
+}
+
+// LinkName refers to the article named by the key name, old link names are kept as aliases
+type LinkName struct {
+	Article *datastore.Key `datastore:"Article"`
 }
 
 type Tag struct {
 	ID        *datastore.Key `datastore:"__key__"`
 	Name      string         `datastore:"Name"`
 	Order     int            `datastore:"Order"`
+	Status    string         `datastore:"Status"`
 	CreatedAt time.Time      `datastore:"CreatedAt"`
 }
 
+// TagStat counts published articles tagged by the tag named by the key name,
+// it's maintained along with tags so that listing tags doesn't need to count them
+type TagStat struct {
+	Count int64 `datastore:"Count,noindex"`
+}
+
+// ArticleRevision is a child of the article keyed by the revision number
+type ArticleRevision struct {
+	Title     string    `datastore:"Title,noindex"`
+	Body      string    `datastore:"Body,noindex"`
+	Tags      []string  `datastore:"Tags,noindex"`
+	EditorID  int64     `datastore:"EditorID,noindex"`
+	CreatedAt time.Time `datastore:"CreatedAt,noindex"`
+}
+
+// Series is a child of the owner, which is in the same entity group as articles in it
+type Series struct {
+	Title        string           `datastore:"Title,noindex"`
+	Description  string           `datastore:"Description,noindex"`
+	Articles     []*datastore.Key `datastore:"Articles"`
+	CreatedAt    time.Time        `datastore:"CreatedAt"`
+	LastModified time.Time        `datastore:"LastModified,noindex"`
+}
+
 type ArticleItem struct {
 	Title     string `datastore:"Title"`
 	CreatedAt int64  `datastore:"CreatedAt"`
 }
+
+// ArticleView is a shard of the view counter of an article in a day
+type ArticleView struct {
+	Article *datastore.Key `datastore:"Article,noindex"`
+	Day     time.Time      `datastore:"Day"`
+	Count   int64          `datastore:"Count,noindex"`
+}
@@ -0,0 +1,162 @@
+package persistence
+
+import (
+	"context"
+
+	dsUtil "lmm/api/pkg/datastore"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+	dsEntity "lmm/api/service/article/port/adapter/persistence/internal/datastore"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+type SeriesDataStore struct {
+	dataStore *datastore.Client
+}
+
+func NewSeriesDataStore(dataStore *datastore.Client) *SeriesDataStore {
+	return &SeriesDataStore{dataStore: dataStore}
+}
+
+func (s *SeriesDataStore) NextID(tx transaction.Transaction, ownerID int64) (*model.SeriesID, error) {
+	key := datastore.IncompleteKey(dsUtil.ArticleSeriesKind, datastore.IDKey(dsUtil.UserKind, ownerID, nil))
+	keys, err := s.dataStore.AllocateIDs(tx, []*datastore.Key{key})
+	if err != nil || len(keys) == 0 {
+		return nil, errors.Wrap(err, "failed to allocate new series key")
+	}
+
+	return model.NewSeriesID(keys[0].Encode()), nil
+}
+
+// Save saves series into datastore
+func (s *SeriesDataStore) Save(tx transaction.Transaction, series *model.Series) error {
+	seriesKey, err := s.seriesKey(series.ID())
+	if err != nil {
+		return err
+	}
+
+	articles := make([]*datastore.Key, len(series.Articles()), len(series.Articles()))
+	for i, id := range series.Articles() {
+		articles[i], err = datastore.DecodeKey(id.String())
+		if err != nil {
+			return errors.Wrapf(domain.ErrNoSuchArticle, "%s: %s", err.Error(), id.String())
+		}
+	}
+
+	if _, err := dsUtil.MustTransaction(tx).Put(seriesKey, &dsEntity.Series{
+		Title:        series.Title(),
+		Description:  series.Description(),
+		Articles:     articles,
+		CreatedAt:    series.CreatedAt(),
+		LastModified: series.LastModified(),
+	}); err != nil {
+		return errors.Wrap(err, "failed to put series into datastore")
+	}
+
+	return nil
+}
+
+// Remove deletes series from datastore
+func (s *SeriesDataStore) Remove(tx transaction.Transaction, id *model.SeriesID) error {
+	seriesKey, err := s.seriesKey(id)
+	if err != nil {
+		return err
+	}
+
+	if err := dsUtil.MustTransaction(tx).Delete(seriesKey); err != nil {
+		return errors.Wrap(err, "failed to delete series")
+	}
+
+	return nil
+}
+
+func (s *SeriesDataStore) FindByID(tx transaction.Transaction, id *model.SeriesID) (*model.Series, error) {
+	seriesKey, err := s.seriesKey(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var data dsEntity.Series
+	if err := dsUtil.MustTransaction(tx).Get(seriesKey, &data); err != nil {
+		return nil, errors.Wrap(domain.ErrNoSuchSeries, err.Error())
+	}
+
+	return s.series(seriesKey, &data)
+}
+
+// FindByArticle finds the series which the article is in.
+// Series are in the same entity group as their articles, so it's queried by the ancestor in the transaction
+func (s *SeriesDataStore) FindByArticle(tx transaction.Transaction, id *model.ArticleID) (*model.Series, error) {
+	articleKey, err := datastore.DecodeKey(id.String())
+	if err != nil {
+		return nil, errors.Wrapf(domain.ErrNoSuchSeries, "%s: %s", err.Error(), id.String())
+	}
+
+	q := datastore.NewQuery(dsUtil.ArticleSeriesKind).Ancestor(articleKey.Parent).Filter("Articles =", articleKey).Limit(1).Transaction(dsUtil.MustTransaction(tx))
+
+	var entities []*dsEntity.Series
+	keys, err := s.dataStore.GetAll(tx, q, &entities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get series by article")
+	}
+
+	if len(keys) == 0 {
+		return nil, domain.ErrNoSuchSeries
+	}
+
+	return s.series(keys[0], entities[0])
+}
+
+// FindAll finds all series, used to export series
+func (s *SeriesDataStore) FindAll(c context.Context) ([]*model.Series, error) {
+	var entities []*dsEntity.Series
+	keys, err := s.dataStore.GetAll(c, datastore.NewQuery(dsUtil.ArticleSeriesKind), &entities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get series")
+	}
+
+	series := make([]*model.Series, len(keys), len(keys))
+	for i, key := range keys {
+		if series[i], err = s.series(key, entities[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return series, nil
+}
+
+func (s *SeriesDataStore) seriesKey(id *model.SeriesID) (*datastore.Key, error) {
+	key, err := datastore.DecodeKey(id.String())
+	if err != nil {
+		return nil, errors.Wrapf(domain.ErrNoSuchSeries, "%s: %s", err.Error(), id.String())
+	}
+	if key.Kind != dsUtil.ArticleSeriesKind || key.Parent == nil {
+		return nil, errors.Wrap(domain.ErrNoSuchSeries, id.String())
+	}
+	return key, nil
+}
+
+func (s *SeriesDataStore) series(key *datastore.Key, data *dsEntity.Series) (*model.Series, error) {
+	articles := make([]*model.ArticleID, len(data.Articles), len(data.Articles))
+	for i, articleKey := range data.Articles {
+		articles[i] = model.NewArticleID(articleKey.Encode())
+	}
+
+	series, err := model.NewSeries(
+		model.NewSeriesID(key.Encode()),
+		model.NewAuthor(key.Parent.ID),
+		data.Title,
+		data.Description,
+		articles,
+		data.CreatedAt,
+		data.LastModified,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	return series, nil
+}
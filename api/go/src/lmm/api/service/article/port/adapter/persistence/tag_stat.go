@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"sort"
+
+	dsUtil "lmm/api/pkg/datastore"
+	"lmm/api/service/article/domain/model"
+	dsEntity "lmm/api/service/article/port/adapter/persistence/internal/datastore"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+// countPublishedTags adds sign to deltas for each tag of published articles
+func countPublishedTags(deltas map[string]int64, tags []*dsEntity.Tag, sign int64) {
+	for _, tag := range tags {
+		if tag.Status == model.ArticleStatusPublished.String() {
+			deltas[tag.Name] += sign
+		}
+	}
+}
+
+// updateTagStats adds deltas to counters of tags in the transaction,
+// the counter is deleted when no published article is tagged
+func updateTagStats(dstx *datastore.Transaction, deltas map[string]int64) error {
+	names := make([]string, 0, len(deltas))
+	for name, delta := range deltas {
+		if delta != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	keys := make([]*datastore.Key, len(names), len(names))
+	for i, name := range names {
+		keys[i] = datastore.NameKey(dsUtil.ArticleTagStatKind, name, nil)
+	}
+
+	stats := make([]*dsEntity.TagStat, len(keys), len(keys))
+	if err := dstx.GetMulti(keys, stats); err != nil {
+		errs, ok := err.(datastore.MultiError)
+		if !ok {
+			return errors.Wrap(err, "failed to get tag stats")
+		}
+		for _, err := range errs {
+			if err != nil && err != datastore.ErrNoSuchEntity {
+				return errors.Wrap(err, "failed to get tag stats")
+			}
+		}
+	}
+
+	putKeys := make([]*datastore.Key, 0, len(keys))
+	putStats := make([]*dsEntity.TagStat, 0, len(keys))
+	deleteKeys := make([]*datastore.Key, 0)
+
+	for i, name := range names {
+		stat := stats[i]
+		if stat == nil {
+			stat = &dsEntity.TagStat{}
+		}
+		stat.Count += deltas[name]
+
+		if stat.Count > 0 {
+			putKeys = append(putKeys, keys[i])
+			putStats = append(putStats, stat)
+		} else {
+			deleteKeys = append(deleteKeys, keys[i])
+		}
+	}
+
+	if _, err := dstx.PutMulti(putKeys, putStats); err != nil {
+		return errors.Wrap(err, "failed to put tag stats")
+	}
+	if err := dstx.DeleteMulti(deleteKeys); err != nil {
+		return errors.Wrap(err, "failed to delete tag stats")
+	}
+
+	return nil
+}
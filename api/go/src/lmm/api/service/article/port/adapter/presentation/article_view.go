@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httpUtil "lmm/api/pkg/http"
+	"lmm/api/service/article/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// ListPopularArticles handles GET /v1/articles/popular?window=7d&count=10
+func (p *GinRouterProvider) ListPopularArticles(c *gin.Context) {
+	count, err := strconv.Atoi(c.DefaultQuery("count", strconv.Itoa(popularArticlesCount)))
+	if err != nil || count < 1 || count > maxPopularArticlesCount {
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, errInvalidCount.Error())
+		return
+	}
+
+	windowParam := c.DefaultQuery("window", popularArticlesWindow)
+	window, err := parseViewWindow(windowParam)
+	if err != nil {
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, errInvalidWindow.Error())
+		return
+	}
+
+	articles, err := p.appService.ViewCounter().PopularArticles(c, window, count)
+	switch errors.Cause(err) {
+	case nil:
+	case domain.ErrInvalidViewWindow:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, errInvalidWindow.Error())
+		return
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+		return
+	}
+
+	items := make([]popularArticleItem, len(articles), len(articles))
+	for i, article := range articles {
+		items[i] = popularArticleItem{
+			articleListItem: articleListItem{
+				ID:     article.Item().ID().String(),
+				Title:  article.Item().Title(),
+				Status: article.Item().Status().String(),
+				PostAt: article.Item().PostAt().Unix(),
+			},
+			Views: article.Views(),
+		}
+	}
+
+	c.JSON(http.StatusOK, &popularArticleListAdapter{Articles: items, Window: windowParam})
+}
+
+// maxViewWindowDays bounds days before they are converted into a duration
+const maxViewWindowDays = 366
+
+// parseViewWindow parses days like 7d as well as durations like 12h
+func parseViewWindow(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, err
+		}
+		if days < 0 || days > maxViewWindowDays {
+			return 0, errInvalidWindow
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// articleViewClientID identifies the reader to deduplicate views,
+// anonymous readers are identified by their address and user agent
+func articleViewClientID(c *gin.Context, readerID int64) string {
+	if readerID != 0 {
+		return "user:" + strconv.FormatInt(readerID, 10)
+	}
+
+	addr := articleViewClientAddr(c)
+
+	h := sha1.New()
+	h.Write([]byte(addr + "\n" + c.Request.UserAgent()))
+	return "anonymous:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// articleViewClientAddr returns the address of the reader.
+// On App Engine the remote address is the front end, which sets X-Appengine-User-Ip to the address of the client
+// and drops the header sent by clients. X-Forwarded-For is never trusted since clients can forge it
+func articleViewClientAddr(c *gin.Context) string {
+	if addr := c.GetHeader("X-Appengine-User-Ip"); addr != "" {
+		return addr
+	}
+
+	addr, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		return c.Request.RemoteAddr
+	}
+	return addr
+}
@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	httpUtil "lmm/api/pkg/http"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	feedTitle         = "lmm"
+	feedSize          = 20
+	feedSummaryLength = 200
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// GetRSSFeed handles GET /v1/feed.rss and GET /v1/articleTags/:tag/feed.rss
+func (p *GinRouterProvider) GetRSSFeed(c *gin.Context) {
+	tag := c.Param("tag")
+
+	articles, ok := p.latestArticlesForFeed(c, "rss", tag)
+	if !ok {
+		return
+	}
+
+	channel := rssChannel{
+		Title:       p.feedTitle(tag),
+		Link:        p.tagPageURL(tag),
+		Description: p.feedTitle(tag),
+		Items:       make([]rssItem, len(articles), len(articles)),
+	}
+	if lastModified := feedLastModified(articles); !lastModified.IsZero() {
+		channel.LastBuildDate = lastModified.UTC().Format(time.RFC1123Z)
+	}
+	for i, article := range articles {
+		channel.Items[i] = rssItem{
+			Title:       article.Content().Text().Title(),
+			Link:        p.articlePageURL(article),
+			GUID:        rssGUID{IsPermaLink: false, Value: article.ID().String()},
+			Description: articleSummary(article),
+			PubDate:     article.CreatedAt().UTC().Format(time.RFC1123Z),
+			Categories:  articleTagNames(article),
+		}
+	}
+
+	p.respondXML(c, "application/rss+xml; charset=utf-8", &rssFeed{Version: "2.0", Channel: channel})
+}
+
+// GetAtomFeed handles GET /v1/feed.atom and GET /v1/articleTags/:tag/feed.atom
+func (p *GinRouterProvider) GetAtomFeed(c *gin.Context) {
+	tag := c.Param("tag")
+
+	articles, ok := p.latestArticlesForFeed(c, "atom", tag)
+	if !ok {
+		return
+	}
+
+	updated := feedLastModified(articles)
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	feed := &atomFeed{
+		Title:   p.feedTitle(tag),
+		ID:      p.tagPageURL(tag),
+		Updated: updated.UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: p.tagPageURL(tag), Rel: "alternate"},
+		Author:  atomAuthor{Name: feedTitle},
+		Entries: make([]atomEntry, len(articles), len(articles)),
+	}
+	for i, article := range articles {
+		categories := make([]atomCategory, 0, len(article.Content().Tags()))
+		for _, name := range articleTagNames(article) {
+			categories = append(categories, atomCategory{Term: name})
+		}
+
+		feed.Entries[i] = atomEntry{
+			Title:      article.Content().Text().Title(),
+			ID:         p.siteURL + "/articles/" + url.PathEscape(article.ID().String()),
+			Link:       atomLink{Href: p.articlePageURL(article), Rel: "alternate"},
+			Published:  article.CreatedAt().UTC().Format(time.RFC3339),
+			Updated:    article.LastModified().UTC().Format(time.RFC3339),
+			Summary:    articleSummary(article),
+			Categories: categories,
+		}
+	}
+
+	p.respondXML(c, "application/atom+xml; charset=utf-8", feed)
+}
+
+// latestArticlesForFeed gets articles in the feed and responds 304 Not Modified if the client has the latest feed,
+// returns false if the response has been written
+func (p *GinRouterProvider) latestArticlesForFeed(c *gin.Context, format, tag string) ([]*model.Article, bool) {
+	articles, err := p.appService.Query().LatestArticles(c, tag, feedSize)
+	if err != nil {
+		httpUtil.LogPanic(c, "unexpected error", err)
+		return nil, false
+	}
+
+	if notModified(c, feedETag(format, tag, articles), feedLastModified(articles)) {
+		c.Status(http.StatusNotModified)
+		return nil, false
+	}
+
+	return articles, true
+}
+
+func (p *GinRouterProvider) respondXML(c *gin.Context, contentType string, v interface{}) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		httpUtil.LogPanic(c, "failed to marshal xml", err)
+		return
+	}
+	c.Data(http.StatusOK, contentType, append([]byte(xml.Header), b...))
+}
+
+func (p *GinRouterProvider) feedTitle(tag string) string {
+	if tag == "" {
+		return feedTitle
+	}
+	return fmt.Sprintf("%s #%s", feedTitle, tag)
+}
+
+func (p *GinRouterProvider) articlePageURL(article *model.Article) string {
+	name := article.LinkName()
+	if name == "" {
+		name = article.ID().String()
+	}
+	return p.siteURL + "/articles/" + url.PathEscape(name)
+}
+
+func (p *GinRouterProvider) tagPageURL(tag string) string {
+	if tag == "" {
+		return p.siteURL + "/articles"
+	}
+	return p.siteURL + "/articles?" + url.Values{"tag": []string{tag}}.Encode()
+}
+
+func articleTagNames(article *model.Article) []string {
+	names := make([]string, len(article.Content().Tags()), len(article.Content().Tags()))
+	for i, tag := range article.Content().Tags() {
+		names[i] = tag.Name()
+	}
+	return names
+}
+
+// articleSummary cuts out the beginning of the article body
+func articleSummary(article *model.Article) string {
+	summary := []rune(strings.Join(strings.Fields(article.Content().Text().Body()), " "))
+	if len(summary) > feedSummaryLength {
+		return string(summary[:feedSummaryLength]) + "…"
+	}
+	return string(summary)
+}
+
+// feedLastModified returns the latest time when articles are modified or published, zero if there is no article
+func feedLastModified(articles []*model.Article) time.Time {
+	var lastModified time.Time
+	for _, article := range articles {
+		if article.LastModified().After(lastModified) {
+			lastModified = article.LastModified()
+		}
+		if article.PublishedAt().After(lastModified) {
+			lastModified = article.PublishedAt()
+		}
+	}
+	return lastModified
+}
+
+// feedETag changes if any article in the feed changes
+func feedETag(format, tag string, articles []*model.Article) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s\n%s\n", format, tag)
+	for _, article := range articles {
+		fmt.Fprintf(h, "%s@%d\n", article.ID().String(), article.Version())
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// notModified sets ETag and Last-Modified headers,
+// and returns true if the resource is not modified since the client fetched it.
+// If-None-Match takes precedence over If-Modified-Since
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := c.GetHeader("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ifModifiedSince)
+		if err == nil && !lastModified.Truncate(time.Second).After(t) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,13 +1,20 @@
 package ui
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"math"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
+	"lmm/api/clock"
+	"lmm/api/pkg/auth"
 	httpUtil "lmm/api/pkg/http"
+	"lmm/api/pkg/http/middleware"
 	"lmm/api/pkg/transaction"
 	"lmm/api/service/article/application"
 	"lmm/api/service/article/application/command"
@@ -23,30 +30,73 @@ var (
 	errTitleRequired = errors.New("title required")
 	errBodyRequired  = errors.New("body requried")
 	errTagsRequired  = errors.New("tags requried")
+	errInvalidCount  = errors.New("invalid count")
+	errInvalidWindow = errors.New("invalid window")
+
+	relatedArticlesCount    = 5
+	maxRelatedArticlesCount = 20
+	popularArticlesCount    = 10
+	maxPopularArticlesCount = 50
+	popularArticlesWindow   = "7d"
 )
 
 type GinRouterProvider struct {
 	appService *application.Service
+	siteURL    string
 }
 
 func NewGinRouterProvider(
 	articleViewer model.ArticleViewer,
 	articleRepository model.ArticleRepository,
+	seriesRepository model.SeriesRepository,
+	articleEventPublisher model.ArticleEventPublisher,
+	articleRenderer model.ArticleRenderer,
+	articleSearcher model.ArticleSearcher,
+	articleRecommender model.ArticleRecommender,
+	authorService model.AuthorService,
 	transactionManager transaction.Manager,
+	clock clock.Clock,
+	articleViewCounter *application.ArticleViewCounter,
+	siteURL string,
 ) *GinRouterProvider {
 	appService := application.NewService(
-		application.NewArticleCommandService(articleRepository, transactionManager),
-		application.NewArticleQueryService(articleViewer, transactionManager),
+		application.NewArticleCommandService(clock, articleRepository, articleEventPublisher, transactionManager),
+		application.NewArticleQueryService(articleViewer, articleRenderer, articleSearcher, articleRecommender, transactionManager),
+		application.NewSeriesCommandService(articleRepository, seriesRepository, transactionManager),
+		application.NewSeriesQueryService(articleViewer, seriesRepository, transactionManager),
+		articleViewCounter,
+		application.NewAuthorQueryService(authorService, articleViewer, transactionManager),
 	)
-	return &GinRouterProvider{appService: appService}
+	return &GinRouterProvider{appService: appService, siteURL: strings.TrimRight(siteURL, "/")}
 }
 
 func (p *GinRouterProvider) Provide(router *gin.Engine) {
-	router.POST("/v1/articles", p.PostNewArticle)
+	router.POST("/v1/articles", middleware.RequirePermission(auth.PermissionPostArticle), p.PostNewArticle)
 	router.PUT("/v1/articles/:articleID", p.PutV1Articles)
+	router.DELETE("/v1/articles/:articleID", p.DeleteV1Articles)
+	router.POST("/v1/articles/:articleID/publish", p.PublishArticle)
+	router.POST("/v1/articles/:articleID/unpublish", p.UnpublishArticle)
+	router.POST("/v1/articles/:articleID/schedule", p.ScheduleArticle)
 	router.GET("/v1/articles", p.ListArticles)
-	router.GET("/v1/articles/:articleID", p.GetArticle)
+	router.GET("/v1/articles/:articleID", p.getArticleOrCollection)
+	router.GET("/v1/articles/:articleID/related", p.ListRelatedArticles)
+	router.GET("/v1/articles/:articleID/revisions", p.ListArticleRevisions)
+	router.GET("/v1/articles/:articleID/revisions/:revision", p.GetArticleRevision)
+	router.GET("/v1/articles/:articleID/revisions/:revision/diff", p.GetArticleRevisionDiff)
+	router.POST("/v1/articles/:articleID/revisions/:revision/restore", p.RestoreArticleRevision)
+	router.GET("/v1/users/:user/articles", p.ListAuthorArticles)
+	router.POST("/v1/series", middleware.RequirePermission(auth.PermissionPostArticle), p.PostNewSeries)
+	router.GET("/v1/series/:seriesID", p.GetSeries)
+	router.PUT("/v1/series/:seriesID", p.PutSeries)
+	router.DELETE("/v1/series/:seriesID", p.DeleteSeries)
 	router.GET("/v1/articleTags", p.GetAllArticleTags)
+	router.PUT("/v1/articleTags/:tag", middleware.RequirePermission(auth.PermissionManageTags), p.RenameArticleTag)
+	router.DELETE("/v1/articleTags/:tag", middleware.RequirePermission(auth.PermissionManageTags), p.DeleteArticleTag)
+	router.POST("/v1/articleTags/:tag/merge", middleware.RequirePermission(auth.PermissionManageTags), p.MergeArticleTag)
+	router.GET("/v1/articleTags/:tag/feed.rss", p.GetRSSFeed)
+	router.GET("/v1/articleTags/:tag/feed.atom", p.GetAtomFeed)
+	router.GET("/v1/feed.rss", p.GetRSSFeed)
+	router.GET("/v1/feed.atom", p.GetAtomFeed)
 }
 
 // PostNewArticle handles POST /1/articles
@@ -67,19 +117,37 @@ func (p *GinRouterProvider) PostNewArticle(c *gin.Context) {
 		return
 	}
 
+	var publishAt time.Time
+	if article.PublishAt != 0 {
+		publishAt = time.Unix(article.PublishAt, 0)
+	}
+
 	articleID, err := p.appService.Command().PostNewArticle(c, command.PostArticle{
-		AuthorID: user.ID,
-		Title:    *article.Title,
-		Body:     *article.Body,
-		Tags:     article.Tags,
+		AuthorID:  user.ID,
+		LinkName:  article.LinkName,
+		Title:     *article.Title,
+		Body:      *article.Body,
+		Tags:      article.Tags,
+		Status:    article.Status,
+		PublishAt: publishAt,
 	})
 	originalErr := errors.Cause(err)
 	switch originalErr {
 	case nil:
 		c.Header("Location", fmt.Sprintf("/v1/articles/%s", articleID.String()))
 		httpUtil.Response(c, http.StatusCreated, "Success")
-	case domain.ErrArticleTitleTooLong, domain.ErrEmptyArticleTitle, domain.ErrInvalidArticleTitle:
+	case
+		domain.ErrArticleTitleTooLong,
+		domain.ErrEmptyArticleTitle,
+		domain.ErrInvalidArticleTitle,
+		domain.ErrInvalidArticleStatus,
+		domain.ErrInvalidPublishTime,
+		domain.ErrPublishTimeRequired,
+		domain.ErrArticleAlreadyPublished,
+		domain.ErrInvalidAliasArticleID:
 		httpUtil.ErrorResponse(c, http.StatusBadRequest, originalErr.Error())
+	case domain.ErrArticleLinkNameAlreadyUsed:
+		httpUtil.ErrorResponse(c, http.StatusConflict, originalErr.Error())
 	case domain.ErrNoSuchUser:
 		httpUtil.Unauthorized(c)
 	default:
@@ -87,7 +155,7 @@ func (p *GinRouterProvider) PostNewArticle(c *gin.Context) {
 	}
 }
 
-// PutV1Articles handles PUT /v1/article/:articleID
+// PutV1Articles handles PUT /v1/article/:articleID, the edit is rejected if If-Match doesn't match the current ETag
 func (p *GinRouterProvider) PutV1Articles(c *gin.Context) {
 	user, ok := httpUtil.AuthFromGinContext(c)
 	if !ok {
@@ -106,12 +174,21 @@ func (p *GinRouterProvider) PutV1Articles(c *gin.Context) {
 		return
 	}
 
+	versions, ok := versionsFromIfMatch(c.GetHeader("If-Match"))
+	if !ok {
+		httpUtil.ErrorResponse(c, http.StatusPreconditionFailed, domain.ErrArticleVersionConflict.Error())
+		return
+	}
+
 	err := p.appService.Command().EditArticle(c, command.EditArticle{
 		UserID:    user.ID,
 		ArticleID: c.Param("articleID"),
+		LinkName:  article.LinkName,
 		Title:     *article.Title,
 		Body:      *article.Body,
 		Tags:      article.Tags,
+		Versions:  versions,
+		EditAny:   user.HasPermission(auth.PermissionEditAnyArticle),
 	})
 
 	original := errors.Cause(err)
@@ -127,6 +204,12 @@ func (p *GinRouterProvider) PutV1Articles(c *gin.Context) {
 
 		httpUtil.ErrorResponse(c, http.StatusBadRequest, original.Error())
 
+	case domain.ErrArticleLinkNameAlreadyUsed:
+		httpUtil.ErrorResponse(c, http.StatusConflict, original.Error())
+
+	case domain.ErrArticleVersionConflict:
+		httpUtil.ErrorResponse(c, http.StatusPreconditionFailed, original.Error())
+
 	case domain.ErrNoSuchArticle, domain.ErrInvalidArticleID:
 		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticle.Error())
 
@@ -141,6 +224,179 @@ func (p *GinRouterProvider) PutV1Articles(c *gin.Context) {
 	}
 }
 
+// articleETag represents the version of the article as an entity tag, followed by a digest of the representation
+// given by format, and the series navigation and the author profile embedded in the article view
+func articleETag(format string, version uint, navigation *model.SeriesNavigation, author *model.AuthorProfile) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s\n", format)
+	if author != nil {
+		fmt.Fprintf(h, "%d\n%s\n%s\n%s\n%s\n", author.ID(), author.Name(), author.DisplayName(), author.Bio(), author.AvatarAssetID())
+	}
+	if navigation != nil {
+		fmt.Fprintf(h, "%s\n%s\n", navigation.Series().ID().String(), navigation.Series().Title())
+		for _, article := range []*model.Article{navigation.Previous(), navigation.Next()} {
+			if article == nil {
+				fmt.Fprintln(h, "-")
+				continue
+			}
+			fmt.Fprintf(h, "%s@%d\n", article.ID().String(), article.Version())
+		}
+	}
+	return strconv.Quote(strconv.FormatUint(uint64(version), 10) + "-" + hex.EncodeToString(h.Sum(nil)))
+}
+
+// versionsFromIfMatch parses the If-Match header, a list of entity tags made from articleETag.
+// Tags are compared by the strong comparison on the article version, so weak tags never match.
+// Returns nil versions if any version is acceptable, and false if the header is malformed or no tag can match
+func versionsFromIfMatch(ifMatch string) ([]uint, bool) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, true
+	}
+
+	versions := make([]uint, 0)
+	for _, etag := range strings.Split(ifMatch, ",") {
+		etag = strings.TrimSpace(etag)
+		if strings.HasPrefix(etag, "W/") {
+			continue
+		}
+
+		s, err := strconv.Unquote(etag)
+		if err != nil {
+			return nil, false
+		}
+
+		if i := strings.IndexByte(s, '-'); i >= 0 {
+			s = s[:i]
+		}
+
+		version, err := strconv.ParseUint(s, 10, 0)
+		if err != nil {
+			return nil, false
+		}
+		versions = append(versions, uint(version))
+	}
+
+	return versions, len(versions) > 0
+}
+
+// DeleteV1Articles handles DELETE /v1/articles/:articleID
+func (p *GinRouterProvider) DeleteV1Articles(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	err := p.appService.Command().DeleteArticle(c, command.DeleteArticle{
+		UserID:    user.ID,
+		ArticleID: c.Param("articleID"),
+		DeleteAny: user.HasPermission(auth.PermissionDeleteAnyArticle),
+	})
+
+	original := errors.Cause(err)
+	switch original {
+	case nil:
+		c.Status(http.StatusNoContent)
+
+	case domain.ErrNoSuchArticle, domain.ErrInvalidArticleID:
+		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticle.Error())
+
+	case domain.ErrNotArticleAuthor:
+		httpUtil.ErrorResponse(c, http.StatusForbidden, original.Error())
+
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+// PublishArticle handles POST /v1/articles/:articleID/publish
+func (p *GinRouterProvider) PublishArticle(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	reqBody := publishArticleAdapter{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			httpUtil.BadRequest(c)
+			return
+		}
+	}
+
+	err := p.appService.Command().PublishArticle(c, command.PublishArticle{
+		UserID:    user.ID,
+		ArticleID: c.Param("articleID"),
+		Unlisted:  reqBody.Unlisted,
+		EditAny:   user.HasPermission(auth.PermissionEditAnyArticle),
+	})
+	p.respondArticleStatusChanged(c, err)
+}
+
+// UnpublishArticle handles POST /v1/articles/:articleID/unpublish
+func (p *GinRouterProvider) UnpublishArticle(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	err := p.appService.Command().UnpublishArticle(c, command.UnpublishArticle{
+		UserID:    user.ID,
+		ArticleID: c.Param("articleID"),
+		EditAny:   user.HasPermission(auth.PermissionEditAnyArticle),
+	})
+	p.respondArticleStatusChanged(c, err)
+}
+
+// ScheduleArticle handles POST /v1/articles/:articleID/schedule
+func (p *GinRouterProvider) ScheduleArticle(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	reqBody := scheduleArticleAdapter{}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	err := p.appService.Command().ScheduleArticle(c, command.ScheduleArticle{
+		UserID:    user.ID,
+		ArticleID: c.Param("articleID"),
+		PublishAt: time.Unix(reqBody.PublishAt, 0),
+		EditAny:   user.HasPermission(auth.PermissionEditAnyArticle),
+	})
+	p.respondArticleStatusChanged(c, err)
+}
+
+func (p *GinRouterProvider) respondArticleStatusChanged(c *gin.Context, err error) {
+	original := errors.Cause(err)
+	switch original {
+	case nil:
+		httpUtil.Response(c, http.StatusOK, "Success")
+
+	case domain.ErrInvalidPublishTime:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, original.Error())
+
+	case domain.ErrArticleAlreadyPublished:
+		httpUtil.ErrorResponse(c, http.StatusConflict, original.Error())
+
+	case domain.ErrNoSuchArticle, domain.ErrInvalidArticleID:
+		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticle.Error())
+
+	case domain.ErrNotArticleAuthor:
+		httpUtil.ErrorResponse(c, http.StatusForbidden, original.Error())
+
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
 func (p *GinRouterProvider) validatePostArticleAdaptor(adaptor *postArticleAdapter) error {
 	if adaptor.Title == nil {
 		return errTitleRequired
@@ -164,6 +420,21 @@ func (p *GinRouterProvider) ListArticles(c *gin.Context) {
 		return
 	}
 
+	if q.Drafts {
+		user, ok := httpUtil.AuthFromGinContext(c)
+		if !ok {
+			httpUtil.Unauthorized(c)
+			return
+		}
+		q.AuthorID = user.ID
+	}
+
+	// clients opt in to paging by cursor by giving cursor, which is empty for the first page
+	if _, ok := c.GetQuery("cursor"); ok {
+		p.listArticlesByCursor(c, q)
+		return
+	}
+
 	v, err := p.appService.Query().ListArticlesByPage(c, q)
 	switch errors.Cause(err) {
 	case nil:
@@ -177,17 +448,34 @@ func (p *GinRouterProvider) ListArticles(c *gin.Context) {
 	}
 }
 
+func (p *GinRouterProvider) listArticlesByCursor(c *gin.Context, q query.ListArticleQuery) {
+	v, err := p.appService.Query().ListArticlesByCursor(c, q)
+	switch errors.Cause(err) {
+	case nil:
+		c.JSON(http.StatusOK, p.articleCursorListViewToJSON(c, v, q.Drafts))
+	case domain.ErrInvalidArticleCursor:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidArticleCursor.Error())
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
 func (p *GinRouterProvider) articleListViewToJSON(view *model.ArticleListView) *articleListAdapter {
-	items := make([]articleListItem, len(view.Items()), len(view.Items()))
-	for i, item := range view.Items() {
+	return &articleListAdapter{
+		Articles:    p.articleListItemsToJSON(view.Items()),
+		HasNextPage: view.HasNextPage(),
+	}
+}
+
+func (p *GinRouterProvider) articleListItemsToJSON(views []*model.ArticleListViewItem) []articleListItem {
+	items := make([]articleListItem, len(views), len(views))
+	for i, item := range views {
 		items[i].ID = item.ID().String()
 		items[i].Title = item.Title()
+		items[i].Status = item.Status().String()
 		items[i].PostAt = item.PostAt().Unix()
 	}
-	return &articleListAdapter{
-		Articles:    items,
-		HasNextPage: view.HasNextPage(),
-	}
+	return items
 }
 
 func (p *GinRouterProvider) articleListViewToJSONV2(c *gin.Context, view *model.ArticleListView) *articleListAdapterV2 {
@@ -200,47 +488,256 @@ func (p *GinRouterProvider) articleListViewToJSONV2(c *gin.Context, view *model.
 		Total:    view.Total(),
 	}
 
-	last := int(math.Ceil(
-		float64(adapterV2.Total) / float64(adapterV2.PerPage),
+	params := url.Values{}
+	if adapterV2.Tag != "" {
+		params.Set("tag", adapterV2.Tag)
+	}
+
+	adapterV2.PrevPage, adapterV2.NextPage, adapterV2.FirstPage, adapterV2.LastPage = pageLinks(
+		c.Request.URL.Path, adapterV2.Page, adapterV2.PerPage, adapterV2.Total, view.HasNextPage(), params,
+	)
+
+	return adapterV2
+}
+
+// ListAuthorArticles handles GET /v1/users/:user/articles, which lists published articles of the author
+func (p *GinRouterProvider) ListAuthorArticles(c *gin.Context) {
+	q := query.ListArticleQuery{}
+	if err := c.BindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": q.ValidateErrors(err),
+		})
+		return
+	}
+
+	_, byCursor := c.GetQuery("cursor")
+
+	author, v, err := p.appService.AuthorQuery().ListAuthorArticles(c, c.Param("user"), q, byCursor)
+	switch errors.Cause(err) {
+	case nil:
+		if byCursor {
+			c.JSON(http.StatusOK, authorArticleCursorListAdapter{
+				Author:                   p.articleAuthorViewToJSON(author),
+				articleCursorListAdapter: p.articleCursorListViewToJSON(c, v, false),
+			})
+		} else {
+			c.JSON(http.StatusOK, authorArticleListAdapter{
+				Author:               p.articleAuthorViewToJSON(author),
+				articleListAdapterV2: p.articleListViewToJSONV2(c, v),
+			})
+		}
+	case domain.ErrNoSuchUser:
+		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchUser.Error())
+	case domain.ErrInvalidArticleCursor:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidArticleCursor.Error())
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+func (p *GinRouterProvider) articleAuthorViewToJSON(author *model.AuthorProfile) *articleAuthorView {
+	return &articleAuthorView{
+		Name:          author.Name(),
+		DisplayName:   author.DisplayName(),
+		Bio:           author.Bio(),
+		AvatarAssetID: author.AvatarAssetID(),
+	}
+}
+
+func (p *GinRouterProvider) articleCursorListViewToJSON(c *gin.Context, view *model.ArticleListView, drafts bool) *articleCursorListAdapter {
+	adapter := &articleCursorListAdapter{
+		Articles:   p.articleListViewToJSON(view).Articles,
+		PerPage:    view.PerPage(),
+		Tag:        view.TagFilter(),
+		NextCursor: view.NextCursor(),
+	}
+
+	if adapter.NextCursor != "" {
+		params := url.Values{}
+		params.Set("cursor", adapter.NextCursor)
+		params.Set("perPage", strconv.Itoa(adapter.PerPage))
+		if adapter.Tag != "" {
+			params.Set("tag", adapter.Tag)
+		}
+		if drafts {
+			params.Set("drafts", "true")
+		}
+		adapter.NextPage = fmt.Sprintf("%s?%s", c.Request.URL.Path, params.Encode())
+	}
+
+	return adapter
+}
+
+// pageLinks builds links to the previous, next, first and last pages, empty if there is no such page
+func pageLinks(path string, page, perPage, total int, hasNextPage bool, params url.Values) (prev, next, first, last string) {
+	lastPage := int(math.Ceil(
+		float64(total) / float64(perPage),
 	))
 
-	if adapterV2.Page > 1 && adapterV2.Page <= last+1 {
-		adapterV2.PrevPage = buildURI(c.Request.URL.Path, adapterV2.Page-1, adapterV2.PerPage, adapterV2.Tag)
+	if page > 1 && page <= lastPage+1 {
+		prev = buildURI(path, page-1, perPage, params)
 	}
 
-	if view.HasNextPage() {
-		adapterV2.NextPage = buildURI(c.Request.URL.Path, adapterV2.Page+1, adapterV2.PerPage, adapterV2.Tag)
+	if hasNextPage {
+		next = buildURI(path, page+1, perPage, params)
 	}
 
-	if adapterV2.Total > 0 {
-		adapterV2.FirstPage = buildURI(c.Request.URL.Path, 1, adapterV2.PerPage, adapterV2.Tag)
-		adapterV2.LastPage = buildURI(c.Request.URL.Path, last, adapterV2.PerPage, adapterV2.Tag)
+	if total > 0 {
+		first = buildURI(path, 1, perPage, params)
+		last = buildURI(path, lastPage, perPage, params)
 	}
 
-	return adapterV2
+	return
 }
 
-func buildURI(path string, page, perPage int, tag string) string {
+func buildURI(path string, page, perPage int, params url.Values) string {
 	vs := url.Values{}
+	for key, values := range params {
+		vs[key] = values
+	}
 
 	vs.Set("page", strconv.Itoa(page))
 	vs.Set("perPage", strconv.Itoa(perPage))
 
-	if tag != "" {
-		vs.Set("tag", tag)
+	return fmt.Sprintf("%s?%s", path, vs.Encode())
+}
+
+// ListRelatedArticles handles GET /v1/articles/:articleID/related
+func (p *GinRouterProvider) ListRelatedArticles(c *gin.Context) {
+	count, err := strconv.Atoi(c.DefaultQuery("count", strconv.Itoa(relatedArticlesCount)))
+	if err != nil || count < 1 || count > maxRelatedArticlesCount {
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, errInvalidCount.Error())
+		return
 	}
 
-	return fmt.Sprintf("%s?%s", path, vs.Encode())
+	var readerID int64
+	if user, ok := httpUtil.AuthFromGinContext(c); ok {
+		readerID = user.ID
+	}
+
+	items, err := p.appService.Query().RelatedArticles(c, c.Param("articleID"), readerID, count)
+	switch errors.Cause(err) {
+	case nil:
+		c.JSON(http.StatusOK, &articleRelatedListAdapter{Articles: p.articleListItemsToJSON(items)})
+	case domain.ErrInvalidArticleID, domain.ErrNoSuchArticle:
+		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticle.Error())
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+// getArticleOrCollection dispatches GET /v1/articles/:articleID.
+// gin doesn't allow static routes like /v1/articles/search next to /v1/articles/:articleID,
+// so their names are reserved as article link names and routed here
+func (p *GinRouterProvider) getArticleOrCollection(c *gin.Context) {
+	switch c.Param("articleID") {
+	case "search":
+		p.SearchArticles(c)
+	case "popular":
+		p.ListPopularArticles(c)
+	default:
+		p.GetArticle(c)
+	}
 }
 
-// GetArticle handles GET /v1/articles/:articleID
+// SearchArticles handles GET /v1/articles/search?q=
+func (p *GinRouterProvider) SearchArticles(c *gin.Context) {
+	q := query.SearchArticleQuery{}
+	if err := c.BindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": q.ValidateErrors(err),
+		})
+		return
+	}
+
+	view, err := p.appService.Query().SearchArticles(c, q)
+	if err != nil {
+		httpUtil.LogPanic(c, "unexpected error", err)
+		return
+	}
+
+	res := &articleSearchAdapter{
+		Articles: make([]articleSearchItem, len(view.Items()), len(view.Items())),
+		Query:    view.Query(),
+		Page:     view.Page(),
+		PerPage:  view.PerPage(),
+		Total:    view.Total(),
+	}
+	for i, item := range view.Items() {
+		res.Articles[i] = articleSearchItem{
+			ID:      item.ID().String(),
+			Title:   item.Title(),
+			Snippet: item.Snippet(),
+			PostAt:  item.PostAt().Unix(),
+		}
+	}
+
+	res.PrevPage, res.NextPage, res.FirstPage, res.LastPage = pageLinks(
+		c.Request.URL.Path, res.Page, res.PerPage, res.Total, view.HasNextPage(), url.Values{"q": []string{res.Query}},
+	)
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetArticle handles GET /v1/articles/:articleID, articleID is either the id or the link name.
+// The body is also rendered into HTML if format=html is given
 func (p *GinRouterProvider) GetArticle(c *gin.Context) {
+	format := c.Query("format")
+	if format != "" && format != "html" {
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	var readerID int64
+	if user, ok := httpUtil.AuthFromGinContext(c); ok {
+		readerID = user.ID
+	}
+
+	linkNameOrID := c.Param("articleID")
+
 	view, err := p.appService.Query().ArticleByID(c,
-		c.Param("articleID"),
+		linkNameOrID,
+		readerID,
 	)
 	switch errors.Cause(err) {
 	case nil:
-		c.JSON(http.StatusOK, p.articleViewToJSON(view))
+		// redirect old link names to the current one
+		if linkNameOrID != view.ID().String() && view.LinkName() != "" && linkNameOrID != view.LinkName() {
+			location := "/v1/articles/" + url.PathEscape(view.LinkName())
+			if c.Request.URL.RawQuery != "" {
+				location += "?" + c.Request.URL.RawQuery
+			}
+			c.Redirect(http.StatusMovedPermanently, location)
+			return
+		}
+		res := p.articleViewToJSON(view)
+		navigation, err := p.appService.SeriesQuery().ArticleSeriesNavigation(c, view, readerID)
+		if err != nil {
+			httpUtil.LogPanic(c, "unexpected error", err)
+			return
+		}
+		if navigation != nil {
+			res.Series = p.articleSeriesViewToJSON(navigation)
+		}
+		author, err := p.appService.AuthorQuery().ArticleAuthor(c, view)
+		if err != nil {
+			httpUtil.LogPanic(c, "unexpected error", err)
+			return
+		}
+		if author != nil {
+			res.Author = p.articleAuthorViewToJSON(author)
+		}
+		if format == "html" {
+			rendered, err := p.appService.Query().RenderArticle(view)
+			if err != nil {
+				httpUtil.LogPanic(c, "unexpected error", err)
+				return
+			}
+			p.setRenderedText(res, rendered)
+		}
+		p.appService.ViewCounter().RecordView(view, readerID, articleViewClientID(c, readerID))
+		c.Header("ETag", articleETag(format, view.Version(), navigation, author))
+		c.JSON(http.StatusOK, res)
 	case domain.ErrInvalidArticleID, domain.ErrNoSuchArticle:
 		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticle.Error())
 	default:
@@ -253,16 +750,194 @@ func (p *GinRouterProvider) articleViewToJSON(model *model.Article) *articleView
 	for i, tag := range model.Content().Tags() {
 		tags[i].Name = tag.Name()
 	}
+	var publishedAt int64
+	if !model.PublishedAt().IsZero() {
+		publishedAt = model.PublishedAt().Unix()
+	}
 	return &articleViewResponse{
 		ID:           model.ID().String(),
+		LinkName:     model.LinkName(),
 		Title:        model.Content().Text().Title(),
 		Body:         model.Content().Text().Body(),
+		Status:       model.Status().String(),
+		Version:      model.Version(),
 		PostAt:       model.CreatedAt().Unix(),
+		PublishedAt:  publishedAt,
 		LastEditedAt: model.LastModified().Unix(),
 		Tags:         tags,
 	}
 }
 
+func (p *GinRouterProvider) setRenderedText(res *articleViewResponse, rendered *model.RenderedText) {
+	toc := make([]*articleTOCItem, len(rendered.TableOfContents()), len(rendered.TableOfContents()))
+	for i, heading := range rendered.TableOfContents() {
+		toc[i] = &articleTOCItem{
+			Level:  heading.Level(),
+			Anchor: heading.Anchor(),
+			Title:  heading.Title(),
+		}
+	}
+
+	res.BodyHTML = rendered.HTML()
+	res.TableOfContents = toc
+	res.ReadingTime = int(rendered.ReadingTime().Minutes())
+}
+
+// ListArticleRevisions handles GET /v1/articles/:articleID/revisions
+func (p *GinRouterProvider) ListArticleRevisions(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	revisions, err := p.appService.Query().ArticleRevisions(c, c.Param("articleID"), user.ID,
+		user.HasPermission(auth.PermissionEditAnyArticle),
+	)
+	if err != nil {
+		p.respondArticleRevisionError(c, err)
+		return
+	}
+
+	views := make(articleRevisionListView, len(revisions), len(revisions))
+	for i, revision := range revisions {
+		views[i] = p.articleRevisionToJSON(revision, false)
+	}
+	c.JSON(http.StatusOK, views)
+}
+
+// GetArticleRevision handles GET /v1/articles/:articleID/revisions/:revision
+func (p *GinRouterProvider) GetArticleRevision(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticleRevision.Error())
+		return
+	}
+
+	revision, err := p.appService.Query().ArticleRevision(c, c.Param("articleID"), number, user.ID,
+		user.HasPermission(auth.PermissionEditAnyArticle),
+	)
+	if err != nil {
+		p.respondArticleRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, p.articleRevisionToJSON(revision, true))
+}
+
+// GetArticleRevisionDiff handles GET /v1/articles/:articleID/revisions/:revision/diff?from=
+// which shows the line diff from the revision given by from, the previous one by default
+func (p *GinRouterProvider) GetArticleRevisionDiff(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	to, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticleRevision.Error())
+		return
+	}
+
+	from := to - 1
+	if s := c.Query("from"); s != "" {
+		if from, err = strconv.Atoi(s); err != nil {
+			httpUtil.BadRequest(c)
+			return
+		}
+	}
+
+	lines, err := p.appService.Query().ArticleRevisionDiff(c, c.Param("articleID"), from, to, user.ID,
+		user.HasPermission(auth.PermissionEditAnyArticle),
+	)
+	if err != nil {
+		p.respondArticleRevisionError(c, err)
+		return
+	}
+
+	diff := &articleRevisionDiffResponse{
+		From:  from,
+		To:    to,
+		Lines: make([]*articleRevisionDiffLine, len(lines), len(lines)),
+	}
+	for i, line := range lines {
+		diff.Lines[i] = &articleRevisionDiffLine{
+			Operation: string(line.Operation()),
+			Text:      line.Text(),
+		}
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// RestoreArticleRevision handles POST /v1/articles/:articleID/revisions/:revision/restore
+func (p *GinRouterProvider) RestoreArticleRevision(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticleRevision.Error())
+		return
+	}
+
+	err = p.appService.Command().RestoreArticleRevision(c, command.RestoreArticleRevision{
+		UserID:         user.ID,
+		ArticleID:      c.Param("articleID"),
+		RevisionNumber: number,
+		EditAny:        user.HasPermission(auth.PermissionEditAnyArticle),
+	})
+	if err != nil {
+		p.respondArticleRevisionError(c, err)
+		return
+	}
+
+	httpUtil.Response(c, http.StatusOK, "Success")
+}
+
+func (p *GinRouterProvider) respondArticleRevisionError(c *gin.Context, err error) {
+	original := errors.Cause(err)
+	switch original {
+	case domain.ErrNoSuchArticle, domain.ErrInvalidArticleID:
+		httpUtil.ErrorResponse(c, http.StatusNotFound, domain.ErrNoSuchArticle.Error())
+	case domain.ErrNoSuchArticleRevision:
+		httpUtil.ErrorResponse(c, http.StatusNotFound, original.Error())
+	case domain.ErrNotArticleAuthor:
+		httpUtil.ErrorResponse(c, http.StatusForbidden, original.Error())
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+func (p *GinRouterProvider) articleRevisionToJSON(revision *model.ArticleRevision, withBody bool) *articleRevisionResponse {
+	tags := make([]articleViewTag, len(revision.Content().Tags()), len(revision.Content().Tags()))
+	for i, tag := range revision.Content().Tags() {
+		tags[i].Name = tag.Name()
+	}
+
+	view := &articleRevisionResponse{
+		Number:    revision.Number(),
+		Title:     revision.Content().Text().Title(),
+		Tags:      tags,
+		EditorID:  revision.Editor().ID(),
+		CreatedAt: revision.CreatedAt().Unix(),
+	}
+	if withBody {
+		view.Body = revision.Content().Text().Body()
+	}
+
+	return view
+}
+
 // GetAllArticleTags handles GET /v1/articleTags
 func (p *GinRouterProvider) GetAllArticleTags(c *gin.Context) {
 	tags, err := p.appService.Query().AllArticleTags(c)
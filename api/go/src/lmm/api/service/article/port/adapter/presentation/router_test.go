@@ -7,16 +7,25 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"lmm/api/clock"
 	jsonUtil "lmm/api/pkg/json"
+	"lmm/api/pkg/pubsub/pubsubtest"
 	testUtil "lmm/api/pkg/testing"
+	"lmm/api/service/article/application"
 	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+	"lmm/api/service/article/port/adapter/markdown"
+	"lmm/api/service/article/port/adapter/messaging"
 	"lmm/api/service/article/port/adapter/persistence"
+	"lmm/api/service/article/port/adapter/search"
 	"lmm/api/util/stringutil"
 	"lmm/api/util/uuidutil"
 
@@ -28,10 +37,50 @@ import (
 )
 
 var (
-	router    *gin.Engine
-	dataStore *datastore.Client
+	router      *gin.Engine
+	dataStore   *datastore.Client
+	viewCounter *application.ArticleViewCounter
+	authors     = &stubAuthorService{profiles: make(map[int64]*model.AuthorProfile)}
 )
 
+// stubAuthorService resolves only authors registered by tests since test users are not owned by the user service
+type stubAuthorService struct {
+	sync.RWMutex
+	profiles map[int64]*model.AuthorProfile
+}
+
+func (s *stubAuthorService) register(user *testUtil.User, displayName string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.profiles[user.ID()] = model.NewAuthorProfile(user.ID(), user.Name, displayName, "bio of "+user.Name, "")
+}
+
+func (s *stubAuthorService) AuthorProfiles(c context.Context, ids []int64) (map[int64]*model.AuthorProfile, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	profiles := make(map[int64]*model.AuthorProfile)
+	for _, id := range ids {
+		if profile, ok := s.profiles[id]; ok {
+			profiles[id] = profile
+		}
+	}
+	return profiles, nil
+}
+
+func (s *stubAuthorService) AuthorByName(c context.Context, name string) (*model.AuthorProfile, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, profile := range s.profiles {
+		if profile.Name() == name {
+			return profile, nil
+		}
+	}
+	return nil, domain.ErrNoSuchUser
+}
+
 func TestMain(m *testing.M) {
 	c := context.Background()
 
@@ -44,12 +93,31 @@ func TestMain(m *testing.M) {
 	router = gin.New()
 	router.Use(testUtil.BearerAuth(dataStore))
 
+	pubsubClient := pubsubtest.NewClient()
+
 	repo := persistence.NewArticleDataStore(dataStore)
-	NewGinRouterProvider(repo, repo, repo).Provide(router)
+	searchIndex := search.NewIndex()
+	indexedRepo := search.NewIndexedArticleRepository(repo, repo, searchIndex)
+	viewCounter = application.NewArticleViewCounter(clock.DefaultClock, persistence.NewArticleViewDataStore(dataStore), repo, repo, time.Hour)
+	NewGinRouterProvider(
+		repo,
+		indexedRepo,
+		persistence.NewSeriesDataStore(dataStore),
+		messaging.NewArticleEventPublisher(pubsubClient),
+		markdown.NewRenderer(),
+		searchIndex,
+		searchIndex,
+		authors,
+		indexedRepo,
+		clock.DefaultClock,
+		viewCounter,
+		"https://lmm.local",
+	).Provide(router)
 
 	code := m.Run()
 
 	dataStore.Close()
+	pubsubClient.Close()
 
 	os.Exit(code)
 }
@@ -409,6 +477,14 @@ func TestGetV1Article(t *testing.T) {
 		})
 	})
 
+	t.Run("ETagChangesWithAuthor", func(t *testing.T) {
+		authors.register(user, "before")
+		etag := getV1Article(articleID).Header().Get("ETag")
+
+		authors.register(user, "after")
+		assert.NotEqual(t, etag, getV1Article(articleID).Header().Get("ETag"))
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		res := getV1Article("no-such-article")
 		assert.Equal(t, http.StatusNotFound, res.Code)
@@ -416,47 +492,1045 @@ func TestGetV1Article(t *testing.T) {
 	})
 }
 
-func postV1Articles(header http.Header, body postArticleAdapter) *httptest.ResponseRecorder {
-	b, err := json.Marshal(body)
-	if err != nil {
-		panic(errors.Wrap(err, "failed to decode to json"))
+func TestDeleteV1Articles(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	res := postV1Articles(
+		header,
+		postArticleAdapter{
+			Title: stringutil.Pointer("title"),
+			Body:  stringutil.Pointer("body"),
+			Tags:  []string{"tag"},
+		},
+	)
+
+	if res.Code != http.StatusCreated {
+		t.Fatal("failed to create test article data")
 	}
 
-	req := httptest.NewRequest(http.MethodPost, "/v1/articles", bytes.NewReader(b))
-	for key, values := range header {
-		for _, value := range values {
-			req.Header.Add(key, value)
+	groups := regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))
+	articleID := groups[1]
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		res := deleteV1Articles(articleID, nil)
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("NotAuthor", func(t *testing.T) {
+		res := deleteV1Articles(articleID, http.Header{"Authorization": []string{"Bearer " + testUtil.NewUser(c, dataStore).AccessToken}})
+		assert.Equal(t, http.StatusForbidden, res.Code)
+		assert.JSONEq(t, jsonUtil.MustJSONify(jsonUtil.JSON{"error": domain.ErrNotArticleAuthor.Error()}), res.Body.String())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		res := deleteV1Articles("notfound", header)
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, jsonUtil.MustJSONify(jsonUtil.JSON{"error": domain.ErrNoSuchArticle.Error()}), res.Body.String())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		res := deleteV1Articles(articleID, header)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+
+		assert.Equal(t, http.StatusNotFound, getV1Article(articleID).Code)
+	})
+}
+
+func TestArticlePermissions(t *testing.T) {
+	c := context.Background()
+
+	newUserWithRole := func(role string) http.Header {
+		user := testUtil.NewUser(c, dataStore)
+		user.Role = role
+		if _, err := dataStore.Put(c, user.Key, user); err != nil {
+			t.Fatal(err)
 		}
+		return http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
 	}
-	res := httptest.NewRecorder()
 
-	router.ServeHTTP(res, req)
+	article := postArticleAdapter{
+		Title: stringutil.Pointer("title"),
+		Body:  stringutil.Pointer("body"),
+		Tags:  []string{"tag"},
+	}
 
-	return res
+	t.Run("GuestCannotPost", func(t *testing.T) {
+		res := postV1Articles(newUserWithRole("guest"), article)
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("AdminEditsAndDeletesAny", func(t *testing.T) {
+		author := http.Header{"Authorization": []string{"Bearer " + testUtil.NewUser(c, dataStore).AccessToken}}
+		admin := newUserWithRole("admin")
+
+		res := postV1Articles(author, article)
+		if res.Code != http.StatusCreated {
+			t.Fatal("failed to create test article data")
+		}
+		articleID := regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))[1]
+
+		res = putV1Articles(articleID, admin, postArticleAdapter{
+			Title: stringutil.Pointer("edited"),
+			Body:  stringutil.Pointer("body"),
+			Tags:  []string{"tag"},
+		})
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		res = deleteV1Articles(articleID, admin)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
 }
 
-func putV1Articles(articleID string, header http.Header, body postArticleAdapter) *httptest.ResponseRecorder {
-	b, err := json.Marshal(body)
-	if err != nil {
-		panic(errors.Wrap(err, "failed to decode to json"))
+func TestPublishArticle(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	res := postV1Articles(
+		header,
+		postArticleAdapter{
+			Title:  stringutil.Pointer("title"),
+			Body:   stringutil.Pointer("body"),
+			Tags:   []string{"tag"},
+			Status: "draft",
+		},
+	)
+
+	if res.Code != http.StatusCreated {
+		t.Fatal("failed to create test article data")
 	}
 
-	req := httptest.NewRequest(http.MethodPut, "/v1/articles/"+articleID, bytes.NewReader(b))
-	for key, values := range header {
-		for _, value := range values {
-			req.Header.Add(key, value)
+	groups := regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))
+	articleID := groups[1]
+
+	t.Run("Draft", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, getV1Article(articleID).Code)
+
+		res := getV1ArticleWithHeader(articleID, header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var articleJSON articleViewResponse
+		if err := json.NewDecoder(res.Body).Decode(&articleJSON); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		assert.Equal(t, "draft", articleJSON.Status)
+		assert.Zero(t, articleJSON.PublishedAt)
+	})
+
+	t.Run("NotAuthor", func(t *testing.T) {
+		res := postV1ArticleStatus(articleID, "publish", http.Header{"Authorization": []string{"Bearer " + testUtil.NewUser(c, dataStore).AccessToken}})
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		res := postV1ArticleStatus(articleID, "publish", header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		res = getV1Article(articleID)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var articleJSON articleViewResponse
+		if err := json.NewDecoder(res.Body).Decode(&articleJSON); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		assert.Equal(t, "published", articleJSON.Status)
+		assert.InDelta(t, articleJSON.PublishedAt, time.Now().Unix(), 1.)
+	})
+
+	t.Run("Unpublish", func(t *testing.T) {
+		res := postV1ArticleStatus(articleID, "unpublish", header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		assert.Equal(t, http.StatusNotFound, getV1Article(articleID).Code)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		res := postV1Articles(
+			header,
+			postArticleAdapter{
+				Title:  stringutil.Pointer("title"),
+				Body:   stringutil.Pointer("body"),
+				Tags:   []string{},
+				Status: "deleted",
+			},
+		)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, jsonUtil.MustJSONify(jsonUtil.JSON{"error": domain.ErrInvalidArticleStatus.Error()}), res.Body.String())
+	})
+}
+
+func TestArticleLinkName(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	linkName := "link-" + strings.ToLower(uuid.New().String()[:8])
+
+	res := postV1Articles(
+		header,
+		postArticleAdapter{
+			LinkName: linkName,
+			Title:    stringutil.Pointer("title"),
+			Body:     stringutil.Pointer("body"),
+			Tags:     []string{},
+		},
+	)
+	if res.Code != http.StatusCreated {
+		t.Fatal("failed to create test article data")
+	}
+
+	groups := regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))
+	articleID := groups[1]
+
+	t.Run("GetByLinkName", func(t *testing.T) {
+		res := getV1Article(linkName)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var articleJSON articleViewResponse
+		if err := json.NewDecoder(res.Body).Decode(&articleJSON); err != nil {
+			t.Fatal("invalid json: ", err.Error())
 		}
+		assert.Equal(t, articleID, articleJSON.ID)
+		assert.Equal(t, linkName, articleJSON.LinkName)
+	})
+
+	t.Run("AlreadyUsed", func(t *testing.T) {
+		res := postV1Articles(
+			header,
+			postArticleAdapter{
+				LinkName: linkName,
+				Title:    stringutil.Pointer("title"),
+				Body:     stringutil.Pointer("body"),
+				Tags:     []string{},
+			},
+		)
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		res := postV1Articles(
+			header,
+			postArticleAdapter{
+				LinkName: "Invalid Link Name",
+				Title:    stringutil.Pointer("title"),
+				Body:     stringutil.Pointer("body"),
+				Tags:     []string{},
+			},
+		)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	t.Run("RedirectOldLinkName", func(t *testing.T) {
+		newLinkName := linkName + "-renamed"
+		res := putV1Articles(articleID, header, postArticleAdapter{
+			LinkName: newLinkName,
+			Title:    stringutil.Pointer("title"),
+			Body:     stringutil.Pointer("body"),
+			Tags:     []string{},
+		})
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		res = getV1Article(linkName)
+		assert.Equal(t, http.StatusMovedPermanently, res.Code)
+		assert.Equal(t, "/v1/articles/"+newLinkName, res.Header().Get("Location"))
+
+		res = getV1Article(linkName + "?format=html")
+		assert.Equal(t, http.StatusMovedPermanently, res.Code)
+		assert.Equal(t, "/v1/articles/"+newLinkName+"?format=html", res.Header().Get("Location"))
+
+		assert.Equal(t, http.StatusOK, getV1Article(newLinkName).Code)
+	})
+}
+
+func TestArticleRevisions(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	res := postV1Articles(header, postArticleAdapter{
+		Title: stringutil.Pointer("title"),
+		Body:  stringutil.Pointer("line1\nline2"),
+		Tags:  []string{},
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatal("failed to create test article data")
 	}
-	res := httptest.NewRecorder()
 
-	router.ServeHTTP(res, req)
+	groups := regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))
+	articleID := groups[1]
 
-	return res
+	res = putV1Articles(articleID, header, postArticleAdapter{
+		Title: stringutil.Pointer("title"),
+		Body:  stringutil.Pointer("line1\nline3"),
+		Tags:  []string{},
+	})
+	if res.Code != http.StatusOK {
+		t.Fatal("failed to edit test article data")
+	}
+
+	t.Run("List", func(t *testing.T) {
+		res := getWithHeader("/v1/articles/"+articleID+"/revisions", header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var revisions articleRevisionListView
+		if err := json.NewDecoder(res.Body).Decode(&revisions); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		if assert.Len(t, revisions, 2) {
+			assert.Equal(t, 2, revisions[0].Number)
+			assert.Equal(t, 1, revisions[1].Number)
+		}
+	})
+
+	t.Run("NotAuthor", func(t *testing.T) {
+		other := http.Header{"Authorization": []string{"Bearer " + testUtil.NewUser(c, dataStore).AccessToken}}
+		res := getWithHeader("/v1/articles/"+articleID+"/revisions", other)
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("EditAny", func(t *testing.T) {
+		admin := testUtil.NewUser(c, dataStore)
+		admin.Role = "admin"
+		if _, err := dataStore.Put(c, admin.Key, admin); err != nil {
+			t.Fatal(err)
+		}
+		adminHeader := http.Header{"Authorization": []string{"Bearer " + admin.AccessToken}}
+
+		assert.Equal(t, http.StatusOK, getWithHeader("/v1/articles/"+articleID+"/revisions", adminHeader).Code)
+		assert.Equal(t, http.StatusOK, getWithHeader("/v1/articles/"+articleID+"/revisions/1", adminHeader).Code)
+		assert.Equal(t, http.StatusOK, getWithHeader("/v1/articles/"+articleID+"/revisions/2/diff", adminHeader).Code)
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		res := getWithHeader("/v1/articles/"+articleID+"/revisions/2/diff", header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var diff articleRevisionDiffResponse
+		if err := json.NewDecoder(res.Body).Decode(&diff); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		assert.Equal(t, 1, diff.From)
+		assert.Equal(t, 2, diff.To)
+		assert.Contains(t, diff.Lines, &articleRevisionDiffLine{Operation: "delete", Text: "line2"})
+		assert.Contains(t, diff.Lines, &articleRevisionDiffLine{Operation: "insert", Text: "line3"})
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		res := postV1ArticleStatus(articleID, "revisions/1/restore", header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		res = getV1Article(articleID)
+		var articleJSON articleViewResponse
+		if err := json.NewDecoder(res.Body).Decode(&articleJSON); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		assert.Equal(t, "line1\nline2", articleJSON.Body)
+
+		res = getWithHeader("/v1/articles/"+articleID+"/revisions/3", header)
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("NoSuchRevision", func(t *testing.T) {
+		res := getWithHeader("/v1/articles/"+articleID+"/revisions/100", header)
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
 }
 
-func getV1Article(articleID string) *httptest.ResponseRecorder {
-	req := httptest.NewRequest(http.MethodGet, "/v1/articles/"+articleID, nil)
-	res := httptest.NewRecorder()
-	router.ServeHTTP(res, req)
-	return res
+func TestPutArticleIfMatch(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	res := postV1Articles(header, postArticleAdapter{
+		Title: stringutil.Pointer("title"),
+		Body:  stringutil.Pointer("body"),
+		Tags:  []string{},
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatal("failed to create test article data")
+	}
+
+	groups := regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))
+	articleID := groups[1]
+
+	etag := getV1Article(articleID).Header().Get("ETag")
+	if !assert.NotEmpty(t, etag) {
+		t.FailNow()
+	}
+
+	edit := func(ifMatch string) *httptest.ResponseRecorder {
+		return putV1Articles(articleID, http.Header{
+			"Authorization": []string{"Bearer " + user.AccessToken},
+			"If-Match":      []string{ifMatch},
+		}, postArticleAdapter{
+			Title: stringutil.Pointer("title"),
+			Body:  stringutil.Pointer("edited"),
+			Tags:  []string{},
+		})
+	}
+
+	assert.Equal(t, http.StatusOK, edit(etag).Code)
+	assert.NotEqual(t, etag, getV1Article(articleID).Header().Get("ETag"))
+
+	t.Run("Stale", func(t *testing.T) {
+		res := edit(etag)
+		assert.Equal(t, http.StatusPreconditionFailed, res.Code)
+		assert.JSONEq(t, jsonUtil.MustJSONify(jsonUtil.JSON{"error": domain.ErrArticleVersionConflict.Error()}), res.Body.String())
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		assert.Equal(t, http.StatusPreconditionFailed, edit("version").Code)
+	})
+
+	t.Run("Weak", func(t *testing.T) {
+		current := getV1Article(articleID).Header().Get("ETag")
+		assert.Equal(t, http.StatusPreconditionFailed, edit("W/"+current).Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		current := getV1Article(articleID).Header().Get("ETag")
+		assert.Equal(t, http.StatusOK, edit(etag+", "+current).Code)
+	})
+
+	t.Run("Any", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, edit("*").Code)
+	})
+}
+
+func TestGetArticleAsHTML(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	res := postV1Articles(header, postArticleAdapter{
+		Title: stringutil.Pointer("title"),
+		Body:  stringutil.Pointer("# Heading\n\n<script>alert(1)</script>"),
+		Tags:  []string{},
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatal("failed to create test article data")
+	}
+
+	groups := regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))
+	articleID := groups[1]
+
+	res = getV1Article(articleID + "?format=html")
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.NotEqual(t, getV1Article(articleID).Header().Get("ETag"), res.Header().Get("ETag"))
+
+	var articleJSON articleViewResponse
+	if err := json.NewDecoder(res.Body).Decode(&articleJSON); err != nil {
+		t.Fatal("invalid json: ", err.Error())
+	}
+	assert.Contains(t, articleJSON.BodyHTML, "Heading</h1>")
+	assert.NotContains(t, articleJSON.BodyHTML, "<script>")
+	assert.Len(t, articleJSON.TableOfContents, 1)
+	assert.Equal(t, 1, articleJSON.ReadingTime)
+
+	assert.Equal(t, http.StatusBadRequest, getV1Article(articleID+"?format=pdf").Code)
+}
+
+func TestSearchArticles(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	word := "word" + strings.ToLower(uuid.New().String()[:8])
+
+	for i := 0; i < 3; i++ {
+		res := postV1Articles(header, postArticleAdapter{
+			Title: stringutil.Pointer("title"),
+			Body:  stringutil.Pointer("body with " + word),
+			Tags:  []string{},
+		})
+		if res.Code != http.StatusCreated {
+			t.Fatal("failed to create test article data")
+		}
+	}
+
+	res := getV1Article("search?q=" + word + "&perPage=2")
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	var result articleSearchAdapter
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatal("invalid json: ", err.Error())
+	}
+	assert.Equal(t, 3, result.Total)
+	assert.Len(t, result.Articles, 2)
+	assert.Contains(t, result.Articles[0].Snippet, "<mark>"+word+"</mark>")
+	assert.Equal(t, "/v1/articles/search?page=2&perPage=2&q="+word, result.NextPage)
+
+	t.Run("EmptyQuery", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, getV1Article("search").Code)
+	})
+}
+
+func TestArticleFeeds(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	tag := "tag" + strings.ToLower(uuid.New().String()[:8])
+
+	res := postV1Articles(header, postArticleAdapter{
+		Title: stringutil.Pointer("feed title"),
+		Body:  stringutil.Pointer("feed body"),
+		Tags:  []string{tag},
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatal("failed to create test article data")
+	}
+
+	t.Run("RSS", func(t *testing.T) {
+		res := getWithHeader("/v1/articleTags/"+tag+"/feed.rss", nil)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "application/rss+xml; charset=utf-8", res.Header().Get("Content-Type"))
+		assert.Contains(t, res.Body.String(), "<title>feed title</title>")
+		assert.Contains(t, res.Body.String(), "<category>"+tag+"</category>")
+	})
+
+	t.Run("Atom", func(t *testing.T) {
+		res := getWithHeader("/v1/articleTags/"+tag+"/feed.atom", nil)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "application/atom+xml; charset=utf-8", res.Header().Get("Content-Type"))
+		assert.Contains(t, res.Body.String(), `<link href="https://lmm.local/articles?tag=`+tag+`" rel="alternate"></link>`)
+	})
+
+	t.Run("NotModified", func(t *testing.T) {
+		etag := getWithHeader("/v1/articleTags/"+tag+"/feed.rss", nil).Header().Get("ETag")
+		if !assert.NotEmpty(t, etag) {
+			t.FailNow()
+		}
+
+		res := getWithHeader("/v1/articleTags/"+tag+"/feed.rss", http.Header{"If-None-Match": []string{etag}})
+		assert.Equal(t, http.StatusNotModified, res.Code)
+		assert.Empty(t, res.Body.String())
+
+		res = getWithHeader("/v1/articleTags/"+tag+"/feed.atom", http.Header{"If-None-Match": []string{etag}})
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+}
+
+func TestListArticlesByCursor(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	tag := "tag" + strings.ToLower(uuid.New().String()[:8])
+
+	for i := 0; i < 3; i++ {
+		res := postV1Articles(header, postArticleAdapter{
+			Title: stringutil.Pointer("title"),
+			Body:  stringutil.Pointer("body"),
+			Tags:  []string{tag},
+		})
+		if res.Code != http.StatusCreated {
+			t.Fatal("failed to create test article data")
+		}
+	}
+
+	list := func(path string) *articleCursorListAdapter {
+		res := getWithHeader(path, nil)
+		if !assert.Equal(t, http.StatusOK, res.Code) {
+			t.FailNow()
+		}
+
+		var result articleCursorListAdapter
+		if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		return &result
+	}
+
+	first := list("/v1/articles?cursor=&perPage=2&tag=" + tag)
+	assert.Len(t, first.Articles, 2)
+	if !assert.NotEmpty(t, first.NextCursor) {
+		t.FailNow()
+	}
+	assert.Contains(t, first.NextPage, "cursor=")
+
+	second := list(first.NextPage)
+	assert.Len(t, second.Articles, 1)
+	assert.Empty(t, second.NextCursor)
+	assert.Empty(t, second.NextPage)
+	assert.NotEqual(t, first.Articles[0].ID, second.Articles[0].ID)
+	assert.NotEqual(t, first.Articles[1].ID, second.Articles[0].ID)
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		res := getWithHeader("/v1/articles?cursor=!", nil)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, jsonUtil.MustJSONify(jsonUtil.JSON{"error": domain.ErrInvalidArticleCursor.Error()}), res.Body.String())
+	})
+
+	t.Run("AnotherFilter", func(t *testing.T) {
+		res := getWithHeader("/v1/articles?perPage=2&cursor="+url.QueryEscape(first.NextCursor), nil)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, jsonUtil.MustJSONify(jsonUtil.JSON{"error": domain.ErrInvalidArticleCursor.Error()}), res.Body.String())
+	})
+}
+
+func TestArticleTagsAdminOnly(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+
+	request := func(method, path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(`{"name":"new","into":"new"}`))
+		for key, values := range header {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPut, "/v1/articleTags/old"},
+		{http.MethodPost, "/v1/articleTags/old/merge"},
+		{http.MethodDelete, "/v1/articleTags/old"},
+	} {
+		assert.Equal(t, http.StatusUnauthorized, request(route.method, route.path, nil).Code)
+		assert.Equal(t, http.StatusForbidden, request(route.method, route.path, header).Code)
+	}
+}
+
+func TestListRelatedArticles(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	tag := "tag" + strings.ToLower(uuid.New().String()[:8])
+
+	ids := make([]string, 2)
+	for i := range ids {
+		res := postV1Articles(header, postArticleAdapter{
+			Title: stringutil.Pointer("title"),
+			Body:  stringutil.Pointer("body"),
+			Tags:  []string{tag},
+		})
+		if res.Code != http.StatusCreated {
+			t.Fatal("failed to create test article data")
+		}
+		ids[i] = regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))[1]
+	}
+
+	res := getV1Article(ids[0] + "/related?count=1")
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	var result articleRelatedListAdapter
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatal("invalid json: ", err.Error())
+	}
+	if assert.Len(t, result.Articles, 1) {
+		assert.Equal(t, ids[1], result.Articles[0].ID)
+	}
+
+	t.Run("InvalidCount", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, getV1Article(ids[0]+"/related?count=0").Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, getV1Article("nothing/related").Code)
+	})
+}
+
+func TestArticleSeries(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	ids := make([]string, 3)
+	for i := range ids {
+		res := postV1Articles(header, postArticleAdapter{
+			Title: stringutil.Pointer("title"),
+			Body:  stringutil.Pointer("body"),
+			Tags:  []string{},
+		})
+		if res.Code != http.StatusCreated {
+			t.Fatal("failed to create test article data")
+		}
+		ids[i] = regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))[1]
+	}
+
+	res := requestV1Series(http.MethodPost, "", header, postSeriesAdapter{Title: "series", Articles: ids})
+	if !assert.Equal(t, http.StatusCreated, res.Code) {
+		t.FailNow()
+	}
+	seriesID := regexp.MustCompile(`^/v1/series/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))[1]
+
+	t.Run("Navigation", func(t *testing.T) {
+		res := getV1ArticleWithHeader(ids[1], header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var article articleViewResponse
+		if err := json.NewDecoder(res.Body).Decode(&article); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		if assert.NotNil(t, article.Series) {
+			assert.Equal(t, seriesID, article.Series.ID)
+			assert.Equal(t, ids[0], article.Series.Previous.ID)
+			assert.Equal(t, ids[2], article.Series.Next.ID)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		res := getWithHeader("/v1/series/"+seriesID, header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var series seriesViewResponse
+		if err := json.NewDecoder(res.Body).Decode(&series); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		assert.Equal(t, "series", series.Title)
+		assert.Len(t, series.Articles, 3)
+
+		assert.Equal(t, http.StatusNotFound, getWithHeader("/v1/series/nothing", header).Code)
+	})
+
+	t.Run("ETagChangesWithSeries", func(t *testing.T) {
+		etag := getV1ArticleWithHeader(ids[1], header).Header().Get("ETag")
+
+		res := requestV1Series(http.MethodPut, "/"+seriesID, header, postSeriesAdapter{Title: "renamed", Articles: ids})
+		if !assert.Equal(t, http.StatusOK, res.Code) {
+			t.FailNow()
+		}
+		assert.NotEqual(t, etag, getV1ArticleWithHeader(ids[1], header).Header().Get("ETag"))
+	})
+
+	t.Run("AlreadyInSeries", func(t *testing.T) {
+		res := requestV1Series(http.MethodPost, "", header, postSeriesAdapter{Title: "series", Articles: ids[:1]})
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		other := testUtil.NewUser(c, dataStore)
+		otherHeader := http.Header{"Authorization": []string{"Bearer " + other.AccessToken}}
+
+		res := requestV1Series(http.MethodPut, "/"+seriesID, otherHeader, postSeriesAdapter{Title: "series"})
+		assert.Equal(t, http.StatusForbidden, res.Code)
+
+		res = requestV1Series(http.MethodDelete, "/"+seriesID, otherHeader, nil)
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		res := requestV1Series(http.MethodDelete, "/"+seriesID, header, nil)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, http.StatusNotFound, getWithHeader("/v1/series/"+seriesID, header).Code)
+	})
+}
+
+func TestListPopularArticles(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	res := postV1Articles(header, postArticleAdapter{
+		Title:  stringutil.Pointer("title"),
+		Body:   stringutil.Pointer("body"),
+		Tags:   []string{},
+		Status: "published",
+	})
+	if res.Code != http.StatusCreated {
+		t.Fatal("failed to create test article data")
+	}
+	articleID := regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))[1]
+
+	// views by the author are not counted, views by the same client are counted once
+	for _, client := range []http.Header{
+		header,
+		{"X-Forwarded-For": []string{"192.0.2.10"}},
+		{"X-Forwarded-For": []string{"192.0.2.10"}},
+		{"X-Forwarded-For": []string{"192.0.2.11"}},
+	} {
+		assert.Equal(t, http.StatusOK, getV1ArticleWithHeader(articleID, client).Code)
+	}
+
+	if _, err := viewCounter.Flush(c); err != nil {
+		t.Fatal(err)
+	}
+
+	res = getV1Article("popular?window=1d&count=50")
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	var result popularArticleListAdapter
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatal("invalid json: ", err.Error())
+	}
+	assert.Equal(t, "1d", result.Window)
+
+	var views int64
+	for _, article := range result.Articles {
+		if article.ID == articleID {
+			views = article.Views
+		}
+	}
+	assert.Equal(t, int64(2), views)
+
+	t.Run("InvalidWindow", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, getV1Article("popular?window=1y").Code)
+		assert.Equal(t, http.StatusBadRequest, getV1Article("popular?window=365d").Code)
+		assert.Equal(t, http.StatusBadRequest, getV1Article("popular?count=0").Code)
+	})
+}
+
+func TestListAuthorArticles(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	authors.register(user, "Writer")
+	header := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	articleIDs := make([]string, 0)
+	for _, status := range []string{"published", "draft", "published"} {
+		res := postV1Articles(header, postArticleAdapter{
+			Title:  stringutil.Pointer("title"),
+			Body:   stringutil.Pointer("body"),
+			Tags:   []string{},
+			Status: status,
+		})
+		if res.Code != http.StatusCreated {
+			t.Fatal("failed to create test article data")
+		}
+		articleIDs = append(articleIDs, regexp.MustCompile(`^/v1/articles/(.+)$`).FindStringSubmatch(res.Header().Get("Location"))[1])
+	}
+
+	t.Run("Article", func(t *testing.T) {
+		res := getV1Article(articleIDs[0])
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var article articleViewResponse
+		if err := json.NewDecoder(res.Body).Decode(&article); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		assert.Equal(t, &articleAuthorView{
+			Name:        user.Name,
+			DisplayName: "Writer",
+			Bio:         "bio of " + user.Name,
+		}, article.Author)
+	})
+
+	t.Run("ByPage", func(t *testing.T) {
+		res := getWithHeader("/v1/users/"+user.Name+"/articles?perPage=1", nil)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var result struct {
+			Author articleAuthorView `json:"author"`
+			articleListAdapterV2
+		}
+		if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		assert.Equal(t, user.Name, result.Author.Name)
+		assert.Equal(t, 2, result.Total)
+		if assert.Len(t, result.Articles, 1) {
+			assert.Equal(t, articleIDs[2], result.Articles[0].ID)
+		}
+		assert.Contains(t, result.NextPage, "/v1/users/"+user.Name+"/articles?")
+	})
+
+	t.Run("ByCursor", func(t *testing.T) {
+		res := getWithHeader("/v1/users/"+user.Name+"/articles?cursor=&perPage=5", header)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var result struct {
+			Author articleAuthorView `json:"author"`
+			articleCursorListAdapter
+		}
+		if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		assert.Equal(t, "Writer", result.Author.DisplayName)
+		if assert.Len(t, result.Articles, 2) {
+			assert.Equal(t, articleIDs[2], result.Articles[0].ID)
+			assert.Equal(t, articleIDs[0], result.Articles[1].ID)
+		}
+		assert.Empty(t, result.NextCursor)
+	})
+
+	t.Run("NoSuchUser", func(t *testing.T) {
+		res := getWithHeader("/v1/users/nobody"+uuidutil.NewUUID()[:8]+"/articles", nil)
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, jsonUtil.MustJSONify(jsonUtil.JSON{"error": domain.ErrNoSuchUser.Error()}), res.Body.String())
+	})
+}
+
+func postV1Articles(header http.Header, body postArticleAdapter) *httptest.ResponseRecorder {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to decode to json"))
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/articles", bytes.NewReader(b))
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func putV1Articles(articleID string, header http.Header, body postArticleAdapter) *httptest.ResponseRecorder {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to decode to json"))
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/articles/"+articleID, bytes.NewReader(b))
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func deleteV1Articles(articleID string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodDelete, "/v1/articles/"+articleID, nil)
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func postV1ArticleStatus(articleID, action string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/articles/"+articleID+"/"+action, nil)
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func getV1ArticleWithHeader(articleID string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/articles/"+articleID, nil)
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	return res
+}
+
+func getV1Article(articleID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/articles/"+articleID, nil)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	return res
+}
+
+func getWithHeader(path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	return res
+}
+
+func requestV1Series(method, path string, header http.Header, body interface{}) *httptest.ResponseRecorder {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to decode to json"))
+	}
+
+	req := httptest.NewRequest(method, "/v1/series"+path, bytes.NewReader(b))
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func TestVersionsFromIfMatch(t *testing.T) {
+	versions, ok := versionsFromIfMatch("*")
+	assert.True(t, ok)
+	assert.Nil(t, versions)
+
+	versions, ok = versionsFromIfMatch(`"1-abc", W/"2-def", "3-ghi"`)
+	assert.True(t, ok)
+	assert.Equal(t, []uint{1, 3}, versions)
+
+	_, ok = versionsFromIfMatch(`W/"1-abc"`)
+	assert.False(t, ok)
+
+	_, ok = versionsFromIfMatch(`"1-abc", version`)
+	assert.False(t, ok)
+}
+
+func TestArticleViewClientID(t *testing.T) {
+	clientID := func(clientIP string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/articles/article", nil)
+		c.Request.RemoteAddr = "169.254.1.1:10000"
+		c.Request.Header.Set("User-Agent", "Mozilla/5.0")
+		c.Request.Header.Set("X-Appengine-User-Ip", clientIP)
+		return articleViewClientID(c, 0)
+	}
+
+	assert.NotEqual(t, clientID("192.0.2.1"), clientID("192.0.2.2"))
+	assert.Equal(t, clientID("192.0.2.1"), clientID("192.0.2.1"))
+}
+
+func TestParseViewWindow(t *testing.T) {
+	window, err := parseViewWindow("7d")
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, window)
+
+	window, err = parseViewWindow("12h")
+	assert.NoError(t, err)
+	assert.Equal(t, 12*time.Hour, window)
+
+	_, err = parseViewWindow("9223372036854775807d")
+	assert.Equal(t, errInvalidWindow, err)
+
+	_, err = parseViewWindow("-1d")
+	assert.Equal(t, errInvalidWindow, err)
 }
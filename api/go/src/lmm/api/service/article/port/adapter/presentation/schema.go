@@ -1,9 +1,24 @@
 package ui
 
 type postArticleAdapter struct {
+	// LinkName is generated from title on posting if empty, and unchanged on editing if empty
+	LinkName string `json:"link_name,omitempty"`
+
 	Title *string  `json:"title"`
 	Body  *string  `json:"body"`
 	Tags  []string `json:"tags"`
+
+	// Status and PublishAt are only used on posting, articles are published at once by default
+	Status    string `json:"status,omitempty"`
+	PublishAt int64  `json:"publish_at,string,omitempty"`
+}
+
+type publishArticleAdapter struct {
+	Unlisted bool `json:"unlisted"`
+}
+
+type scheduleArticleAdapter struct {
+	PublishAt int64 `json:"publish_at,string"`
 }
 
 type articleListAdapter struct {
@@ -23,19 +38,99 @@ type articleListAdapterV2 struct {
 	LastPage  string            `json:"lastPage,omitempty"`
 }
 
+type articleCursorListAdapter struct {
+	Articles   []articleListItem `json:"articles"`
+	PerPage    int               `json:"perPage"`
+	Tag        string            `json:"tag,omitempty"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	NextPage   string            `json:"nextPage,omitempty"`
+}
+
+type authorArticleListAdapter struct {
+	Author *articleAuthorView `json:"author"`
+	*articleListAdapterV2
+}
+
+type authorArticleCursorListAdapter struct {
+	Author *articleAuthorView `json:"author"`
+	*articleCursorListAdapter
+}
+
+type articleAuthorView struct {
+	Name          string `json:"name"`
+	DisplayName   string `json:"display_name"`
+	Bio           string `json:"bio,omitempty"`
+	AvatarAssetID string `json:"avatar_asset_id,omitempty"`
+}
+
+type articleRelatedListAdapter struct {
+	Articles []articleListItem `json:"articles"`
+}
+
+type popularArticleListAdapter struct {
+	Articles []popularArticleItem `json:"articles"`
+	Window   string               `json:"window"`
+}
+
+type popularArticleItem struct {
+	articleListItem
+	Views int64 `json:"views"`
+}
+
+type articleSearchAdapter struct {
+	Articles  []articleSearchItem `json:"articles"`
+	Query     string              `json:"q"`
+	Page      int                 `json:"page"`
+	PerPage   int                 `json:"perPage"`
+	Total     int                 `json:"total"`
+	PrevPage  string              `json:"prevPage,omitempty"`
+	NextPage  string              `json:"nextPage,omitempty"`
+	FirstPage string              `json:"firstPage,omitempty"`
+	LastPage  string              `json:"lastPage,omitempty"`
+}
+
+type articleSearchItem struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
+	PostAt  int64  `json:"post_at,string"`
+}
+
 type articleListItem struct {
 	ID     string `json:"id"`
 	Title  string `json:"title"`
+	Status string `json:"status"`
 	PostAt int64  `json:"post_at,string"`
 }
 
 type articleViewResponse struct {
 	ID           string           `json:"id"`
+	LinkName     string           `json:"link_name"`
 	Title        string           `json:"title"`
 	Body         string           `json:"body"`
+	Status       string           `json:"status"`
+	Version      uint             `json:"version"`
 	PostAt       int64            `json:"post_at,string"`
+	PublishedAt  int64            `json:"published_at,string,omitempty"`
 	LastEditedAt int64            `json:"last_edited_at,string"`
 	Tags         []articleViewTag `json:"tags"`
+
+	// Author is empty if the author no longer exists
+	Author *articleAuthorView `json:"author,omitempty"`
+
+	// Series is empty if the article is not in any series
+	Series *articleSeriesView `json:"series,omitempty"`
+
+	// only filled if format=html
+	BodyHTML        string            `json:"body_html,omitempty"`
+	TableOfContents []*articleTOCItem `json:"toc,omitempty"`
+	ReadingTime     int               `json:"reading_time,omitempty"`
+}
+
+type articleTOCItem struct {
+	Level  int    `json:"level"`
+	Anchor string `json:"anchor"`
+	Title  string `json:"title"`
 }
 
 type articleViewTag struct {
@@ -48,3 +143,53 @@ type articleTagListItemView struct {
 	Name  string `json:"name"`
 	Count int    `json:"count"`
 }
+
+type articleRevisionListView = []*articleRevisionResponse
+
+type articleRevisionResponse struct {
+	Number    int              `json:"number"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body,omitempty"`
+	Tags      []articleViewTag `json:"tags"`
+	EditorID  int64            `json:"editor_id,string"`
+	CreatedAt int64            `json:"created_at,string"`
+}
+
+type articleRevisionDiffResponse struct {
+	From  int                        `json:"from"`
+	To    int                        `json:"to"`
+	Lines []*articleRevisionDiffLine `json:"lines"`
+}
+
+type articleRevisionDiffLine struct {
+	Operation string `json:"operation"`
+	Text      string `json:"text"`
+}
+
+type postSeriesAdapter struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Articles    []string `json:"articles"`
+}
+
+type seriesViewResponse struct {
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	Articles     []articleListItem `json:"articles"`
+	CreatedAt    int64             `json:"created_at,string"`
+	LastModified int64             `json:"last_modified,string"`
+}
+
+type articleSeriesView struct {
+	ID       string             `json:"id"`
+	Title    string             `json:"title"`
+	Previous *articleSeriesLink `json:"previous,omitempty"`
+	Next     *articleSeriesLink `json:"next,omitempty"`
+}
+
+type articleSeriesLink struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Link  string `json:"link"`
+}
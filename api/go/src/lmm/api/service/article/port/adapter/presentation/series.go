@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"net/http"
+	"net/url"
+
+	httpUtil "lmm/api/pkg/http"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// PostNewSeries handles POST /v1/series
+func (p *GinRouterProvider) PostNewSeries(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	reqBody := postSeriesAdapter{}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	seriesID, err := p.appService.SeriesCommand().PostNewSeries(c, command.PostSeries{
+		UserID:      user.ID,
+		Title:       reqBody.Title,
+		Description: reqBody.Description,
+		Articles:    reqBody.Articles,
+	})
+	if err == nil {
+		c.Header("Location", "/v1/series/"+seriesID.String())
+		httpUtil.Response(c, http.StatusCreated, "Success")
+		return
+	}
+	p.respondSeriesError(c, err)
+}
+
+// GetSeries handles GET /v1/series/:seriesID
+func (p *GinRouterProvider) GetSeries(c *gin.Context) {
+	var readerID int64
+	if user, ok := httpUtil.AuthFromGinContext(c); ok {
+		readerID = user.ID
+	}
+
+	series, articles, err := p.appService.SeriesQuery().SeriesByID(c, c.Param("seriesID"), readerID)
+	if err != nil {
+		p.respondSeriesError(c, err)
+		return
+	}
+
+	items := make([]articleListItem, len(articles), len(articles))
+	for i, article := range articles {
+		items[i] = articleListItem{
+			ID:     article.ID().String(),
+			Title:  article.Content().Text().Title(),
+			Status: article.Status().String(),
+			PostAt: article.CreatedAt().Unix(),
+		}
+	}
+
+	c.JSON(http.StatusOK, &seriesViewResponse{
+		ID:           series.ID().String(),
+		Title:        series.Title(),
+		Description:  series.Description(),
+		Articles:     items,
+		CreatedAt:    series.CreatedAt().Unix(),
+		LastModified: series.LastModified().Unix(),
+	})
+}
+
+// PutSeries handles PUT /v1/series/:seriesID
+func (p *GinRouterProvider) PutSeries(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	reqBody := postSeriesAdapter{}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	err := p.appService.SeriesCommand().EditSeries(c, command.EditSeries{
+		UserID:      user.ID,
+		SeriesID:    c.Param("seriesID"),
+		Title:       reqBody.Title,
+		Description: reqBody.Description,
+		Articles:    reqBody.Articles,
+	})
+	if err == nil {
+		httpUtil.Response(c, http.StatusOK, "Success")
+		return
+	}
+	p.respondSeriesError(c, err)
+}
+
+// DeleteSeries handles DELETE /v1/series/:seriesID
+func (p *GinRouterProvider) DeleteSeries(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	err := p.appService.SeriesCommand().DeleteSeries(c, command.DeleteSeries{
+		UserID:   user.ID,
+		SeriesID: c.Param("seriesID"),
+	})
+	if err == nil {
+		httpUtil.Response(c, http.StatusOK, "Success")
+		return
+	}
+	p.respondSeriesError(c, err)
+}
+
+func (p *GinRouterProvider) respondSeriesError(c *gin.Context, err error) {
+	original := errors.Cause(err)
+	switch original {
+	case domain.ErrEmptySeriesTitle, domain.ErrSeriesTitleTooLong, domain.ErrDuplicateSeriesArticle:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, original.Error())
+
+	case domain.ErrNoSuchArticle, domain.ErrInvalidArticleID:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, domain.ErrNoSuchArticle.Error())
+
+	case domain.ErrNotSeriesOwner, domain.ErrNotArticleAuthor:
+		httpUtil.ErrorResponse(c, http.StatusForbidden, original.Error())
+
+	case domain.ErrNoSuchSeries:
+		httpUtil.ErrorResponse(c, http.StatusNotFound, original.Error())
+
+	case domain.ErrArticleAlreadyInSeries:
+		httpUtil.ErrorResponse(c, http.StatusConflict, original.Error())
+
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+func (p *GinRouterProvider) articleSeriesViewToJSON(navigation *model.SeriesNavigation) *articleSeriesView {
+	link := func(article *model.Article) *articleSeriesLink {
+		if article == nil {
+			return nil
+		}
+		name := article.LinkName()
+		if name == "" {
+			name = article.ID().String()
+		}
+		return &articleSeriesLink{
+			ID:    article.ID().String(),
+			Title: article.Content().Text().Title(),
+			Link:  "/v1/articles/" + url.PathEscape(name),
+		}
+	}
+
+	return &articleSeriesView{
+		ID:       navigation.Series().ID().String(),
+		Title:    navigation.Series().Title(),
+		Previous: link(navigation.Previous()),
+		Next:     link(navigation.Next()),
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"net/http"
+
+	httpUtil "lmm/api/pkg/http"
+	"lmm/api/service/article/application/command"
+	"lmm/api/service/article/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+type renameArticleTagAdapter struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type mergeArticleTagAdapter struct {
+	Into string `json:"into" binding:"required"`
+}
+
+// RenameArticleTag handles PUT /v1/articleTags/:tag
+func (p *GinRouterProvider) RenameArticleTag(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	reqBody := renameArticleTagAdapter{}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	err := p.appService.Command().RenameArticleTag(c, command.RenameArticleTag{
+		UserID:  user.ID,
+		Tag:     c.Param("tag"),
+		NewName: reqBody.Name,
+	})
+	p.respondArticleTagChanged(c, err)
+}
+
+// MergeArticleTag handles POST /v1/articleTags/:tag/merge
+func (p *GinRouterProvider) MergeArticleTag(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	reqBody := mergeArticleTagAdapter{}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	err := p.appService.Command().MergeArticleTag(c, command.MergeArticleTag{
+		UserID: user.ID,
+		Tag:    c.Param("tag"),
+		Into:   reqBody.Into,
+	})
+	p.respondArticleTagChanged(c, err)
+}
+
+// DeleteArticleTag handles DELETE /v1/articleTags/:tag
+func (p *GinRouterProvider) DeleteArticleTag(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	err := p.appService.Command().DeleteArticleTag(c, command.DeleteArticleTag{
+		UserID: user.ID,
+		Tag:    c.Param("tag"),
+	})
+	p.respondArticleTagChanged(c, err)
+}
+
+func (p *GinRouterProvider) respondArticleTagChanged(c *gin.Context, err error) {
+	original := errors.Cause(err)
+	switch original {
+	case nil:
+		httpUtil.Response(c, http.StatusOK, "Success")
+
+	case domain.ErrInvalidTagName, domain.ErrSameArticleTag:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, original.Error())
+
+	case domain.ErrNoSuchArticleTag:
+		httpUtil.ErrorResponse(c, http.StatusNotFound, original.Error())
+
+	case domain.ErrArticleTagAlreadyUsed:
+		httpUtil.ErrorResponse(c, http.StatusConflict, original.Error())
+
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
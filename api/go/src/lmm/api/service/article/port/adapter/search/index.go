@@ -0,0 +1,254 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	titleWeight = 3.
+	tagWeight   = 2.
+	bodyWeight  = 1.
+)
+
+// PublishedArticleSource provides all published articles to build the index
+type PublishedArticleSource interface {
+	FindAllPublished(c context.Context) ([]*model.Article, error)
+}
+
+type document struct {
+	id     *model.ArticleID
+	title  string
+	body   string
+	tags   []string
+	postAt time.Time
+	terms  map[string]float64
+
+	// text holds terms of the title and the body only, used to compare articles
+	text map[string]float64
+}
+
+// Index is an in-process inverted index over title, body and tags of published articles
+type Index struct {
+	sync.RWMutex
+	documents map[model.ArticleID]*document
+	postings  map[string]map[model.ArticleID]float64
+
+	// rebuilding serializes Rebuild, changes records articles put or removed (nil) while rebuilding
+	// so that they are replayed onto the rebuilt index
+	rebuilding sync.Mutex
+	changes    map[model.ArticleID]*model.Article
+}
+
+// NewIndex creates an empty index
+func NewIndex() *Index {
+	return &Index{
+		documents: make(map[model.ArticleID]*document),
+		postings:  make(map[string]map[model.ArticleID]float64),
+	}
+}
+
+// Rebuild replaces all documents in the index with articles from source,
+// articles put or removed while loading them are kept as changed
+func (index *Index) Rebuild(c context.Context, source PublishedArticleSource) error {
+	index.rebuilding.Lock()
+	defer index.rebuilding.Unlock()
+
+	index.Lock()
+	index.changes = make(map[model.ArticleID]*model.Article)
+	index.Unlock()
+
+	articles, err := source.FindAllPublished(c)
+
+	index.Lock()
+	defer index.Unlock()
+
+	changes := index.changes
+	index.changes = nil
+
+	if err != nil {
+		return errors.Wrap(err, "failed to find published articles")
+	}
+
+	index.documents = make(map[model.ArticleID]*document)
+	index.postings = make(map[string]map[model.ArticleID]float64)
+	for _, article := range articles {
+		index.add(article)
+	}
+
+	for id, article := range changes {
+		id := id
+		index.remove(&id)
+		if article != nil {
+			index.add(article)
+		}
+	}
+
+	return nil
+}
+
+// Put adds the article into the index or replaces the old one,
+// the article is removed from the index if it's not published
+func (index *Index) Put(article *model.Article) {
+	index.Lock()
+	defer index.Unlock()
+
+	index.remove(article.ID())
+	index.add(article)
+
+	if index.changes != nil {
+		index.changes[*article.ID()] = article
+	}
+}
+
+// Remove removes the article from the index
+func (index *Index) Remove(id *model.ArticleID) {
+	index.Lock()
+	defer index.Unlock()
+
+	index.remove(id)
+
+	if index.changes != nil {
+		index.changes[*id] = nil
+	}
+}
+
+func (index *Index) add(article *model.Article) {
+	if article.Status() != model.ArticleStatusPublished {
+		return
+	}
+
+	doc := newDocument(article)
+
+	id := *article.ID()
+	index.documents[id] = doc
+	for term, frequency := range doc.terms {
+		if index.postings[term] == nil {
+			index.postings[term] = make(map[model.ArticleID]float64)
+		}
+		index.postings[term][id] = frequency
+	}
+}
+
+func newDocument(article *model.Article) *document {
+	text := article.Content().Text()
+
+	doc := &document{
+		id:     article.ID(),
+		title:  text.Title(),
+		body:   text.Body(),
+		tags:   make([]string, 0, len(article.Content().Tags())),
+		postAt: article.CreatedAt(),
+		terms:  make(map[string]float64),
+		text:   make(map[string]float64),
+	}
+
+	for _, term := range tokenize(text.Title()) {
+		doc.terms[term] += titleWeight
+		doc.text[term] += titleWeight
+	}
+	for _, tag := range article.Content().Tags() {
+		doc.tags = append(doc.tags, tag.Name())
+		for _, term := range tokenize(tag.Name()) {
+			doc.terms[term] += tagWeight
+		}
+	}
+	for _, term := range tokenize(text.Body()) {
+		doc.terms[term] += bodyWeight
+		doc.text[term] += bodyWeight
+	}
+
+	return doc
+}
+
+func (index *Index) remove(id *model.ArticleID) {
+	doc, ok := index.documents[*id]
+	if !ok {
+		return
+	}
+
+	for term := range doc.terms {
+		delete(index.postings[term], *id)
+		if len(index.postings[term]) == 0 {
+			delete(index.postings, term)
+		}
+	}
+	delete(index.documents, *id)
+}
+
+type scoredDocument struct {
+	document *document
+	score    float64
+}
+
+// SearchArticles implements model.ArticleSearcher.
+// Articles containing all terms of the query are ranked by tf-idf weighted by the field
+func (index *Index) SearchArticles(query string, count, page int) (*model.ArticleSearchView, error) {
+	terms := uniqueTokens(tokenize(query))
+	if len(terms) == 0 {
+		return model.NewArticleSearchView([]*model.ArticleSearchViewItem{}, query, page, count, 0, false), nil
+	}
+
+	index.RLock()
+	defer index.RUnlock()
+
+	scores := make(map[model.ArticleID]float64)
+	for i, term := range terms {
+		postings := index.postings[term]
+		idf := math.Log(1 + float64(len(index.documents))/float64(len(postings)+1))
+
+		for id, frequency := range postings {
+			if _, ok := scores[id]; i == 0 || ok {
+				scores[id] += frequency * idf
+			}
+		}
+
+		// drop articles which don't contain the term
+		for id := range scores {
+			if _, ok := postings[id]; !ok {
+				delete(scores, id)
+			}
+		}
+	}
+
+	matched := make([]*scoredDocument, 0, len(scores))
+	for id, score := range scores {
+		matched = append(matched, &scoredDocument{document: index.documents[id], score: score})
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].score != matched[j].score {
+			return matched[i].score > matched[j].score
+		}
+		return matched[i].document.postAt.After(matched[j].document.postAt)
+	})
+
+	total := len(matched)
+
+	offset := (page - 1) * count
+	if offset > total {
+		offset = total
+	}
+	end := offset + count
+	if end > total {
+		end = total
+	}
+
+	items := make([]*model.ArticleSearchViewItem, 0, end-offset)
+	for _, m := range matched[offset:end] {
+		items = append(items, model.NewArticleSearchViewItem(
+			m.document.id,
+			m.document.title,
+			snippet(m.document.body, terms),
+			m.document.postAt,
+		))
+	}
+
+	return model.NewArticleSearchView(items, query, page, count, total, end < total), nil
+}
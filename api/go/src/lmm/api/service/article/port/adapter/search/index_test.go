@@ -0,0 +1,101 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lmm/api/service/article/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestArticle(t *testing.T, id, title, body string, tags []string, status model.ArticleStatus) *model.Article {
+	content, err := model.NewContent(title, body, tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	return model.NewArticle(model.NewArticleID(id), model.NewAuthor(1), content, status, 1, now, now, now)
+}
+
+func searchIDs(t *testing.T, index *Index, query string) []string {
+	view, err := index.SearchArticles(query, 10, 1)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ids := make([]string, 0)
+	for _, item := range view.Items() {
+		ids = append(ids, item.ID().String())
+	}
+	return ids
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"hello", "world", "go", "1", "13"}, tokenize("Hello, World! Go 1.13"))
+	assert.Equal(t, []string{"日本", "本語", "go", "の"}, tokenize("日本語 go の"))
+	assert.Equal(t, []string{"東京", "京タ", "タワ", "ワー"}, tokenize("東京タワー"))
+}
+
+func TestIndex(t *testing.T) {
+	index := NewIndex()
+
+	index.Put(newTestArticle(t, "1", "Go concurrency", "goroutines and channels", []string{"golang"}, model.ArticleStatusPublished))
+	index.Put(newTestArticle(t, "2", "Cooking", "how to cook rice in go style", []string{}, model.ArticleStatusPublished))
+	index.Put(newTestArticle(t, "3", "東京の旅行", "東京タワーに行きました", []string{"旅行"}, model.ArticleStatusPublished))
+	index.Put(newTestArticle(t, "4", "Draft", "go draft", []string{}, model.ArticleStatusDraft))
+
+	t.Run("RankedByField", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "2"}, searchIDs(t, index, "go"))
+	})
+
+	t.Run("AllTermsRequired", func(t *testing.T) {
+		assert.Equal(t, []string{"2"}, searchIDs(t, index, "go rice"))
+		assert.Empty(t, searchIDs(t, index, "go python"))
+	})
+
+	t.Run("CJK", func(t *testing.T) {
+		assert.Equal(t, []string{"3"}, searchIDs(t, index, "東京タワー"))
+		assert.Equal(t, []string{"3"}, searchIDs(t, index, "旅行"))
+		assert.Empty(t, searchIDs(t, index, "京都"))
+	})
+
+	t.Run("Snippet", func(t *testing.T) {
+		view, err := index.SearchArticles("タワー", 10, 1)
+		assert.NoError(t, err)
+		if assert.Len(t, view.Items(), 1) {
+			assert.Equal(t, "東京<mark>タワー</mark>に行きました", view.Items()[0].Snippet())
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		view, err := index.SearchArticles("go", 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, view.Total())
+		assert.True(t, view.HasNextPage())
+		assert.Len(t, view.Items(), 1)
+
+		view, err = index.SearchArticles("go", 1, 3)
+		assert.NoError(t, err)
+		assert.False(t, view.HasNextPage())
+		assert.Empty(t, view.Items())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		index.Put(newTestArticle(t, "2", "Cooking", "how to cook rice", []string{}, model.ArticleStatusPublished))
+		assert.Equal(t, []string{"1"}, searchIDs(t, index, "go"))
+
+		index.Put(newTestArticle(t, "1", "Go concurrency", "goroutines and channels", []string{"golang"}, model.ArticleStatusDraft))
+		assert.Empty(t, searchIDs(t, index, "go"))
+
+		index.Remove(model.NewArticleID("3"))
+		assert.Empty(t, searchIDs(t, index, "東京"))
+	})
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, "a &lt;b&gt; <mark>Go</mark>", snippet("a <b>\n\nGo", []string{"go"}))
+	assert.Equal(t, "…"+strings.Repeat("x", 29)+" <mark>go</mark>", snippet(strings.Repeat("x", 50)+" go", []string{"go"}))
+}
@@ -0,0 +1,114 @@
+package search
+
+import (
+	"math"
+	"sort"
+
+	"lmm/api/service/article/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	relatedTagWeight  = 1.
+	relatedTextWeight = 2.
+)
+
+// RelatedArticles implements model.ArticleRecommender.
+// Published articles are scored by tags shared with the article, weighted by how rare the tags are,
+// and by the cosine similarity of tf-idf vectors of their titles and bodies
+func (index *Index) RelatedArticles(article *model.Article, count int) ([]*model.ArticleListViewItem, error) {
+	target := newDocument(article)
+
+	index.RLock()
+	defer index.RUnlock()
+
+	total := float64(len(index.documents))
+
+	tagFrequencies := make(map[string]int)
+	for _, doc := range index.documents {
+		for _, tag := range doc.tags {
+			tagFrequencies[tag]++
+		}
+	}
+
+	idf := func(term string) float64 {
+		return math.Log(1 + total/float64(len(index.postings[term])+1))
+	}
+
+	targetVector := make(map[string]float64, len(target.text))
+	for term, frequency := range target.text {
+		targetVector[term] = frequency * idf(term)
+	}
+	targetNorm := norm(targetVector)
+
+	matched := make([]*scoredDocument, 0)
+	for id, doc := range index.documents {
+		if id == *article.ID() {
+			continue
+		}
+
+		var tagScore float64
+		for _, tag := range doc.tags {
+			if containsString(target.tags, tag) {
+				tagScore += math.Log(1 + total/float64(tagFrequencies[tag]))
+			}
+		}
+
+		var dot float64
+		vector := make(map[string]float64, len(doc.text))
+		for term, frequency := range doc.text {
+			vector[term] = frequency * idf(term)
+			dot += vector[term] * targetVector[term]
+		}
+
+		var textScore float64
+		if dot > 0 {
+			textScore = dot / (norm(vector) * targetNorm)
+		}
+
+		score := relatedTagWeight*tagScore + relatedTextWeight*textScore
+		if score > 0 {
+			matched = append(matched, &scoredDocument{document: doc, score: score})
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].score != matched[j].score {
+			return matched[i].score > matched[j].score
+		}
+		return matched[i].document.postAt.After(matched[j].document.postAt)
+	})
+
+	if len(matched) > count {
+		matched = matched[:count]
+	}
+
+	items := make([]*model.ArticleListViewItem, len(matched), len(matched))
+	for i, m := range matched {
+		item, err := model.NewArticleListViewItem(m.document.id, m.document.title, model.ArticleStatusPublished, m.document.postAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		items[i] = item
+	}
+
+	return items, nil
+}
+
+func norm(vector map[string]float64) float64 {
+	var sum float64
+	for _, v := range vector {
+		sum += v * v
+	}
+	return math.Sqrt(sum)
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
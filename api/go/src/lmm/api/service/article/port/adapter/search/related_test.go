@@ -0,0 +1,46 @@
+package search
+
+import (
+	"testing"
+
+	"lmm/api/service/article/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelatedArticles(t *testing.T) {
+	index := NewIndex()
+
+	target := newTestArticle(t, "1", "Go concurrency", "goroutines and channels", []string{"golang", "programming"}, model.ArticleStatusPublished)
+	index.Put(target)
+	index.Put(newTestArticle(t, "2", "Channels in depth", "buffered channels and goroutines", []string{"programming"}, model.ArticleStatusPublished))
+	index.Put(newTestArticle(t, "3", "Generics", "type parameters", []string{"golang", "programming"}, model.ArticleStatusPublished))
+	index.Put(newTestArticle(t, "4", "Travel", "trip to Tokyo", []string{"travel"}, model.ArticleStatusPublished))
+	index.Put(newTestArticle(t, "5", "Web", "http server", []string{"programming"}, model.ArticleStatusPublished))
+	index.Put(newTestArticle(t, "6", "Draft goroutines", "goroutines and channels", []string{"golang"}, model.ArticleStatusDraft))
+
+	relatedIDs := func(article *model.Article, count int) []string {
+		items, err := index.RelatedArticles(article, count)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		ids := make([]string, 0)
+		for _, item := range items {
+			ids = append(ids, item.ID().String())
+		}
+		return ids
+	}
+
+	t.Run("Ranked", func(t *testing.T) {
+		assert.Equal(t, []string{"3", "2", "5"}, relatedIDs(target, 10))
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		assert.Equal(t, []string{"3", "2"}, relatedIDs(target, 2))
+	})
+
+	t.Run("Unrelated", func(t *testing.T) {
+		assert.Empty(t, relatedIDs(newTestArticle(t, "7", "Cooking", "rice", []string{"food"}, model.ArticleStatusDraft), 10))
+	})
+}
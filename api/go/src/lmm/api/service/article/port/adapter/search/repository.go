@@ -0,0 +1,115 @@
+package search
+
+import (
+	"context"
+	"sync"
+
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/domain/model"
+)
+
+// indexChange is a change to the index made in a transaction, article is nil if removed
+type indexChange struct {
+	id      *model.ArticleID
+	article *model.Article
+}
+
+// IndexedArticleRepository keeps the index up to date with articles saved and removed by the repository.
+// Changes made in transactions run by it are applied to the index only after committed
+type IndexedArticleRepository struct {
+	model.ArticleRepository
+	transactionManager transaction.Manager
+	index              *Index
+
+	mu      sync.Mutex
+	pending map[transaction.Transaction][]indexChange
+}
+
+// NewIndexedArticleRepository wraps repository to update index
+func NewIndexedArticleRepository(repository model.ArticleRepository, transactionManager transaction.Manager, index *Index) *IndexedArticleRepository {
+	return &IndexedArticleRepository{
+		ArticleRepository:  repository,
+		transactionManager: transactionManager,
+		index:              index,
+		pending:            make(map[transaction.Transaction][]indexChange),
+	}
+}
+
+// Begin implementation, changes in the transaction are applied to the index immediately
+func (r *IndexedArticleRepository) Begin(c context.Context, opts *transaction.Option) (transaction.Transaction, error) {
+	return r.transactionManager.Begin(c, opts)
+}
+
+// RunInTransaction runs f and applies its changes to the index if the transaction is committed
+func (r *IndexedArticleRepository) RunInTransaction(c context.Context, f transaction.FuncRunInTransaction, opts *transaction.Option) error {
+	var changes []indexChange
+
+	err := r.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		r.mu.Lock()
+		r.pending[tx] = make([]indexChange, 0)
+		r.mu.Unlock()
+
+		defer func() {
+			r.mu.Lock()
+			changes = r.pending[tx]
+			delete(r.pending, tx)
+			r.mu.Unlock()
+		}()
+
+		return f(tx)
+	}, opts)
+
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		r.apply(change)
+	}
+
+	return nil
+}
+
+// Save saves article and puts it into the index
+func (r *IndexedArticleRepository) Save(tx transaction.Transaction, article *model.Article) error {
+	if err := r.ArticleRepository.Save(tx, article); err != nil {
+		return err
+	}
+
+	r.change(tx, indexChange{id: article.ID(), article: article})
+
+	return nil
+}
+
+// Remove removes article from the repository and the index
+func (r *IndexedArticleRepository) Remove(tx transaction.Transaction, id *model.ArticleID) error {
+	if err := r.ArticleRepository.Remove(tx, id); err != nil {
+		return err
+	}
+
+	r.change(tx, indexChange{id: id})
+
+	return nil
+}
+
+// change defers the change until the transaction is committed, or applies it now if not run by RunInTransaction
+func (r *IndexedArticleRepository) change(tx transaction.Transaction, change indexChange) {
+	r.mu.Lock()
+	changes, ok := r.pending[tx]
+	if ok {
+		r.pending[tx] = append(changes, change)
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		r.apply(change)
+	}
+}
+
+func (r *IndexedArticleRepository) apply(change indexChange) {
+	if change.article == nil {
+		r.index.Remove(change.id)
+	} else {
+		r.index.Put(change.article)
+	}
+}
@@ -0,0 +1,108 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/article/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// nopArticleRepository saves nothing
+type nopArticleRepository struct {
+	model.ArticleRepository
+}
+
+func (r *nopArticleRepository) Save(tx transaction.Transaction, article *model.Article) error {
+	return nil
+}
+
+func (r *nopArticleRepository) Remove(tx transaction.Transaction, id *model.ArticleID) error {
+	return nil
+}
+
+// nopTransactionManager runs functions in a new transaction each time
+type nopTransactionManager struct{}
+
+type nopTx struct {
+	transaction.Transaction
+}
+
+func (m *nopTransactionManager) Begin(c context.Context, opts *transaction.Option) (transaction.Transaction, error) {
+	return &nopTx{transaction.Nop()}, nil
+}
+
+func (m *nopTransactionManager) RunInTransaction(c context.Context, f transaction.FuncRunInTransaction, opts *transaction.Option) error {
+	tx, _ := m.Begin(c, opts)
+	return f(tx)
+}
+
+func TestIndexedArticleRepository(t *testing.T) {
+	c := context.Background()
+
+	index := NewIndex()
+	repo := NewIndexedArticleRepository(&nopArticleRepository{}, &nopTransactionManager{}, index)
+
+	article := newTestArticle(t, "1", "Go concurrency", "goroutines", []string{}, model.ArticleStatusPublished)
+
+	t.Run("NotAppliedBeforeCommit", func(t *testing.T) {
+		err := repo.RunInTransaction(c, func(tx transaction.Transaction) error {
+			if err := repo.Save(tx, article); err != nil {
+				return err
+			}
+			assert.Empty(t, searchIDs(t, index, "go"))
+			return nil
+		}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, searchIDs(t, index, "go"))
+	})
+
+	t.Run("NotAppliedIfFailed", func(t *testing.T) {
+		err := repo.RunInTransaction(c, func(tx transaction.Transaction) error {
+			if err := repo.Remove(tx, article.ID()); err != nil {
+				return err
+			}
+			return errors.New("failed")
+		}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"1"}, searchIDs(t, index, "go"))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		err := repo.RunInTransaction(c, func(tx transaction.Transaction) error {
+			return repo.Remove(tx, article.ID())
+		}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, searchIDs(t, index, "go"))
+	})
+}
+
+// sourceFunc provides articles by calling the func
+type sourceFunc func() []*model.Article
+
+func (f sourceFunc) FindAllPublished(c context.Context) ([]*model.Article, error) {
+	return f(), nil
+}
+
+func TestIndexRebuildKeepsChanges(t *testing.T) {
+	index := NewIndex()
+
+	old := newTestArticle(t, "1", "Go concurrency", "goroutines", []string{}, model.ArticleStatusPublished)
+	removed := newTestArticle(t, "2", "Go modules", "go.mod", []string{}, model.ArticleStatusPublished)
+	put := newTestArticle(t, "3", "Go generics", "type parameters", []string{}, model.ArticleStatusPublished)
+
+	err := index.Rebuild(context.Background(), sourceFunc(func() []*model.Article {
+		// articles loaded before the changes below
+		articles := []*model.Article{old, removed}
+
+		index.Put(put)
+		index.Remove(removed.ID())
+
+		return articles
+	}))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1", "3"}, searchIDs(t, index, "go"))
+}
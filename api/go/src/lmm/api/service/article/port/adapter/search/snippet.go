@@ -0,0 +1,63 @@
+package search
+
+import (
+	"html"
+	"strings"
+)
+
+var (
+	snippetLength = 120
+	snippetBefore = 30
+)
+
+// snippet cuts out the part of text around the first matched term,
+// the text is HTML escaped and the matched terms are wrapped by <mark>
+func snippet(text string, terms []string) string {
+	runes := []rune(normalizeSpaces(text))
+	lower := lowerRunes(string(runes))
+
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		t := []rune(term)
+		for i := indexRunes(lower, t, 0); i >= 0; i = indexRunes(lower, t, i+1) {
+			for j := i; j < i+len(t); j++ {
+				marked[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+
+	start := 0
+	if first > snippetBefore {
+		start = first - snippetBefore
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && marked[j] == marked[i] {
+			j++
+		}
+		if marked[i] {
+			b.WriteString("<mark>" + html.EscapeString(string(runes[i:j])) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(string(runes[i:j])))
+		}
+		i = j
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"lmm/api/service/article/domain"
+	"lmm/api/service/article/domain/model"
+	userApp "lmm/api/service/user/application"
+	userDomain "lmm/api/service/user/domain"
+	userModel "lmm/api/service/user/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// AuthorAdapter translates users in the user context into authors in the article context
+type AuthorAdapter struct {
+	userApp *userApp.Service
+}
+
+// NewAuthorAdapter creates a model.AuthorService backed by the user application service
+func NewAuthorAdapter(userApp *userApp.Service) *AuthorAdapter {
+	return &AuthorAdapter{userApp: userApp}
+}
+
+// AuthorProfiles implementation
+func (s *AuthorAdapter) AuthorProfiles(c context.Context, ids []int64) (map[int64]*model.AuthorProfile, error) {
+	users, err := s.userApp.UserDescriptorsByIDs(c, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[int64]*model.AuthorProfile, len(users))
+	for _, user := range users {
+		profiles[int64(user.ID())] = authorProfile(user)
+	}
+	return profiles, nil
+}
+
+// AuthorByName implementation
+func (s *AuthorAdapter) AuthorByName(c context.Context, name string) (*model.AuthorProfile, error) {
+	user, err := s.userApp.UserDescriptorByName(c, name)
+	switch errors.Cause(err) {
+	case nil:
+	case userDomain.ErrNoSuchUser:
+		return nil, errors.Wrap(domain.ErrNoSuchUser, name)
+	default:
+		return nil, err
+	}
+
+	return authorProfile(user), nil
+}
+
+func authorProfile(user *userModel.UserDescriptor) *model.AuthorProfile {
+	return model.NewAuthorProfile(
+		int64(user.ID()),
+		user.Name(),
+		user.DisplayName(),
+		user.Profile().Bio(),
+		user.Profile().AvatarAssetID(),
+	)
+}
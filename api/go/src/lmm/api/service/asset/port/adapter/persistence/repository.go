@@ -87,6 +87,12 @@ func (s *AssetDataStore) Save(c context.Context, model *usecase.Asset) error {
 	return nil
 }
 
+// photoItem is a projection of asset, whose timestamp is projected in microseconds
+type photoItem struct {
+	Filename  string `datastore:"Filename"`
+	CreatedAt int64  `datastore:"CreatedAt"`
+}
+
 type photoTag struct {
 	Name  string `datastore:"Name"`
 	Order int    `datastore:"Order"`
@@ -146,13 +152,13 @@ func (s *AssetDataStore) Find(c context.Context, id *usecase.AssetID) (*usecase.
 }
 
 func (s *AssetDataStore) ListPhotos(c context.Context, count int, cursor string) ([]*usecase.Photo, string, error) {
-	q := datastore.NewQuery(dsUtil.AssetKind).Project("Filename").Filter("Type =", "Photo").Order("-CreatedAt").Limit(count)
+	q := datastore.NewQuery(dsUtil.AssetKind).Project("Filename", "CreatedAt").Filter("Type =", "Photo").Order("-CreatedAt").Limit(count)
 	dsCursor, err := datastore.DecodeCursor(cursor)
 	if err == nil {
 		q = q.Start(dsCursor)
 	}
 
-	photo := asset{}
+	photo := photoItem{}
 	photos := make([]*usecase.Photo, 0)
 
 	iter := s.dataStore.Run(c, q)
@@ -173,9 +179,12 @@ Iteration:
 		}
 
 		photos = append(photos, &usecase.Photo{
-			ID:   key.Encode(),
-			URL:  s.GetPublicURL(c, photo.Filename),
-			Tags: tags,
+			ID:         key.Encode(),
+			UserID:     key.Parent.ID,
+			URL:        s.GetPublicURL(c, photo.Filename),
+			Tags:       tags,
+			Filename:   photo.Filename,
+			UploadedAt: time.Unix(0, photo.CreatedAt*int64(time.Microsecond)),
 		})
 	}
 
@@ -49,3 +49,12 @@ func (uploader *GCSUploader) Upload(c context.Context, asset *usecase.AssetToUpl
 
 	return fmt.Sprintf(templatePublicURL, uploader.bucketName, asset.Filename), nil
 }
+
+// Download opens the uploaded file, which is used to export photos
+func (uploader *GCSUploader) Download(c context.Context, filename string) (io.ReadCloser, error) {
+	r, err := uploader.bucket.Object(filename).NewReader(c)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s in GCS", filename)
+	}
+	return r, nil
+}
@@ -3,7 +3,9 @@ package presentation
 import (
 	"net/http"
 
+	"lmm/api/pkg/auth"
 	httpUtil "lmm/api/pkg/http"
+	"lmm/api/pkg/http/middleware"
 	"lmm/api/service/asset/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -23,8 +25,8 @@ func NewGinRouterProvider(app *usecase.Usecase) *GinRouterProvider {
 }
 
 func (p *GinRouterProvider) Provide(router *gin.Engine) {
-	router.POST("/v1/photos", p.PostV1Photos)
-	router.PUT("/v1/photos/:photo/tags", p.PutV1PhotoTags)
+	router.POST("/v1/photos", middleware.RequirePermission(auth.PermissionUploadAsset), p.PostV1Photos)
+	router.PUT("/v1/photos/:photo/tags", middleware.RequirePermission(auth.PermissionUploadAsset), p.PutV1PhotoTags)
 	router.GET("/v1/photos", p.GetV1Photos)
 	router.GET("/v1/photos/:photo", p.GetV1Photo)
 }
@@ -120,7 +122,7 @@ func (p *GinRouterProvider) PutV1PhotoTags(c *gin.Context) {
 		return
 	}
 
-	err := p.usecase.SetPhotoTags(c, user.ID, photo.ID, tags.Tags)
+	err := p.usecase.SetPhotoTags(c, user.ID, photo.ID, tags.Tags, user.HasPermission(auth.PermissionEditAnyAsset))
 	if err != nil {
 		httpUtil.LogWarn(c, "error on setting photo tags", err)
 	}
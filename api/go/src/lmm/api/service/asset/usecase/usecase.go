@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"io"
+	"mime"
 	"path"
 	"time"
 
@@ -77,6 +78,11 @@ type Photo struct {
 	ID   string   `json:"id"`
 	URL  string   `json:"url"`
 	Tags []string `json:"tags"`
+
+	// UserID, Filename and UploadedAt are kept from clients, which are used to export photos
+	UserID     int64     `json:"-"`
+	Filename   string    `json:"-"`
+	UploadedAt time.Time `json:"-"`
 }
 
 type AssetRepository interface {
@@ -137,7 +143,8 @@ func (uc *Usecase) UploadPhoto(c context.Context, photo *AssetToUpload) (url str
 	return
 }
 
-func (uc *Usecase) SetPhotoTags(c context.Context, userID int64, id string, tags []string) error {
+// SetPhotoTags replaces tags of the photo uploaded by the user, or by anyone if editAny is true
+func (uc *Usecase) SetPhotoTags(c context.Context, userID int64, id string, tags []string, editAny bool) error {
 	assetID := NewAssetID(id)
 	return uc.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
 		asset, err := uc.assetRepository.Find(tx, assetID)
@@ -145,7 +152,7 @@ func (uc *Usecase) SetPhotoTags(c context.Context, userID int64, id string, tags
 			return errors.Wrap(ErrNoSuchPhoto, err.Error())
 		}
 
-		if userID != asset.UserID {
+		if userID != asset.UserID && !editAny {
 			return ErrForbidden
 		}
 
@@ -157,6 +164,35 @@ func (uc *Usecase) SetPhotoTags(c context.Context, userID int64, id string, tags
 	}, nil)
 }
 
+// RestorePhoto registers the photo as it was exported and uploads its file, which is used to restore exported photos
+func (uc *Usecase) RestorePhoto(c context.Context, asset *Asset, tags []string, data io.ReadCloser) error {
+	if asset.Filename == "" || path.Base(asset.Filename) != asset.Filename {
+		return errors.Errorf("invalid photo filename: %s", asset.Filename)
+	}
+	asset.Type = PhotoType
+
+	return uc.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		if err := uc.assetRepository.Save(tx, asset); err != nil {
+			return err
+		}
+
+		if err := uc.assetRepository.SetPhotoTags(tx, asset.ID, tags); err != nil {
+			return err
+		}
+
+		if _, err := uc.fileUploader.Upload(tx, &AssetToUpload{
+			ContentType: mime.TypeByExtension(path.Ext(asset.Filename)),
+			DataSource:  data,
+			Filename:    asset.Filename,
+			UserID:      asset.UserID,
+		}); err != nil {
+			return errors.Wrap(err, "failed to upload photo")
+		}
+
+		return nil
+	}, nil)
+}
+
 func (uc *Usecase) UploadAsset(c context.Context, assert *AssetToUpload) error {
 	panic("not implemented")
 }
@@ -175,9 +211,11 @@ func (uc *Usecase) GetPhotoInfo(c context.Context, id string) (photo *Photo, err
 		}
 
 		photo = &Photo{
-			ID:   asset.ID.String(),
-			URL:  uc.assetRepository.GetPublicURL(tx, asset.Filename),
-			Tags: tags,
+			ID:         asset.ID.String(),
+			URL:        uc.assetRepository.GetPublicURL(tx, asset.Filename),
+			Tags:       tags,
+			Filename:   asset.Filename,
+			UploadedAt: asset.UploadedAt,
 		}
 
 		return err
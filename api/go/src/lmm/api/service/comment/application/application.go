@@ -0,0 +1,28 @@
+package application
+
+// Service is like a registry for services in comment bounded context
+type Service struct {
+	commentCommandService *CommentCommandService
+	commentQueryService   *CommentQueryService
+}
+
+// NewService is a constructor of Service
+func NewService(
+	commentCommandService *CommentCommandService,
+	commentQueryService *CommentQueryService,
+) *Service {
+	return &Service{
+		commentCommandService: commentCommandService,
+		commentQueryService:   commentQueryService,
+	}
+}
+
+// Command service
+func (s *Service) Command() *CommentCommandService {
+	return s.commentCommandService
+}
+
+// Query service
+func (s *Service) Query() *CommentQueryService {
+	return s.commentQueryService
+}
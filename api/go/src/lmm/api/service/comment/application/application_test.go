@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/comment/domain"
+	"lmm/api/service/comment/domain/model"
+)
+
+type InmemoryCommentRepository struct {
+	sync.RWMutex
+	memory map[model.CommentID]*model.Comment
+	nextID int
+}
+
+func NewInmemoryCommentRepository() *InmemoryCommentRepository {
+	return &InmemoryCommentRepository{memory: make(map[model.CommentID]*model.Comment)}
+}
+
+func (repo *InmemoryCommentRepository) NextID(tx transaction.Transaction, articleID string) (*model.CommentID, error) {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.nextID++
+	return model.NewCommentID(strconv.Itoa(repo.nextID)), nil
+}
+
+func (repo *InmemoryCommentRepository) Save(tx transaction.Transaction, comment *model.Comment) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.memory[*comment.ID()] = comment
+	return nil
+}
+
+func (repo *InmemoryCommentRepository) Remove(tx transaction.Transaction, id *model.CommentID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if _, ok := repo.memory[*id]; !ok {
+		return domain.ErrNoSuchComment
+	}
+	delete(repo.memory, *id)
+	return nil
+}
+
+func (repo *InmemoryCommentRepository) FindByID(tx transaction.Transaction, id *model.CommentID) (*model.Comment, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	comment, ok := repo.memory[*id]
+	if !ok {
+		return nil, domain.ErrNoSuchComment
+	}
+	return comment, nil
+}
+
+func (repo *InmemoryCommentRepository) FindByArticle(tx transaction.Transaction, articleID string) ([]*model.Comment, error) {
+	return repo.find(func(comment *model.Comment) bool {
+		return comment.ArticleID() == articleID
+	}, 0), nil
+}
+
+func (repo *InmemoryCommentRepository) FindByStatus(tx transaction.Transaction, status model.CommentStatus, count int) ([]*model.Comment, error) {
+	return repo.find(func(comment *model.Comment) bool {
+		return comment.Status() == status
+	}, count), nil
+}
+
+func (repo *InmemoryCommentRepository) find(match func(*model.Comment) bool, count int) []*model.Comment {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	comments := make([]*model.Comment, 0)
+	for _, comment := range repo.memory {
+		if match(comment) {
+			comments = append(comments, comment)
+		}
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		a, _ := strconv.Atoi(comments[i].ID().String())
+		b, _ := strconv.Atoi(comments[j].ID().String())
+		return a < b
+	})
+
+	if count > 0 && len(comments) > count {
+		comments = comments[:count]
+	}
+	return comments
+}
+
+func (repo *InmemoryCommentRepository) Begin(c context.Context, opts *transaction.Option) (transaction.Transaction, error) {
+	return transaction.Nop(), nil
+}
+
+func (repo *InmemoryCommentRepository) RunInTransaction(c context.Context, f func(tx transaction.Transaction) error, opts *transaction.Option) error {
+	tx, err := repo.Begin(c, opts)
+	if err != nil {
+		panic("unexpected error: " + err.Error())
+	}
+	defer tx.Commit()
+
+	return f(tx)
+}
+
+type inmemoryArticleService map[string]*model.Article
+
+func (s inmemoryArticleService) ArticleByID(c context.Context, id string) (*model.Article, error) {
+	article, ok := s[id]
+	if !ok {
+		return nil, domain.ErrNoSuchArticle
+	}
+	return article, nil
+}
+
+type recordingCommentEventPublisher struct {
+	sync.Mutex
+	posted []*model.CommentID
+}
+
+func (pub *recordingCommentEventPublisher) NotifyCommentPosted(c context.Context, comment *model.Comment, article *model.Article) error {
+	pub.Lock()
+	defer pub.Unlock()
+
+	pub.posted = append(pub.posted, comment.ID())
+	return nil
+}
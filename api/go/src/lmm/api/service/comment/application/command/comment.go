@@ -0,0 +1,35 @@
+package command
+
+// PostComment command
+type PostComment struct {
+	ArticleID string
+
+	// ParentID is the id of the replied comment, empty if not a reply
+	ParentID string
+
+	// UserID is 0 if the commenter is anonymous
+	UserID int64
+	Name   string
+	Body   string
+
+	// Moderator is true if the commenter can moderate comments
+	Moderator bool
+}
+
+// ModerateComment command
+type ModerateComment struct {
+	UserID    int64
+	CommentID string
+	Status    string
+}
+
+// DeleteComment command
+type DeleteComment struct {
+	UserID    int64
+	CommentID string
+}
+
+// DeleteArticleComments command
+type DeleteArticleComments struct {
+	ArticleID string
+}
@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"lmm/api/clock"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/comment/application/command"
+	"lmm/api/service/comment/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// CommentCommandService is a command side application of comment
+type CommentCommandService struct {
+	articleService        model.ArticleService
+	commentRepository     model.CommentRepository
+	commentEventPublisher model.CommentEventPublisher
+	transactionManager    transaction.Manager
+}
+
+// NewCommentCommandService is a constructor of CommentCommandService
+func NewCommentCommandService(
+	articleService model.ArticleService,
+	commentRepository model.CommentRepository,
+	commentEventPublisher model.CommentEventPublisher,
+	transactionManager transaction.Manager,
+) *CommentCommandService {
+	return &CommentCommandService{
+		articleService:        articleService,
+		commentRepository:     commentRepository,
+		commentEventPublisher: commentEventPublisher,
+		transactionManager:    transactionManager,
+	}
+}
+
+// PostNewComment is used for posting a comment or a reply on a public article
+func (app *CommentCommandService) PostNewComment(c context.Context, cmd command.PostComment) (comment *model.Comment, err error) {
+	article, err := app.articleService.ArticleByID(c, cmd.ArticleID)
+	if err != nil {
+		return nil, err
+	}
+
+	commenter, err := model.NewCommenter(cmd.UserID, cmd.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *model.CommentID
+	if cmd.ParentID != "" {
+		parentID = model.NewCommentID(cmd.ParentID)
+	}
+
+	err = app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		if parentID != nil {
+			parent, err := app.commentRepository.FindByID(tx, parentID)
+			if err != nil {
+				return err
+			}
+			if err := parent.CheckReplyTo(article.ID()); err != nil {
+				return err
+			}
+		}
+
+		id, err := app.commentRepository.NextID(tx, article.ID())
+		if err != nil {
+			return err
+		}
+
+		comment, err = model.NewComment(id, article.ID(), parentID, commenter, cmd.Body,
+			model.PostedCommentStatus(commenter, article, cmd.Moderator), clock.Now(), time.Time{},
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := app.commentRepository.Save(tx, comment); err != nil {
+			return err
+		}
+
+		if err := app.commentEventPublisher.NotifyCommentPosted(c, comment, article); err != nil {
+			return errors.Wrap(err, "failed to notify comment posted")
+		}
+
+		return nil
+	}, nil)
+
+	return
+}
+
+// ModerateComment approves the comment or marks it as spam
+func (app *CommentCommandService) ModerateComment(c context.Context, cmd command.ModerateComment) error {
+	status, err := model.NewCommentStatus(cmd.Status)
+	if err != nil {
+		return err
+	}
+
+	return app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		comment, err := app.commentRepository.FindByID(tx, model.NewCommentID(cmd.CommentID))
+		if err != nil {
+			return err
+		}
+
+		if err := comment.Moderate(status); err != nil {
+			return err
+		}
+
+		return app.commentRepository.Save(tx, comment)
+	}, nil)
+}
+
+// DeleteComment deletes the comment with all replies to it
+func (app *CommentCommandService) DeleteComment(c context.Context, cmd command.DeleteComment) error {
+	return app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		comment, err := app.commentRepository.FindByID(tx, model.NewCommentID(cmd.CommentID))
+		if err != nil {
+			return err
+		}
+
+		comments, err := app.commentRepository.FindByArticle(tx, comment.ArticleID())
+		if err != nil {
+			return err
+		}
+
+		for _, id := range model.CommentWithReplies(comments, comment.ID()) {
+			if err := app.commentRepository.Remove(tx, id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil)
+}
+
+// DeleteArticleComments deletes all comments on the deleted article
+func (app *CommentCommandService) DeleteArticleComments(c context.Context, cmd command.DeleteArticleComments) error {
+	return app.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		comments, err := app.commentRepository.FindByArticle(tx, cmd.ArticleID)
+		if err != nil {
+			return err
+		}
+
+		for _, comment := range comments {
+			if err := app.commentRepository.Remove(tx, comment.ID()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil)
+}
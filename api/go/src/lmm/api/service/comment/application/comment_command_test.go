@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	_ "lmm/api/clock/testing"
+	"lmm/api/service/comment/application/command"
+	"lmm/api/service/comment/application/query"
+	"lmm/api/service/comment/domain"
+	"lmm/api/service/comment/domain/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentCommands(t *testing.T) {
+	c := context.Background()
+
+	articles := inmemoryArticleService{
+		"article": model.NewArticle("article", 1, "title"),
+		"other":   model.NewArticle("other", 1, "title"),
+	}
+	repo := NewInmemoryCommentRepository()
+	pub := &recordingCommentEventPublisher{}
+	app := NewCommentCommandService(articles, repo, pub, repo)
+	queryApp := NewCommentQueryService(articles, repo, repo)
+
+	threadIDs := func(articleID string) []string {
+		threads, err := queryApp.ArticleComments(c, articleID)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		ids := make([]string, 0)
+		var walk func(threads []*model.CommentThread)
+		walk = func(threads []*model.CommentThread) {
+			for _, thread := range threads {
+				ids = append(ids, thread.Comment().ID().String())
+				walk(thread.Replies())
+			}
+		}
+		walk(threads)
+		return ids
+	}
+
+	postComment := func(cmd command.PostComment) *model.CommentID {
+		comment, err := app.PostNewComment(c, cmd)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return comment.ID()
+	}
+
+	userComment := postComment(command.PostComment{ArticleID: "article", UserID: 1, Name: "author", Body: "hello"})
+	anonymousComment := postComment(command.PostComment{ArticleID: "article", Name: "anonymous", Body: "hi"})
+
+	assert.Equal(t, []*model.CommentID{userComment, anonymousComment}, pub.posted)
+
+	t.Run("NoSuchArticle", func(t *testing.T) {
+		_, err := app.PostNewComment(c, command.PostComment{ArticleID: "nothing", Name: "anonymous", Body: "hi"})
+		assert.Equal(t, domain.ErrNoSuchArticle, errors.Cause(err))
+	})
+
+	t.Run("PostedStatus", func(t *testing.T) {
+		comment, err := app.PostNewComment(c, command.PostComment{ArticleID: "other", UserID: 2, Name: "user", Body: "hello"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, model.CommentStatusPending, comment.Status())
+			assert.NoError(t, app.DeleteComment(c, command.DeleteComment{UserID: 1, CommentID: comment.ID().String()}))
+		}
+
+		comment, err = app.PostNewComment(c, command.PostComment{ArticleID: "other", UserID: 3, Name: "moderator", Body: "hello", Moderator: true})
+		if assert.NoError(t, err) {
+			assert.Equal(t, model.CommentStatusApproved, comment.Status())
+		}
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		assert.Equal(t, []string{userComment.String()}, threadIDs("article"))
+
+		pending, err := queryApp.CommentsByStatus(c, query.ListCommentQuery{Status: "pending", Count: 10})
+		assert.NoError(t, err)
+		if assert.Len(t, pending, 1) {
+			assert.Equal(t, anonymousComment, pending[0].ID())
+		}
+
+		_, err = app.PostNewComment(c, command.PostComment{ArticleID: "article", ParentID: anonymousComment.String(), Name: "user", Body: "reply"})
+		assert.Equal(t, domain.ErrNoSuchComment, errors.Cause(err))
+	})
+
+	t.Run("Moderate", func(t *testing.T) {
+		err := app.ModerateComment(c, command.ModerateComment{UserID: 1, CommentID: anonymousComment.String(), Status: "pending"})
+		assert.Equal(t, domain.ErrInvalidCommentStatus, errors.Cause(err))
+
+		err = app.ModerateComment(c, command.ModerateComment{UserID: 1, CommentID: "nothing", Status: "approved"})
+		assert.Equal(t, domain.ErrNoSuchComment, errors.Cause(err))
+
+		assert.NoError(t, app.ModerateComment(c, command.ModerateComment{UserID: 1, CommentID: anonymousComment.String(), Status: "approved"}))
+		assert.Equal(t, []string{userComment.String(), anonymousComment.String()}, threadIDs("article"))
+	})
+
+	t.Run("Reply", func(t *testing.T) {
+		_, err := app.PostNewComment(c, command.PostComment{ArticleID: "other", ParentID: userComment.String(), UserID: 1, Name: "author", Body: "reply"})
+		assert.Equal(t, domain.ErrCommentNotInArticle, errors.Cause(err))
+
+		reply := postComment(command.PostComment{ArticleID: "article", ParentID: userComment.String(), UserID: 1, Name: "author", Body: "reply"})
+		assert.Equal(t, []string{userComment.String(), reply.String(), anonymousComment.String()}, threadIDs("article"))
+
+		t.Run("Delete", func(t *testing.T) {
+			assert.NoError(t, app.DeleteComment(c, command.DeleteComment{UserID: 1, CommentID: userComment.String()}))
+			assert.Equal(t, []string{anonymousComment.String()}, threadIDs("article"))
+
+			_, err := repo.FindByID(nil, reply)
+			assert.Equal(t, domain.ErrNoSuchComment, err)
+		})
+	})
+
+	t.Run("DeleteArticleComments", func(t *testing.T) {
+		other := postComment(command.PostComment{ArticleID: "other", UserID: 1, Name: "author", Body: "comment"})
+
+		assert.NoError(t, app.DeleteArticleComments(c, command.DeleteArticleComments{ArticleID: "article"}))
+		assert.Empty(t, threadIDs("article"))
+		assert.Contains(t, threadIDs("other"), other.String())
+
+		assert.NoError(t, app.DeleteArticleComments(c, command.DeleteArticleComments{ArticleID: "article"}))
+	})
+}
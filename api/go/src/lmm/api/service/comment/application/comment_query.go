@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/comment/application/query"
+	"lmm/api/service/comment/domain/model"
+)
+
+// CommentQueryService is a query side application of comment
+type CommentQueryService struct {
+	articleService    model.ArticleService
+	commentRepository model.CommentRepository
+	txManager         transaction.Manager
+}
+
+// NewCommentQueryService is a constructor of CommentQueryService
+func NewCommentQueryService(
+	articleService model.ArticleService,
+	commentRepository model.CommentRepository,
+	txManager transaction.Manager,
+) *CommentQueryService {
+	return &CommentQueryService{
+		articleService:    articleService,
+		commentRepository: commentRepository,
+		txManager:         txManager,
+	}
+}
+
+// ArticleComments gets approved comments on the public article in threads
+func (app *CommentQueryService) ArticleComments(c context.Context, articleID string) (threads []*model.CommentThread, err error) {
+	article, err := app.articleService.ArticleByID(c, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		comments, err := app.commentRepository.FindByArticle(tx, article.ID())
+		if err != nil {
+			return err
+		}
+
+		visible := make([]*model.Comment, 0, len(comments))
+		for _, comment := range comments {
+			if comment.IsVisible() {
+				visible = append(visible, comment)
+			}
+		}
+
+		threads = model.NewCommentThreads(visible)
+
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
+
+// CommentsByStatus lists comments in the status from the oldest one, used as the moderation queue
+func (app *CommentQueryService) CommentsByStatus(c context.Context, q query.ListCommentQuery) (comments []*model.Comment, err error) {
+	status, err := model.NewCommentStatus(q.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.txManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		comments, err = app.commentRepository.FindByStatus(tx, status, q.Count)
+		return err
+	}, &transaction.Option{ReadOnly: true})
+
+	return
+}
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"gopkg.in/go-playground/validator.v8"
+)
+
+type ListCommentQuery struct {
+	Status string `form:"status,default=pending"`
+	Count  int    `form:"count,default=20" binding:"min=1,max=100"`
+}
+
+func (q *ListCommentQuery) ValidateErrors(err error) []string {
+	errors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+
+	errStrings := make([]string, 0, len(errors))
+	for _, err := range errors {
+		switch err.Field {
+		case "Count":
+			errStrings = append(errStrings, "invalid count")
+		}
+	}
+
+	return errStrings
+}
@@ -0,0 +1,36 @@
+package model
+
+import "context"
+
+// Article is the commented article seen from the comment context
+type Article struct {
+	id       string
+	authorID int64
+	title    string
+}
+
+// NewArticle creates an article
+func NewArticle(id string, authorID int64, title string) *Article {
+	return &Article{id: id, authorID: authorID, title: title}
+}
+
+// ID returns the id of the article
+func (a *Article) ID() string {
+	return a.id
+}
+
+// AuthorID returns the user id of the article author
+func (a *Article) AuthorID() int64 {
+	return a.authorID
+}
+
+// Title returns the title of the article
+func (a *Article) Title() string {
+	return a.title
+}
+
+// ArticleService finds articles open to comments in the article context,
+// returns domain.ErrNoSuchArticle if the article does not exist or is not public
+type ArticleService interface {
+	ArticleByID(c context.Context, id string) (*Article, error)
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"lmm/api/clock"
+	"lmm/api/service/comment/domain"
+)
+
+var (
+	commentBodyMaxLength = 2000
+)
+
+type CommentID string
+
+func NewCommentID(s string) *CommentID {
+	id := CommentID(s)
+	return &id
+}
+
+func (id *CommentID) String() string {
+	return string(*id)
+}
+
+// Comment is an aggregate root model of a comment on an article
+type Comment struct {
+	id          *CommentID
+	articleID   string
+	parentID    *CommentID
+	commenter   *Commenter
+	body        string
+	status      CommentStatus
+	createdAt   time.Time
+	moderatedAt time.Time
+}
+
+// NewComment is a comment constructor, parentID is nil if the comment is not a reply
+func NewComment(
+	id *CommentID,
+	articleID string,
+	parentID *CommentID,
+	commenter *Commenter,
+	body string,
+	status CommentStatus,
+	createdAt, moderatedAt time.Time,
+) (*Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, domain.ErrEmptyCommentBody
+	}
+	if utf8.RuneCountInString(body) > commentBodyMaxLength {
+		return nil, domain.ErrCommentBodyTooLong
+	}
+
+	return &Comment{
+		id:          id,
+		articleID:   articleID,
+		parentID:    parentID,
+		commenter:   commenter,
+		body:        body,
+		status:      status,
+		createdAt:   createdAt,
+		moderatedAt: moderatedAt,
+	}, nil
+}
+
+// PostedCommentStatus returns the status of a newly posted comment,
+// only comments from moderators or the article author are approved, others wait for moderation
+func PostedCommentStatus(commenter *Commenter, article *Article, moderator bool) CommentStatus {
+	if commenter.IsAnonymous() {
+		return CommentStatusPending
+	}
+	if moderator || commenter.UserID() == article.AuthorID() {
+		return CommentStatusApproved
+	}
+	return CommentStatusPending
+}
+
+// ID returns the id of the comment
+func (c *Comment) ID() *CommentID {
+	return c.id
+}
+
+// ArticleID returns the id of the commented article
+func (c *Comment) ArticleID() string {
+	return c.articleID
+}
+
+// ParentID returns the id of the replied comment, nil if the comment is not a reply
+func (c *Comment) ParentID() *CommentID {
+	return c.parentID
+}
+
+// Commenter returns who posted the comment
+func (c *Comment) Commenter() *Commenter {
+	return c.commenter
+}
+
+// Body returns the body of the comment
+func (c *Comment) Body() string {
+	return c.body
+}
+
+// Status returns the moderation status of the comment
+func (c *Comment) Status() CommentStatus {
+	return c.status
+}
+
+// CreatedAt returns the time when the comment is posted
+func (c *Comment) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// ModeratedAt returns the time when the comment is moderated, zero if never moderated
+func (c *Comment) ModeratedAt() time.Time {
+	return c.moderatedAt
+}
+
+// IsVisible returns true if the comment is shown to everyone
+func (c *Comment) IsVisible() bool {
+	return c.status == CommentStatusApproved
+}
+
+// Moderate approves the comment or marks it as spam
+func (c *Comment) Moderate(status CommentStatus) error {
+	if status != CommentStatusApproved && status != CommentStatusSpam {
+		return domain.ErrInvalidCommentStatus
+	}
+	c.status = status
+	c.moderatedAt = clock.Now()
+	return nil
+}
+
+// CheckReplyTo returns an error unless the comment can be replied on the article
+func (c *Comment) CheckReplyTo(articleID string) error {
+	if c.articleID != articleID {
+		return domain.ErrCommentNotInArticle
+	}
+	if !c.IsVisible() {
+		return domain.ErrNoSuchComment
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lmm/api/clock"
+	"lmm/api/service/comment/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestComment(t *testing.T, id string, parentID *CommentID, createdAt time.Time) *Comment {
+	commenter, err := NewCommenter(1, "user")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	comment, err := NewComment(NewCommentID(id), "article", parentID, commenter, "body", CommentStatusApproved, createdAt, time.Time{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return comment
+}
+
+func TestNewComment(t *testing.T) {
+	_, err := NewCommenter(0, " ")
+	assert.Equal(t, domain.ErrEmptyCommenterName, err)
+
+	_, err = NewCommenter(0, strings.Repeat("a", commenterNameMaxLength+1))
+	assert.Equal(t, domain.ErrCommenterNameTooLong, err)
+
+	anonymous, err := NewCommenter(0, " anonymous ")
+	assert.NoError(t, err)
+	assert.Equal(t, "anonymous", anonymous.Name())
+	assert.True(t, anonymous.IsAnonymous())
+	article := NewArticle("article", 1, "title")
+	assert.Equal(t, CommentStatusPending, PostedCommentStatus(anonymous, article, false))
+
+	user, err := NewCommenter(1, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, CommentStatusApproved, PostedCommentStatus(user, article, false))
+
+	other, err := NewCommenter(2, "other")
+	assert.NoError(t, err)
+	assert.Equal(t, CommentStatusPending, PostedCommentStatus(other, article, false))
+	assert.Equal(t, CommentStatusApproved, PostedCommentStatus(other, article, true))
+
+	now := clock.Now()
+
+	_, err = NewComment(NewCommentID("1"), "article", nil, user, "\n", CommentStatusApproved, now, time.Time{})
+	assert.Equal(t, domain.ErrEmptyCommentBody, err)
+
+	_, err = NewComment(NewCommentID("1"), "article", nil, user, strings.Repeat("あ", commentBodyMaxLength+1), CommentStatusApproved, now, time.Time{})
+	assert.Equal(t, domain.ErrCommentBodyTooLong, err)
+}
+
+func TestCommentModerate(t *testing.T) {
+	comment := newTestComment(t, "1", nil, clock.Now())
+
+	assert.Equal(t, domain.ErrInvalidCommentStatus, comment.Moderate(CommentStatusPending))
+
+	assert.NoError(t, comment.Moderate(CommentStatusSpam))
+	assert.False(t, comment.IsVisible())
+	assert.False(t, comment.ModeratedAt().IsZero())
+	assert.Equal(t, domain.ErrNoSuchComment, comment.CheckReplyTo("article"))
+
+	assert.NoError(t, comment.Moderate(CommentStatusApproved))
+	assert.NoError(t, comment.CheckReplyTo("article"))
+	assert.Equal(t, domain.ErrCommentNotInArticle, comment.CheckReplyTo("other"))
+}
+
+func TestNewCommentThreads(t *testing.T) {
+	now := clock.Now()
+
+	comments := []*Comment{
+		newTestComment(t, "reply", NewCommentID("1"), now.Add(2*time.Second)),
+		newTestComment(t, "2", nil, now.Add(time.Second)),
+		newTestComment(t, "1", nil, now),
+		newTestComment(t, "nested", NewCommentID("reply"), now.Add(3*time.Second)),
+		newTestComment(t, "orphan", NewCommentID("hidden"), now),
+	}
+
+	threads := NewCommentThreads(comments)
+	if assert.Len(t, threads, 2) {
+		assert.Equal(t, "1", threads[0].Comment().ID().String())
+		assert.Equal(t, "2", threads[1].Comment().ID().String())
+		assert.Empty(t, threads[1].Replies())
+
+		if assert.Len(t, threads[0].Replies(), 1) {
+			reply := threads[0].Replies()[0]
+			assert.Equal(t, "reply", reply.Comment().ID().String())
+			if assert.Len(t, reply.Replies(), 1) {
+				assert.Equal(t, "nested", reply.Replies()[0].Comment().ID().String())
+			}
+		}
+	}
+
+	assert.Equal(t,
+		[]*CommentID{NewCommentID("1"), NewCommentID("reply"), NewCommentID("nested")},
+		CommentWithReplies(comments, NewCommentID("1")),
+	)
+}
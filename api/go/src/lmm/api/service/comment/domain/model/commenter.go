@@ -0,0 +1,45 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"lmm/api/service/comment/domain"
+)
+
+var (
+	commenterNameMaxLength = 32
+)
+
+// Commenter is who posts a comment, an anonymous commenter has no user id
+type Commenter struct {
+	userID int64
+	name   string
+}
+
+// NewCommenter creates a commenter, userID is 0 if the commenter is anonymous
+func NewCommenter(userID int64, name string) (*Commenter, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, domain.ErrEmptyCommenterName
+	}
+	if utf8.RuneCountInString(name) > commenterNameMaxLength {
+		return nil, domain.ErrCommenterNameTooLong
+	}
+	return &Commenter{userID: userID, name: name}, nil
+}
+
+// UserID returns the user id of the commenter, 0 if anonymous
+func (c *Commenter) UserID() int64 {
+	return c.userID
+}
+
+// Name returns the display name of the commenter
+func (c *Commenter) Name() string {
+	return c.name
+}
+
+// IsAnonymous returns true if the commenter has not signed in
+func (c *Commenter) IsAnonymous() bool {
+	return c.userID == 0
+}
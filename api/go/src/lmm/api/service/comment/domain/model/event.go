@@ -0,0 +1,8 @@
+package model
+
+import "context"
+
+// CommentEventPublisher publishes domain events of comment
+type CommentEventPublisher interface {
+	NotifyCommentPosted(context.Context, *Comment, *Article) error
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"lmm/api/pkg/transaction"
+)
+
+// CommentRepository interface
+type CommentRepository interface {
+	NextID(tx transaction.Transaction, articleID string) (*CommentID, error)
+	Save(tx transaction.Transaction, comment *Comment) error
+	Remove(tx transaction.Transaction, id *CommentID) error
+	FindByID(tx transaction.Transaction, id *CommentID) (*Comment, error)
+
+	// FindByArticle finds all comments on the article in any status from the oldest one
+	FindByArticle(tx transaction.Transaction, articleID string) ([]*Comment, error)
+
+	// FindByStatus finds at most count comments in the status from the oldest one
+	FindByStatus(tx transaction.Transaction, status CommentStatus, count int) ([]*Comment, error)
+}
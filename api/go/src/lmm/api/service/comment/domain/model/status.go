@@ -0,0 +1,31 @@
+package model
+
+import "lmm/api/service/comment/domain"
+
+// CommentStatus shows the moderation state of a comment
+type CommentStatus string
+
+const (
+	// CommentStatusPending means the comment is waiting for moderation and only visible to admins
+	CommentStatusPending CommentStatus = "pending"
+
+	// CommentStatusApproved means the comment is visible to everyone
+	CommentStatusApproved CommentStatus = "approved"
+
+	// CommentStatusSpam means the comment has been rejected by a moderator
+	CommentStatusSpam CommentStatus = "spam"
+)
+
+// NewCommentStatus parses s into CommentStatus
+func NewCommentStatus(s string) (CommentStatus, error) {
+	switch status := CommentStatus(s); status {
+	case CommentStatusPending, CommentStatusApproved, CommentStatusSpam:
+		return status, nil
+	default:
+		return "", domain.ErrInvalidCommentStatus
+	}
+}
+
+func (s CommentStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,66 @@
+package model
+
+import "sort"
+
+// CommentThread is a comment with its replies
+type CommentThread struct {
+	comment *Comment
+	replies []*CommentThread
+}
+
+// Comment returns the comment
+func (t *CommentThread) Comment() *Comment {
+	return t.comment
+}
+
+// Replies returns replies to the comment from the oldest one
+func (t *CommentThread) Replies() []*CommentThread {
+	return t.replies
+}
+
+// NewCommentThreads builds threads of comments from the oldest one.
+// Replies to comments which are not given are dropped
+func NewCommentThreads(comments []*Comment) []*CommentThread {
+	sorted := make([]*Comment, len(comments), len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt().Before(sorted[j].CreatedAt())
+	})
+
+	children := make(map[CommentID][]*Comment)
+	roots := make([]*Comment, 0)
+	for _, comment := range sorted {
+		if comment.ParentID() == nil {
+			roots = append(roots, comment)
+		} else {
+			children[*comment.ParentID()] = append(children[*comment.ParentID()], comment)
+		}
+	}
+
+	var build func(comments []*Comment) []*CommentThread
+	build = func(comments []*Comment) []*CommentThread {
+		threads := make([]*CommentThread, len(comments), len(comments))
+		for i, comment := range comments {
+			threads[i] = &CommentThread{comment: comment, replies: build(children[*comment.ID()])}
+		}
+		return threads
+	}
+
+	return build(roots)
+}
+
+// CommentWithReplies returns the comment and all replies to it in the comments
+func CommentWithReplies(comments []*Comment, id *CommentID) []*CommentID {
+	children := make(map[CommentID][]*CommentID)
+	for _, comment := range comments {
+		if comment.ParentID() != nil {
+			children[*comment.ParentID()] = append(children[*comment.ParentID()], comment.ID())
+		}
+	}
+
+	ids := []*CommentID{id}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[*ids[i]]...)
+	}
+	return ids
+}
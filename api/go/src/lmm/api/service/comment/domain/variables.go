@@ -0,0 +1,15 @@
+package domain
+
+import "errors"
+
+var (
+	ErrCommentBodyTooLong   = errors.New("comment body too long")
+	ErrCommenterNameTooLong = errors.New("commenter name too long")
+	ErrCommentNotInArticle  = errors.New("comment is not in the article")
+	ErrEmptyCommentBody     = errors.New("empty comment body")
+	ErrEmptyCommenterName   = errors.New("empty commenter name")
+	ErrInvalidCommentID     = errors.New("invalid comment id")
+	ErrInvalidCommentStatus = errors.New("invalid comment status")
+	ErrNoSuchArticle        = errors.New("no such article")
+	ErrNoSuchComment        = errors.New("no such comment")
+)
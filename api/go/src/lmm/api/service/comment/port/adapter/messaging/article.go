@@ -0,0 +1,26 @@
+package messaging
+
+import (
+	"context"
+
+	"lmm/api/messaging"
+	"lmm/api/pkg/pubsub"
+	"lmm/api/service/comment/application"
+	"lmm/api/service/comment/application/command"
+)
+
+type articleDeletedEvent struct {
+	ArticleID string `json:"article_id"`
+}
+
+// NewArticleDeletedHandler deletes the comments on an article when the article service deleted it
+func NewArticleDeletedHandler(app *application.CommentCommandService) messaging.EventHandler {
+	return func(c context.Context, evt messaging.Event) error {
+		var msg articleDeletedEvent
+		if err := pubsub.ScanEvent(evt, &msg); err != nil {
+			return err
+		}
+
+		return app.DeleteArticleComments(c, command.DeleteArticleComments{ArticleID: msg.ArticleID})
+	}
+}
@@ -0,0 +1,71 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"lmm/api/messaging"
+	"lmm/api/service/comment/domain/model"
+)
+
+const (
+	TopicCommentPosted = "CommentPosted"
+)
+
+type commentEventPublisher struct {
+	client messaging.Publisher
+}
+
+func NewCommentEventPublisher(pub messaging.Publisher) model.CommentEventPublisher {
+	return &commentEventPublisher{
+		client: pub,
+	}
+}
+
+type commentPostedEvent struct {
+	CommentID       string    `json:"comment_id"`
+	ParentID        string    `json:"parent_id,omitempty"`
+	ArticleID       string    `json:"article_id"`
+	ArticleAuthorID int64     `json:"article_author_id"`
+	ArticleTitle    string    `json:"article_title"`
+	CommenterID     int64     `json:"commenter_id,omitempty"`
+	CommenterName   string    `json:"commenter_name"`
+	Status          string    `json:"status"`
+	CommentPostedAt time.Time `json:"comment_posted_at"`
+
+	topic       string
+	publishedAt time.Time
+}
+
+func (e *commentPostedEvent) Topic() string {
+	return e.topic
+}
+
+func (e *commentPostedEvent) PublishedAt() time.Time {
+	return e.publishedAt
+}
+
+func (e *commentPostedEvent) Message() interface{} {
+	return e
+}
+
+func (p *commentEventPublisher) NotifyCommentPosted(c context.Context, comment *model.Comment, article *model.Article) error {
+	var parentID string
+	if comment.ParentID() != nil {
+		parentID = comment.ParentID().String()
+	}
+
+	return p.client.Publish(c, &commentPostedEvent{
+		CommentID:       comment.ID().String(),
+		ParentID:        parentID,
+		ArticleID:       article.ID(),
+		ArticleAuthorID: article.AuthorID(),
+		ArticleTitle:    article.Title(),
+		CommenterID:     comment.Commenter().UserID(),
+		CommenterName:   comment.Commenter().Name(),
+		Status:          comment.Status().String(),
+		CommentPostedAt: comment.CreatedAt(),
+		topic:           TopicCommentPosted,
+		publishedAt:     time.Now(),
+	})
+}
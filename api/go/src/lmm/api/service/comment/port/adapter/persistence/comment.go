@@ -0,0 +1,202 @@
+package persistence
+
+import (
+	"context"
+	"sort"
+
+	dsUtil "lmm/api/pkg/datastore"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/comment/domain"
+	"lmm/api/service/comment/domain/model"
+	dsEntity "lmm/api/service/comment/port/adapter/persistence/internal/datastore"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+type CommentDataStore struct {
+	dataStore *datastore.Client
+	transaction.Manager
+}
+
+func NewCommentDataStore(dataStore *datastore.Client) *CommentDataStore {
+	return &CommentDataStore{
+		dataStore: dataStore,
+		Manager:   dsUtil.NewTransactionManager(dataStore),
+	}
+}
+
+// threadKey is the key of the entity group of all comments on the article
+func (s *CommentDataStore) threadKey(articleID string) *datastore.Key {
+	return datastore.NameKey(dsUtil.CommentThreadKind, articleID, nil)
+}
+
+func (s *CommentDataStore) NextID(tx transaction.Transaction, articleID string) (*model.CommentID, error) {
+	key := datastore.IncompleteKey(dsUtil.CommentKind, s.threadKey(articleID))
+	keys, err := s.dataStore.AllocateIDs(tx, []*datastore.Key{key})
+	if err != nil || len(keys) == 0 {
+		return nil, errors.Wrap(err, "failed to allocate new comment key")
+	}
+
+	return model.NewCommentID(keys[0].Encode()), nil
+}
+
+// Save saves comment into datastore
+func (s *CommentDataStore) Save(tx transaction.Transaction, comment *model.Comment) error {
+	commentKey, err := s.commentKey(comment.ID())
+	if err != nil {
+		return err
+	}
+
+	var parentKey *datastore.Key
+	if comment.ParentID() != nil {
+		parentKey, err = s.commentKey(comment.ParentID())
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := dsUtil.MustTransaction(tx).Put(commentKey, &dsEntity.Comment{
+		Parent:      parentKey,
+		UserID:      comment.Commenter().UserID(),
+		Name:        comment.Commenter().Name(),
+		Body:        comment.Body(),
+		Status:      comment.Status().String(),
+		CreatedAt:   comment.CreatedAt(),
+		ModeratedAt: comment.ModeratedAt(),
+	}); err != nil {
+		return errors.Wrap(err, "failed to put comment into datastore")
+	}
+
+	return nil
+}
+
+// Remove deletes comment from datastore
+func (s *CommentDataStore) Remove(tx transaction.Transaction, id *model.CommentID) error {
+	commentKey, err := s.commentKey(id)
+	if err != nil {
+		return err
+	}
+
+	if err := dsUtil.MustTransaction(tx).Delete(commentKey); err != nil {
+		return errors.Wrap(err, "failed to delete comment")
+	}
+
+	return nil
+}
+
+func (s *CommentDataStore) FindByID(tx transaction.Transaction, id *model.CommentID) (*model.Comment, error) {
+	commentKey, err := s.commentKey(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var data dsEntity.Comment
+	if err := dsUtil.MustTransaction(tx).Get(commentKey, &data); err != nil {
+		return nil, errors.Wrap(domain.ErrNoSuchComment, err.Error())
+	}
+
+	return s.comment(commentKey, &data)
+}
+
+// FindByArticle finds all comments on the article by the ancestor in the transaction
+func (s *CommentDataStore) FindByArticle(tx transaction.Transaction, articleID string) ([]*model.Comment, error) {
+	q := datastore.NewQuery(dsUtil.CommentKind).Ancestor(s.threadKey(articleID)).Transaction(dsUtil.MustTransaction(tx))
+
+	var entities []*dsEntity.Comment
+	keys, err := s.dataStore.GetAll(tx, q, &entities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get comments by article")
+	}
+
+	comments, err := s.comments(keys, entities)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt().Before(comments[j].CreatedAt())
+	})
+
+	return comments, nil
+}
+
+// FindByStatus finds comments in the status across articles, which is not transactional
+func (s *CommentDataStore) FindByStatus(tx transaction.Transaction, status model.CommentStatus, count int) ([]*model.Comment, error) {
+	q := datastore.NewQuery(dsUtil.CommentKind).Filter("Status =", status.String()).Order("CreatedAt").Limit(count)
+
+	var entities []*dsEntity.Comment
+	keys, err := s.dataStore.GetAll(tx, q, &entities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get comments by status")
+	}
+
+	return s.comments(keys, entities)
+}
+
+// FindAll finds comments on all articles, used to export comments
+func (s *CommentDataStore) FindAll(c context.Context) ([]*model.Comment, error) {
+	var entities []*dsEntity.Comment
+	keys, err := s.dataStore.GetAll(c, datastore.NewQuery(dsUtil.CommentKind), &entities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get comments")
+	}
+
+	return s.comments(keys, entities)
+}
+
+func (s *CommentDataStore) commentKey(id *model.CommentID) (*datastore.Key, error) {
+	key, err := datastore.DecodeKey(id.String())
+	if err != nil {
+		return nil, errors.Wrapf(domain.ErrNoSuchComment, "%s: %s", err.Error(), id.String())
+	}
+	if key.Kind != dsUtil.CommentKind || key.Parent == nil || key.Parent.Kind != dsUtil.CommentThreadKind {
+		return nil, errors.Wrap(domain.ErrNoSuchComment, id.String())
+	}
+	return key, nil
+}
+
+func (s *CommentDataStore) comments(keys []*datastore.Key, entities []*dsEntity.Comment) ([]*model.Comment, error) {
+	comments := make([]*model.Comment, len(keys), len(keys))
+	for i, key := range keys {
+		comment, err := s.comment(key, entities[i])
+		if err != nil {
+			return nil, err
+		}
+		comments[i] = comment
+	}
+	return comments, nil
+}
+
+func (s *CommentDataStore) comment(key *datastore.Key, data *dsEntity.Comment) (*model.Comment, error) {
+	commenter, err := model.NewCommenter(data.UserID, data.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	status, err := model.NewCommentStatus(data.Status)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	var parentID *model.CommentID
+	if data.Parent != nil {
+		parentID = model.NewCommentID(data.Parent.Encode())
+	}
+
+	comment, err := model.NewComment(
+		model.NewCommentID(key.Encode()),
+		key.Parent.Name,
+		parentID,
+		commenter,
+		data.Body,
+		status,
+		data.CreatedAt,
+		data.ModeratedAt,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	return comment, nil
+}
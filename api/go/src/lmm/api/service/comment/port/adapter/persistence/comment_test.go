@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lmm/api/clock"
+	_ "lmm/api/clock/testing"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/comment/domain"
+	"lmm/api/service/comment/domain/model"
+	"lmm/api/util/uuidutil"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testCommentRepo model.CommentRepository = &CommentDataStore{}
+)
+
+func TestCommentDataStore(t *testing.T) {
+	ctx := context.Background()
+
+	dataStore, err := datastore.NewClient(context.Background(), "")
+	if err != nil {
+		panic(errors.Wrap(err, "failed to connect to datastore"))
+	}
+
+	commentDataStore := NewCommentDataStore(dataStore)
+	articleID := uuidutil.NewUUID()
+
+	commenter, err := model.NewCommenter(0, "anonymous")
+	if err != nil {
+		t.Fatal(errors.Wrap(err, "internal error"))
+	}
+
+	save := func(parentID *model.CommentID) *model.Comment {
+		var comment *model.Comment
+		err := commentDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			id, err := commentDataStore.NextID(tx, articleID)
+			if err != nil {
+				return err
+			}
+
+			comment, err = model.NewComment(id, articleID, parentID, commenter, "body", model.CommentStatusPending, clock.Now(), time.Time{})
+			if err != nil {
+				return err
+			}
+
+			return commentDataStore.Save(tx, comment)
+		}, nil)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return comment
+	}
+
+	comment := save(nil)
+	reply := save(comment.ID())
+
+	t.Run("FindByID", func(t *testing.T) {
+		commentDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			found, err := commentDataStore.FindByID(tx, reply.ID())
+			assert.NoError(t, err)
+			assert.Equal(t, articleID, found.ArticleID())
+			assert.Equal(t, comment.ID(), found.ParentID())
+			assert.Equal(t, "anonymous", found.Commenter().Name())
+			assert.Equal(t, model.CommentStatusPending, found.Status())
+
+			_, err = commentDataStore.FindByID(tx, model.NewCommentID("nothing"))
+			assert.Equal(t, domain.ErrNoSuchComment, errors.Cause(err))
+
+			return nil
+		}, nil)
+	})
+
+	t.Run("FindByArticle", func(t *testing.T) {
+		commentDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			comments, err := commentDataStore.FindByArticle(tx, articleID)
+			assert.NoError(t, err)
+			if assert.Len(t, comments, 2) {
+				assert.Equal(t, comment.ID(), comments[0].ID())
+				assert.Equal(t, reply.ID(), comments[1].ID())
+			}
+			return nil
+		}, nil)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		commentDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			assert.NoError(t, commentDataStore.Remove(tx, reply.ID()))
+			return nil
+		}, nil)
+
+		commentDataStore.RunInTransaction(ctx, func(tx transaction.Transaction) error {
+			_, err := commentDataStore.FindByID(tx, reply.ID())
+			assert.Equal(t, domain.ErrNoSuchComment, errors.Cause(err))
+			return nil
+		}, nil)
+	})
+}
@@ -0,0 +1,17 @@
+package internal
+
+import (
+	"time"
+
+	"cloud.google.com/go/datastore"
+)
+
+type Comment struct {
+	Parent      *datastore.Key `datastore:"Parent,noindex"`
+	UserID      int64          `datastore:"UserID,noindex"`
+	Name        string         `datastore:"Name,noindex"`
+	Body        string         `datastore:"Body,noindex"`
+	Status      string         `datastore:"Status"`
+	CreatedAt   time.Time      `datastore:"CreatedAt"`
+	ModeratedAt time.Time      `datastore:"ModeratedAt,noindex"`
+}
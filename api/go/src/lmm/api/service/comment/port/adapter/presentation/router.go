@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"net/http"
+
+	"lmm/api/pkg/auth"
+	httpUtil "lmm/api/pkg/http"
+	"lmm/api/pkg/http/middleware"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/comment/application"
+	"lmm/api/service/comment/application/command"
+	"lmm/api/service/comment/application/query"
+	"lmm/api/service/comment/domain"
+	"lmm/api/service/comment/domain/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+type GinRouterProvider struct {
+	appService *application.Service
+}
+
+func NewGinRouterProvider(
+	articleService model.ArticleService,
+	commentRepository model.CommentRepository,
+	commentEventPublisher model.CommentEventPublisher,
+	transactionManager transaction.Manager,
+) *GinRouterProvider {
+	appService := application.NewService(
+		application.NewCommentCommandService(articleService, commentRepository, commentEventPublisher, transactionManager),
+		application.NewCommentQueryService(articleService, commentRepository, transactionManager),
+	)
+	return &GinRouterProvider{appService: appService}
+}
+
+func (p *GinRouterProvider) Provide(router *gin.Engine) {
+	router.GET("/v1/articles/:articleID/comments", p.ListArticleComments)
+	router.POST("/v1/articles/:articleID/comments", p.PostNewComment)
+	router.GET("/v1/comments", middleware.RequirePermission(auth.PermissionModerateComments), p.ListComments)
+	router.POST("/v1/comments/:commentID/approve", middleware.RequirePermission(auth.PermissionModerateComments), p.ApproveComment)
+	router.POST("/v1/comments/:commentID/spam", middleware.RequirePermission(auth.PermissionModerateComments), p.MarkCommentAsSpam)
+	router.DELETE("/v1/comments/:commentID", middleware.RequirePermission(auth.PermissionModerateComments), p.DeleteComment)
+}
+
+// ListArticleComments handles GET /v1/articles/:articleID/comments
+func (p *GinRouterProvider) ListArticleComments(c *gin.Context) {
+	threads, err := p.appService.Query().ArticleComments(c, c.Param("articleID"))
+	switch errors.Cause(err) {
+	case nil:
+		c.JSON(http.StatusOK, &commentThreadListAdapter{Comments: commentThreadsToJSON(threads)})
+	case domain.ErrNoSuchArticle:
+		httpUtil.NotFound(c)
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+// PostNewComment handles POST /v1/articles/:articleID/comments,
+// comments are pending until approved unless posted by a moderator or the article author
+func (p *GinRouterProvider) PostNewComment(c *gin.Context) {
+	reqBody := postCommentAdapter{}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	cmd := command.PostComment{
+		ArticleID: c.Param("articleID"),
+		ParentID:  reqBody.ParentID,
+		Name:      reqBody.Name,
+		Body:      reqBody.Body,
+	}
+	if user, ok := httpUtil.AuthFromGinContext(c); ok {
+		cmd.UserID = user.ID
+		cmd.Name = user.Name
+		cmd.Moderator = user.HasPermission(auth.PermissionModerateComments)
+	}
+
+	comment, err := p.appService.Command().PostNewComment(c, cmd)
+	original := errors.Cause(err)
+	switch original {
+	case nil:
+		c.JSON(http.StatusCreated, &commentPostedResponse{ID: comment.ID().String(), Status: comment.Status().String()})
+
+	case domain.ErrEmptyCommentBody, domain.ErrCommentBodyTooLong,
+		domain.ErrEmptyCommenterName, domain.ErrCommenterNameTooLong,
+		domain.ErrNoSuchComment, domain.ErrCommentNotInArticle:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, original.Error())
+
+	case domain.ErrNoSuchArticle:
+		httpUtil.NotFound(c)
+
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+// ListComments handles GET /v1/comments?status=pending, which is the moderation queue
+func (p *GinRouterProvider) ListComments(c *gin.Context) {
+	q := query.ListCommentQuery{}
+	if err := c.ShouldBindQuery(&q); err != nil {
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, q.ValidateErrors(err)[0])
+		return
+	}
+
+	comments, err := p.appService.Query().CommentsByStatus(c, q)
+	original := errors.Cause(err)
+	switch original {
+	case nil:
+	case domain.ErrInvalidCommentStatus:
+		httpUtil.ErrorResponse(c, http.StatusBadRequest, original.Error())
+		return
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+		return
+	}
+
+	items := make([]commentListItem, len(comments), len(comments))
+	for i, comment := range comments {
+		items[i] = commentListItem{
+			ID:        comment.ID().String(),
+			ArticleID: comment.ArticleID(),
+			UserID:    comment.Commenter().UserID(),
+			Name:      comment.Commenter().Name(),
+			Body:      comment.Body(),
+			Status:    comment.Status().String(),
+			CreatedAt: comment.CreatedAt().Unix(),
+		}
+		if comment.ParentID() != nil {
+			items[i].ParentID = comment.ParentID().String()
+		}
+	}
+
+	c.JSON(http.StatusOK, &commentListAdapter{Comments: items})
+}
+
+// ApproveComment handles POST /v1/comments/:commentID/approve
+func (p *GinRouterProvider) ApproveComment(c *gin.Context) {
+	p.moderateComment(c, model.CommentStatusApproved)
+}
+
+// MarkCommentAsSpam handles POST /v1/comments/:commentID/spam
+func (p *GinRouterProvider) MarkCommentAsSpam(c *gin.Context) {
+	p.moderateComment(c, model.CommentStatusSpam)
+}
+
+func (p *GinRouterProvider) moderateComment(c *gin.Context, status model.CommentStatus) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	err := p.appService.Command().ModerateComment(c, command.ModerateComment{
+		UserID:    user.ID,
+		CommentID: c.Param("commentID"),
+		Status:    status.String(),
+	})
+	p.respondCommentModerated(c, err)
+}
+
+// DeleteComment handles DELETE /v1/comments/:commentID, replies to the comment are deleted together
+func (p *GinRouterProvider) DeleteComment(c *gin.Context) {
+	user, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	err := p.appService.Command().DeleteComment(c, command.DeleteComment{
+		UserID:    user.ID,
+		CommentID: c.Param("commentID"),
+	})
+	p.respondCommentModerated(c, err)
+}
+
+func (p *GinRouterProvider) respondCommentModerated(c *gin.Context, err error) {
+	switch errors.Cause(err) {
+	case nil:
+		httpUtil.Response(c, http.StatusOK, "Success")
+	case domain.ErrNoSuchComment:
+		httpUtil.NotFound(c)
+	default:
+		httpUtil.LogPanic(c, "unexpected error", err)
+	}
+}
+
+func commentThreadsToJSON(threads []*model.CommentThread) []commentThreadView {
+	views := make([]commentThreadView, len(threads), len(threads))
+	for i, thread := range threads {
+		comment := thread.Comment()
+		views[i] = commentThreadView{
+			ID:         comment.ID().String(),
+			Name:       comment.Commenter().Name(),
+			Registered: !comment.Commenter().IsAnonymous(),
+			Body:       comment.Body(),
+			CreatedAt:  comment.CreatedAt().Unix(),
+			Replies:    commentThreadsToJSON(thread.Replies()),
+		}
+	}
+	return views
+}
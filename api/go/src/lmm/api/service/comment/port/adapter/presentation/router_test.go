@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"lmm/api/pkg/pubsub/pubsubtest"
+	testUtil "lmm/api/pkg/testing"
+	"lmm/api/service/comment/domain"
+	"lmm/api/service/comment/domain/model"
+	"lmm/api/service/comment/port/adapter/messaging"
+	"lmm/api/service/comment/port/adapter/persistence"
+	"lmm/api/util/uuidutil"
+
+	"cloud.google.com/go/datastore"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	router    *gin.Engine
+	dataStore *datastore.Client
+)
+
+type stubArticleService struct{}
+
+// ArticleByID treats ids starting with "public" as public articles
+func (s *stubArticleService) ArticleByID(c context.Context, id string) (*model.Article, error) {
+	if len(id) < 6 || id[:6] != "public" {
+		return nil, domain.ErrNoSuchArticle
+	}
+	return model.NewArticle(id, 1, "title"), nil
+}
+
+func TestMain(m *testing.M) {
+	var err error
+	dataStore, err = datastore.NewClient(context.Background(), "")
+	if err != nil {
+		panic("failed to connect to datastore: " + err.Error())
+	}
+
+	router = gin.New()
+	router.Use(testUtil.BearerAuth(dataStore))
+
+	pubsubClient := pubsubtest.NewClient()
+
+	repo := persistence.NewCommentDataStore(dataStore)
+	NewGinRouterProvider(
+		&stubArticleService{},
+		repo,
+		messaging.NewCommentEventPublisher(pubsubClient),
+		repo,
+	).Provide(router)
+
+	code := m.Run()
+
+	dataStore.Close()
+	pubsubClient.Close()
+
+	os.Exit(code)
+}
+
+func TestArticleComments(t *testing.T) {
+	c := context.Background()
+
+	user := testUtil.NewUser(c, dataStore)
+	userHeader := http.Header{"Authorization": []string{"Bearer " + user.AccessToken}}
+
+	admin := testUtil.NewUser(c, dataStore)
+	admin.Role = "admin"
+	if _, err := dataStore.Put(c, admin.Key, admin); err != nil {
+		t.Fatal(err)
+	}
+	adminHeader := http.Header{"Authorization": []string{"Bearer " + admin.AccessToken}}
+
+	articleID := "public" + uuidutil.NewUUID()
+
+	postComment := func(header http.Header, body postCommentAdapter) commentPostedResponse {
+		res := request(http.MethodPost, "/v1/articles/"+articleID+"/comments", header, body)
+		if !assert.Equal(t, http.StatusCreated, res.Code) {
+			t.FailNow()
+		}
+
+		var posted commentPostedResponse
+		if err := json.NewDecoder(res.Body).Decode(&posted); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		return posted
+	}
+
+	listComments := func() []commentThreadView {
+		res := request(http.MethodGet, "/v1/articles/"+articleID+"/comments", nil, nil)
+		if !assert.Equal(t, http.StatusOK, res.Code) {
+			t.FailNow()
+		}
+
+		var threads commentThreadListAdapter
+		if err := json.NewDecoder(res.Body).Decode(&threads); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		return threads.Comments
+	}
+
+	userComment := postComment(userHeader, postCommentAdapter{Body: "hello"})
+	assert.Equal(t, "pending", userComment.Status)
+
+	adminComment := postComment(adminHeader, postCommentAdapter{Body: "hello"})
+	assert.Equal(t, "approved", adminComment.Status)
+
+	anonymousComment := postComment(nil, postCommentAdapter{Name: "anonymous", Body: "hi"})
+	assert.Equal(t, "pending", anonymousComment.Status)
+
+	if threads := listComments(); assert.Len(t, threads, 1) {
+		assert.Equal(t, adminComment.ID, threads[0].ID)
+		assert.Equal(t, admin.Name, threads[0].Name)
+		assert.True(t, threads[0].Registered)
+	}
+
+	t.Run("BadRequest", func(t *testing.T) {
+		res := request(http.MethodPost, "/v1/articles/"+articleID+"/comments", nil, postCommentAdapter{Body: "no name"})
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+
+		res = request(http.MethodPost, "/v1/articles/"+articleID+"/comments", userHeader, postCommentAdapter{Body: "reply", ParentID: anonymousComment.ID})
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	t.Run("NoSuchArticle", func(t *testing.T) {
+		res := request(http.MethodPost, "/v1/articles/private/comments", userHeader, postCommentAdapter{Body: "hello"})
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, http.StatusNotFound, request(http.MethodGet, "/v1/articles/private/comments", nil, nil).Code)
+	})
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		for _, route := range []struct{ method, path string }{
+			{http.MethodGet, "/v1/comments"},
+			{http.MethodPost, "/v1/comments/" + anonymousComment.ID + "/approve"},
+			{http.MethodPost, "/v1/comments/" + anonymousComment.ID + "/spam"},
+			{http.MethodDelete, "/v1/comments/" + anonymousComment.ID},
+		} {
+			assert.Equal(t, http.StatusUnauthorized, request(route.method, route.path, nil, nil).Code)
+			assert.Equal(t, http.StatusForbidden, request(route.method, route.path, userHeader, nil).Code)
+		}
+	})
+
+	t.Run("Moderate", func(t *testing.T) {
+		res := request(http.MethodGet, "/v1/comments?status=pending&count=100", adminHeader, nil)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var pending commentListAdapter
+		if err := json.NewDecoder(res.Body).Decode(&pending); err != nil {
+			t.Fatal("invalid json: ", err.Error())
+		}
+		found := 0
+		for _, comment := range pending.Comments {
+			if comment.ID == anonymousComment.ID || comment.ID == userComment.ID {
+				found++
+			}
+		}
+		assert.Equal(t, 2, found)
+
+		res = request(http.MethodPost, "/v1/comments/"+anonymousComment.ID+"/approve", adminHeader, nil)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Len(t, listComments(), 2)
+
+		reply := postComment(adminHeader, postCommentAdapter{Body: "reply", ParentID: anonymousComment.ID})
+		if threads := listComments(); assert.Len(t, threads, 2) && assert.Len(t, threads[1].Replies, 1) {
+			assert.Equal(t, reply.ID, threads[1].Replies[0].ID)
+		}
+
+		res = request(http.MethodDelete, "/v1/comments/"+anonymousComment.ID, adminHeader, nil)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Len(t, listComments(), 1)
+
+		res = request(http.MethodPost, "/v1/comments/"+reply.ID+"/spam", adminHeader, nil)
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
+
+func request(method, path string, header http.Header, body interface{}) *httptest.ResponseRecorder {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to decode to json"))
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
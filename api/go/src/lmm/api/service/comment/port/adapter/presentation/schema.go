@@ -0,0 +1,41 @@
+package ui
+
+type postCommentAdapter struct {
+	// Name is only used if the commenter is anonymous
+	Name     string `json:"name"`
+	Body     string `json:"body"`
+	ParentID string `json:"parent_id"`
+}
+
+type commentPostedResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type commentThreadListAdapter struct {
+	Comments []commentThreadView `json:"comments"`
+}
+
+type commentThreadView struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	Registered bool                `json:"registered"`
+	Body       string              `json:"body"`
+	CreatedAt  int64               `json:"created_at,string"`
+	Replies    []commentThreadView `json:"replies"`
+}
+
+type commentListAdapter struct {
+	Comments []commentListItem `json:"comments"`
+}
+
+type commentListItem struct {
+	ID        string `json:"id"`
+	ArticleID string `json:"article_id"`
+	ParentID  string `json:"parent_id,omitempty"`
+	UserID    int64  `json:"user_id,omitempty"`
+	Name      string `json:"name"`
+	Body      string `json:"body"`
+	Status    string `json:"status"`
+	CreatedAt int64  `json:"created_at,string"`
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	articleApp "lmm/api/service/article/application"
+	articleDomain "lmm/api/service/article/domain"
+	"lmm/api/service/comment/domain"
+	"lmm/api/service/comment/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// ArticleAdapter translates public articles in the article context into the comment context
+type ArticleAdapter struct {
+	articleQuery *articleApp.ArticleQueryService
+}
+
+// NewArticleAdapter creates a model.ArticleService backed by the article query service
+func NewArticleAdapter(articleQuery *articleApp.ArticleQueryService) *ArticleAdapter {
+	return &ArticleAdapter{articleQuery: articleQuery}
+}
+
+// ArticleByID finds the article by its id or link name, only articles readable by anonymous readers are found
+func (s *ArticleAdapter) ArticleByID(c context.Context, id string) (*model.Article, error) {
+	article, err := s.articleQuery.ArticleByID(c, id, 0)
+	switch errors.Cause(err) {
+	case nil:
+	case articleDomain.ErrNoSuchArticle, articleDomain.ErrInvalidArticleID:
+		return nil, errors.Wrap(domain.ErrNoSuchArticle, id)
+	default:
+		return nil, err
+	}
+
+	return model.NewArticle(article.ID().String(), article.Author().ID(), article.Content().Text().Title()), nil
+}
@@ -0,0 +1,160 @@
+package presentation
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httpUtil "lmm/api/pkg/http"
+	"lmm/api/service/sitemap/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	imageNamespace   = "http://www.google.com/schemas/sitemap-image/1.1"
+)
+
+type GinRouterProvider struct {
+	usecase *usecase.Usecase
+	apiURL  string
+	maxAge  time.Duration
+}
+
+// NewGinRouterProvider creates a router provider serving sitemaps,
+// apiURL is used to locate sitemap files from the sitemap index and
+// responses may be cached by clients for maxAge
+func NewGinRouterProvider(app *usecase.Usecase, apiURL string, maxAge time.Duration) *GinRouterProvider {
+	return &GinRouterProvider{usecase: app, apiURL: strings.TrimRight(apiURL, "/"), maxAge: maxAge}
+}
+
+func (p *GinRouterProvider) Provide(router *gin.Engine) {
+	router.GET("/v1/sitemap.xml", p.GetV1Sitemap)
+	router.GET("/v1/sitemaps/:sitemap", p.GetV1SitemapPage)
+}
+
+type urlSet struct {
+	XMLName    xml.Name      `xml:"urlset"`
+	XMLNS      string        `xml:"xmlns,attr"`
+	XMLNSImage string        `xml:"xmlns:image,attr,omitempty"`
+	URLs       []*urlElement `xml:"url"`
+}
+
+type urlElement struct {
+	Loc     string          `xml:"loc"`
+	LastMod string          `xml:"lastmod,omitempty"`
+	Images  []*imageElement `xml:"image:image"`
+}
+
+type imageElement struct {
+	Loc string `xml:"image:loc"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name          `xml:"sitemapindex"`
+	XMLNS    string            `xml:"xmlns,attr"`
+	Sitemaps []*sitemapElement `xml:"sitemap"`
+}
+
+type sitemapElement struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// GetV1Sitemap handles GET /v1/sitemap.xml
+// It responds a sitemap index instead if urls don't fit in a sitemap
+func (p *GinRouterProvider) GetV1Sitemap(c *gin.Context) {
+	sitemap, err := p.usecase.Sitemap(c)
+	if err != nil {
+		httpUtil.LogPanic(c, "unexpected error", err)
+		return
+	}
+
+	if !sitemap.NeedsIndex() {
+		p.respondURLSet(c, sitemap, 1)
+		return
+	}
+
+	index := &sitemapIndex{
+		XMLNS:    sitemapNamespace,
+		Sitemaps: make([]*sitemapElement, sitemap.PageCount(), sitemap.PageCount()),
+	}
+	for i := range index.Sitemaps {
+		index.Sitemaps[i] = &sitemapElement{
+			Loc:     fmt.Sprintf("%s/v1/sitemaps/%d.xml", p.apiURL, i+1),
+			LastMod: formatLastMod(sitemap.LastModified(i + 1)),
+		}
+	}
+
+	p.respondXML(c, sitemap, index)
+}
+
+// GetV1SitemapPage handles GET /v1/sitemaps/:sitemap
+func (p *GinRouterProvider) GetV1SitemapPage(c *gin.Context) {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("sitemap"), ".xml"))
+	if err != nil {
+		httpUtil.NotFound(c)
+		return
+	}
+
+	sitemap, err := p.usecase.Sitemap(c)
+	if err != nil {
+		httpUtil.LogPanic(c, "unexpected error", err)
+		return
+	}
+
+	p.respondURLSet(c, sitemap, page)
+}
+
+func (p *GinRouterProvider) respondURLSet(c *gin.Context, sitemap *usecase.Sitemap, page int) {
+	urls, err := sitemap.Page(page)
+	if err != nil {
+		httpUtil.NotFound(c)
+		return
+	}
+
+	set := &urlSet{
+		XMLNS: sitemapNamespace,
+		URLs:  make([]*urlElement, len(urls), len(urls)),
+	}
+	for i, url := range urls {
+		images := make([]*imageElement, len(url.Images), len(url.Images))
+		for j, image := range url.Images {
+			images[j] = &imageElement{Loc: image}
+		}
+		if len(images) > 0 {
+			set.XMLNSImage = imageNamespace
+		}
+
+		set.URLs[i] = &urlElement{
+			Loc:     url.Loc,
+			LastMod: formatLastMod(url.LastModified),
+			Images:  images,
+		}
+	}
+
+	p.respondXML(c, sitemap, set)
+}
+
+func (p *GinRouterProvider) respondXML(c *gin.Context, sitemap *usecase.Sitemap, v interface{}) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		httpUtil.LogPanic(c, "failed to marshal xml", err)
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(p.maxAge.Seconds())))
+	c.Header("Last-Modified", sitemap.BuiltAt().UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), b...))
+}
+
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
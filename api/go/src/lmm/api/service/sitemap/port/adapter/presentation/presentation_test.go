@@ -0,0 +1,68 @@
+package presentation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	clockTesting "lmm/api/clock/testing"
+	"lmm/api/service/sitemap/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticSource []*usecase.URL
+
+func (s staticSource) URLs(c context.Context) ([]*usecase.URL, error) {
+	return s, nil
+}
+
+func TestGetV1Sitemap(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	router := gin.New()
+	NewGinRouterProvider(usecase.New(clockTesting.NewClock(now), time.Hour, staticSource{
+		{Loc: "https://lmm.local/articles/a", LastModified: now},
+		{Loc: "https://lmm.local/photos", Images: []string{"https://storage.local/photo.jpg"}},
+	}), "https://api.lmm.local", time.Hour).Provide(router)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		return res
+	}
+
+	t.Run("URLSet", func(t *testing.T) {
+		res := get("/v1/sitemap.xml")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "public, max-age=3600", res.Header().Get("Cache-Control"))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+			`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">`+
+			`<url><loc>https://lmm.local/articles/a</loc><lastmod>2020-01-02T03:04:05Z</lastmod></url>`+
+			`<url><loc>https://lmm.local/photos</loc><image:image><image:loc>https://storage.local/photo.jpg</image:loc></image:image></url>`+
+			`</urlset>`, res.Body.String())
+	})
+
+	t.Run("Index", func(t *testing.T) {
+		defer func(max int) { usecase.MaxURLsPerSitemap = max }(usecase.MaxURLsPerSitemap)
+		usecase.MaxURLsPerSitemap = 1
+
+		res := get("/v1/sitemap.xml")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+			`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+			`<sitemap><loc>https://api.lmm.local/v1/sitemaps/1.xml</loc><lastmod>2020-01-02T03:04:05Z</lastmod></sitemap>`+
+			`<sitemap><loc>https://api.lmm.local/v1/sitemaps/2.xml</loc></sitemap>`+
+			`</sitemapindex>`, res.Body.String())
+
+		res = get("/v1/sitemaps/2.xml")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), "<loc>https://lmm.local/photos</loc>")
+
+		assert.Equal(t, http.StatusNotFound, get("/v1/sitemaps/3.xml").Code)
+		assert.Equal(t, http.StatusNotFound, get("/v1/sitemaps/index.xml").Code)
+	})
+}
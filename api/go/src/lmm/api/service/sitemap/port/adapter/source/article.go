@@ -0,0 +1,90 @@
+package source
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"lmm/api/service/article/domain/model"
+	"lmm/api/service/sitemap/usecase"
+
+	"github.com/pkg/errors"
+)
+
+// PublishedArticleFinder finds all published articles
+type PublishedArticleFinder interface {
+	FindAllPublished(c context.Context) ([]*model.Article, error)
+}
+
+// ArticleURLSource lists the article list page, tag listing pages and published article pages
+type ArticleURLSource struct {
+	finder  PublishedArticleFinder
+	siteURL string
+}
+
+// NewArticleURLSource creates an ArticleURLSource listing pages under siteURL
+func NewArticleURLSource(finder PublishedArticleFinder, siteURL string) *ArticleURLSource {
+	return &ArticleURLSource{finder: finder, siteURL: strings.TrimRight(siteURL, "/")}
+}
+
+// URLs implements usecase.URLSource
+func (s *ArticleURLSource) URLs(c context.Context) ([]*usecase.URL, error) {
+	articles, err := s.finder.FindAllPublished(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find published articles")
+	}
+
+	sort.Slice(articles, func(i, j int) bool {
+		return articles[i].CreatedAt().After(articles[j].CreatedAt())
+	})
+
+	var listLastModified time.Time
+	tagLastModified := make(map[string]time.Time)
+	tagNames := make([]string, 0)
+
+	articleURLs := make([]*usecase.URL, 0, len(articles))
+	for _, article := range articles {
+		lastModified := article.LastModified()
+		if lastModified.After(listLastModified) {
+			listLastModified = lastModified
+		}
+
+		for _, tag := range article.Content().Tags() {
+			t, ok := tagLastModified[tag.Name()]
+			if !ok {
+				tagNames = append(tagNames, tag.Name())
+			}
+			if !ok || lastModified.After(t) {
+				tagLastModified[tag.Name()] = lastModified
+			}
+		}
+
+		articleURLs = append(articleURLs, &usecase.URL{
+			Loc:          s.articlePageURL(article),
+			LastModified: lastModified,
+		})
+	}
+
+	sort.Strings(tagNames)
+
+	urls := make([]*usecase.URL, 0, 1+len(tagNames)+len(articleURLs))
+	urls = append(urls, &usecase.URL{Loc: s.siteURL + "/articles", LastModified: listLastModified})
+	for _, name := range tagNames {
+		urls = append(urls, &usecase.URL{
+			Loc:          s.siteURL + "/articles?" + url.Values{"tag": []string{name}}.Encode(),
+			LastModified: tagLastModified[name],
+		})
+	}
+
+	return append(urls, articleURLs...), nil
+}
+
+func (s *ArticleURLSource) articlePageURL(article *model.Article) string {
+	name := article.LinkName()
+	if name == "" {
+		name = article.ID().String()
+	}
+	return s.siteURL + "/articles/" + url.PathEscape(name)
+}
@@ -0,0 +1,54 @@
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lmm/api/service/article/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type publishedArticles []*model.Article
+
+func (articles publishedArticles) FindAllPublished(c context.Context) ([]*model.Article, error) {
+	return articles, nil
+}
+
+func TestArticleURLSource(t *testing.T) {
+	newArticle := func(id string, tags []string, lastModified time.Time) *model.Article {
+		content, err := model.NewContent("title", "body", tags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return model.NewArticle(model.NewArticleID(id), model.NewAuthor(1), content, model.ArticleStatusPublished, 1, lastModified, lastModified, lastModified)
+	}
+
+	t1 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	urls, err := NewArticleURLSource(publishedArticles{
+		newArticle("a", []string{"go", "web"}, t1),
+		newArticle("b", []string{"go"}, t2),
+	}, "https://lmm.local/").URLs(context.Background())
+	assert.NoError(t, err)
+
+	locs := make([]string, len(urls), len(urls))
+	lastModified := make(map[string]time.Time)
+	for i, url := range urls {
+		locs[i] = url.Loc
+		lastModified[url.Loc] = url.LastModified
+	}
+
+	assert.Equal(t, []string{
+		"https://lmm.local/articles",
+		"https://lmm.local/articles?tag=go",
+		"https://lmm.local/articles?tag=web",
+		"https://lmm.local/articles/b",
+		"https://lmm.local/articles/a",
+	}, locs)
+	assert.Equal(t, t2, lastModified["https://lmm.local/articles"])
+	assert.Equal(t, t2, lastModified["https://lmm.local/articles?tag=go"])
+	assert.Equal(t, t1, lastModified["https://lmm.local/articles?tag=web"])
+}
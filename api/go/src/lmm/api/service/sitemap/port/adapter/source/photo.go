@@ -0,0 +1,63 @@
+package source
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	assetUsecase "lmm/api/service/asset/usecase"
+	"lmm/api/service/sitemap/usecase"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	photoPageSize = 100
+
+	// maxImagesPerURL is the limit of images attached to a url in an image sitemap
+	maxImagesPerURL = 1000
+)
+
+// PhotoLister lists photos page by page
+type PhotoLister interface {
+	ListPhotos(c context.Context, countStr, cursor string) ([]*assetUsecase.Photo, string, error)
+}
+
+// PhotoURLSource lists the photo gallery page with its photos as images
+type PhotoURLSource struct {
+	lister  PhotoLister
+	siteURL string
+}
+
+// NewPhotoURLSource creates a PhotoURLSource listing the gallery under siteURL
+func NewPhotoURLSource(lister PhotoLister, siteURL string) *PhotoURLSource {
+	return &PhotoURLSource{lister: lister, siteURL: strings.TrimRight(siteURL, "/")}
+}
+
+// URLs implements usecase.URLSource
+func (s *PhotoURLSource) URLs(c context.Context) ([]*usecase.URL, error) {
+	images := make([]string, 0)
+
+	cursor := ""
+	for len(images) < maxImagesPerURL {
+		photos, next, err := s.lister.ListPhotos(c, strconv.Itoa(photoPageSize), cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list photos")
+		}
+
+		for _, photo := range photos {
+			images = append(images, photo.URL)
+		}
+
+		if len(photos) < photoPageSize || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+
+	if len(images) > maxImagesPerURL {
+		images = images[:maxImagesPerURL]
+	}
+
+	return []*usecase.URL{{Loc: s.siteURL + "/photos", Images: images}}, nil
+}
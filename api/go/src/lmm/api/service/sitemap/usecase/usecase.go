@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"lmm/api/clock"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// MaxURLsPerSitemap is the limit of urls in a sitemap file defined by sitemaps.org
+	MaxURLsPerSitemap = 50000
+
+	ErrNoSuchSitemap = errors.New("no such sitemap")
+)
+
+// URL is a page listed in the sitemap
+type URL struct {
+	Loc          string
+	LastModified time.Time
+	Images       []string
+}
+
+// URLSource provides urls of pages to list in the sitemap
+type URLSource interface {
+	URLs(c context.Context) ([]*URL, error)
+}
+
+// Sitemap is a snapshot of all urls split into sitemap files
+type Sitemap struct {
+	urls    []*URL
+	builtAt time.Time
+}
+
+// PageCount returns the number of sitemap files
+func (s *Sitemap) PageCount() int {
+	if len(s.urls) == 0 {
+		return 1
+	}
+	return (len(s.urls) + MaxURLsPerSitemap - 1) / MaxURLsPerSitemap
+}
+
+// NeedsIndex reports whether urls have to be split by a sitemap index
+func (s *Sitemap) NeedsIndex() bool {
+	return s.PageCount() > 1
+}
+
+// Page returns urls in the nth sitemap file counted from 1
+func (s *Sitemap) Page(n int) ([]*URL, error) {
+	if n < 1 || n > s.PageCount() {
+		return nil, ErrNoSuchSitemap
+	}
+
+	begin := (n - 1) * MaxURLsPerSitemap
+	end := begin + MaxURLsPerSitemap
+	if end > len(s.urls) {
+		end = len(s.urls)
+	}
+	return s.urls[begin:end], nil
+}
+
+// LastModified returns the latest modified time of urls in the nth sitemap file, zero if unknown
+func (s *Sitemap) LastModified(n int) time.Time {
+	urls, err := s.Page(n)
+	if err != nil {
+		return time.Time{}
+	}
+
+	var lastModified time.Time
+	for _, url := range urls {
+		if url.LastModified.After(lastModified) {
+			lastModified = url.LastModified
+		}
+	}
+	return lastModified
+}
+
+// BuiltAt returns when the sitemap is built
+func (s *Sitemap) BuiltAt() time.Time {
+	return s.builtAt
+}
+
+// Usecase builds the sitemap from sources and keeps it for ttl
+// so that crawlers don't make us scan all sources on every request
+type Usecase struct {
+	mutex   sync.Mutex
+	clock   clock.Clock
+	ttl     time.Duration
+	sources []URLSource
+	cache   *Sitemap
+}
+
+func New(clock clock.Clock, ttl time.Duration, sources ...URLSource) *Usecase {
+	return &Usecase{
+		clock:   clock,
+		ttl:     ttl,
+		sources: sources,
+	}
+}
+
+// Sitemap returns the cached sitemap, which is rebuilt if expired
+func (uc *Usecase) Sitemap(c context.Context) (*Sitemap, error) {
+	uc.mutex.Lock()
+	defer uc.mutex.Unlock()
+
+	now := uc.clock.Now()
+	if uc.cache != nil && now.Before(uc.cache.builtAt.Add(uc.ttl)) {
+		return uc.cache, nil
+	}
+
+	urls := make([]*URL, 0)
+	for _, source := range uc.sources {
+		sourceURLs, err := source.URLs(c)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build sitemap")
+		}
+		urls = append(urls, sourceURLs...)
+	}
+
+	uc.cache = &Sitemap{urls: urls, builtAt: now}
+
+	return uc.cache, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	clockTesting "lmm/api/clock/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingSource struct {
+	urls  []*URL
+	calls int
+}
+
+func (s *countingSource) URLs(c context.Context) ([]*URL, error) {
+	s.calls++
+	return s.urls, nil
+}
+
+func TestSitemap(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clockTesting.NewClock(now)
+
+	source := &countingSource{}
+	for i := 0; i < 5; i++ {
+		source.urls = append(source.urls, &URL{
+			Loc:          fmt.Sprintf("https://lmm.local/articles/%d", i),
+			LastModified: now.Add(time.Duration(i) * time.Hour),
+		})
+	}
+
+	uc := New(clock, time.Hour, source)
+
+	t.Run("Cached", func(t *testing.T) {
+		sitemap, err := uc.Sitemap(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, sitemap.NeedsIndex())
+
+		clock.Set(now.Add(59 * time.Minute))
+		_, err = uc.Sitemap(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, source.calls)
+
+		clock.Set(now.Add(time.Hour))
+		_, err = uc.Sitemap(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, source.calls)
+	})
+
+	t.Run("Split", func(t *testing.T) {
+		defer func(max int) { MaxURLsPerSitemap = max }(MaxURLsPerSitemap)
+		MaxURLsPerSitemap = 2
+
+		sitemap, err := uc.Sitemap(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, sitemap.NeedsIndex())
+		assert.Equal(t, 3, sitemap.PageCount())
+
+		urls, err := sitemap.Page(3)
+		assert.NoError(t, err)
+		assert.Len(t, urls, 1)
+		assert.Equal(t, now.Add(3*time.Hour), sitemap.LastModified(2))
+
+		_, err = sitemap.Page(4)
+		assert.Equal(t, ErrNoSuchSitemap, err)
+		_, err = sitemap.Page(0)
+		assert.Equal(t, ErrNoSuchSitemap, err)
+	})
+}
@@ -2,7 +2,10 @@ package application
 
 import (
 	"context"
+	"sort"
+	"strconv"
 
+	"lmm/api/clock"
 	authUtil "lmm/api/pkg/auth"
 	"lmm/api/pkg/transaction"
 	"lmm/api/service/user/application/command"
@@ -20,6 +23,7 @@ type Service struct {
 	tokenService       model.TokenService
 	transactionManager transaction.Manager
 	userRepository     model.UserRepository
+	sessionRepository  model.SessionRepository
 	userEventPublisher model.UserEventPublisher
 }
 
@@ -29,6 +33,7 @@ func NewService(
 	tokenService model.TokenService,
 	txManager transaction.Manager,
 	userRepository model.UserRepository,
+	sessionRepository model.SessionRepository,
 	userEventPublisher model.UserEventPublisher,
 ) *Service {
 	return &Service{
@@ -37,6 +42,7 @@ func NewService(
 		tokenService:       tokenService,
 		transactionManager: txManager,
 		userRepository:     userRepository,
+		sessionRepository:  sessionRepository,
 		userEventPublisher: userEventPublisher,
 	}
 }
@@ -78,49 +84,55 @@ func (s *Service) RegisterNewUser(c context.Context, cmd command.Register) (int6
 	return userID, nil
 }
 
-// BasicAuth authenticate user by basic auth
+// BasicAuth authenticate user by basic auth, which starts a new session of the device.
+// Expired sessions of the user are removed meanwhile
 func (s *Service) BasicAuth(c context.Context, cmd command.Login) (auth *authUtil.Auth, err error) {
-	err = s.transactionManager.RunInTransaction(c,
-		func(tx transaction.Transaction) error {
-			user, err := s.login(tx, cmd.UserName, cmd.Password)
-			if err != nil {
-				return errors.Wrap(err, "failed to login")
-			}
+	err = s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		user, err := s.login(tx, cmd.UserName, cmd.Password)
+		if err != nil {
+			return errors.Wrap(err, "failed to login")
+		}
 
-			accessToken, err := s.tokenService.Encrypt(user.Token())
-			if err != nil {
-				return errors.Wrap(err, "internal error: faile to encrypt user token")
-			}
+		if err := s.removeExpiredSessions(tx, user.ID()); err != nil {
+			return err
+		}
 
-			auth = &authUtil.Auth{
-				ID:    int64(user.ID()),
-				Name:  user.Name(),
-				Role:  user.Role().Name(),
-				Token: accessToken.Hashed(),
-			}
+		session, err := s.factory.NewSession(user.ID(), cmd.UserAgent, cmd.IP)
+		if err != nil {
+			return errors.Wrap(err, "internal error: failed to create session")
+		}
 
-			return nil
-		},
-		&transaction.Option{ReadOnly: true},
-	)
+		accessToken, err := s.tokenService.Encrypt(user, session.ID())
+		if err != nil {
+			return errors.Wrap(err, "internal error: faile to encrypt user token")
+		}
+
+		session.Extend(accessToken)
+		if err := s.sessionRepository.Save(tx, session); err != nil {
+			return errors.Wrap(err, "failed to save session")
+		}
+
+		auth = &authUtil.Auth{
+			ID:        int64(user.ID()),
+			Name:      user.Name(),
+			Role:      user.Role().Name(),
+			Token:     accessToken.Hashed(),
+			SessionID: string(session.ID()),
+		}
+
+		return nil
+	}, nil)
 	return
 }
 
-// BearerAuth authenticate user by bearer auth
+// BearerAuth authenticate user by bearer auth, tokens of revoked sessions are rejected
 func (s *Service) BearerAuth(c context.Context, hashed string) (auth *authUtil.Auth, err error) {
-	token, err := s.tokenService.Decrypt(hashed)
-	if err != nil {
-		return nil, errors.Wrap(err, "invalid access token")
-	}
-
-	if token.Expired() {
-		return nil, errors.New("access token expired")
-	}
+	var session *model.Session
 
 	err = s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
-		user, err := s.userRepository.FindByToken(tx, token.Raw())
+		user, token, err := s.findUserByAccessToken(tx, hashed)
 		if err != nil {
-			return errors.Wrap(err, "failed to find user by token")
+			return err
 		}
 
 		auth = &authUtil.Auth{
@@ -130,37 +142,177 @@ func (s *Service) BearerAuth(c context.Context, hashed string) (auth *authUtil.A
 			Token: user.Token(), // note that this is the raw token instead of the hashed one
 		}
 
+		// access tokens issued before sessions are accepted by the user token alone,
+		// until the legacy token key is unset and they are never decrypted
+		if token.UserID() == 0 && token.SessionID() == "" {
+			return nil
+		}
+
+		session, err = s.findSession(tx, user, token)
+		if err != nil {
+			return err
+		}
+		auth.SessionID = string(session.ID())
+
 		return nil
 	}, &transaction.Option{ReadOnly: true})
-	return
-}
 
-// RefreshAccessToken refreshes a valid oldAccessToken into a valid newAccessToken
-func (s *Service) RefreshAccessToken(c context.Context, hashed string) (newAccessToken *model.AccessToken, err error) {
-	token, err := s.tokenService.Decrypt(hashed)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid access token")
+		return nil, err
 	}
 
-	if token.Expired() {
-		return nil, errors.New("access token expired")
+	if session != nil && session.Touch(clock.Now()) {
+		// the last seen time is only informative, failing to record it should not reject the request
+		s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+			if _, err := s.sessionRepository.FindByID(tx, session.UserID(), session.ID()); err != nil {
+				return err
+			}
+			return s.sessionRepository.Save(tx, session)
+		}, nil)
 	}
 
+	return auth, nil
+}
+
+// RefreshAccessToken refreshes a valid oldAccessToken into a valid newAccessToken bound to the same session,
+// which is kept until the new one expires
+func (s *Service) RefreshAccessToken(c context.Context, hashed string) (newAccessToken *model.AccessToken, err error) {
 	err = s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
-		user, err := s.userRepository.FindByToken(tx, token.Raw())
+		user, session, err := s.findSessionByAccessToken(tx, hashed)
 		if err != nil {
 			return err
 		}
-		newAccessToken, err = s.tokenService.Encrypt(user.Token())
+		newAccessToken, err = s.tokenService.Encrypt(user, session.ID())
 		if err != nil {
 			panic(errors.Wrap(err, "internal error"))
 		}
-		return nil
-	}, &transaction.Option{ReadOnly: true})
+
+		session.Extend(newAccessToken)
+		session.Touch(clock.Now())
+		return s.sessionRepository.Save(tx, session)
+	}, nil)
 
 	return
 }
 
+// findSessionByAccessToken finds the user and the session which the valid access token is bound to
+func (s *Service) findSessionByAccessToken(tx transaction.Transaction, hashed string) (*model.User, *model.Session, error) {
+	user, token, err := s.findUserByAccessToken(tx, hashed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session, err := s.findSession(tx, user, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, session, nil
+}
+
+// findUserByAccessToken finds the user who the valid access token is issued to
+func (s *Service) findUserByAccessToken(tx transaction.Transaction, hashed string) (*model.User, *model.AccessToken, error) {
+	token, err := s.tokenService.Decrypt(hashed)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid access token")
+	}
+
+	if token.Expired() {
+		return nil, nil, errors.New("access token expired")
+	}
+
+	var user *model.User
+	if token.UserID() != 0 {
+		user, err = s.userRepository.FindByID(tx, token.UserID())
+	} else {
+		// access tokens issued before JWT are bound to the user token
+		user, err = s.userRepository.FindByToken(tx, token.Raw())
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to find user by token")
+	}
+
+	return user, token, nil
+}
+
+// findSession finds the session of the user which the access token is bound to
+func (s *Service) findSession(tx transaction.Transaction, user *model.User, token *model.AccessToken) (*model.Session, error) {
+	if token.SessionID() == "" {
+		return nil, errors.Wrap(domain.ErrNoSuchSession, "access token bound to no session")
+	}
+
+	session, err := s.sessionRepository.FindByID(tx, user.ID(), token.SessionID())
+	if err != nil {
+		return nil, errors.Wrap(err, "session revoked")
+	}
+
+	return session, nil
+}
+
+// UserSessions lists unexpired sessions of the user, recently used ones first
+func (s *Service) UserSessions(c context.Context, userID int64) ([]*model.Session, error) {
+	var found []*model.Session
+	err := s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) (err error) {
+		found, err = s.sessionRepository.FindByUser(tx, model.UserID(userID))
+		return err
+	}, &transaction.Option{ReadOnly: true})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find sessions")
+	}
+
+	now := clock.Now()
+	sessions := make([]*model.Session, 0, len(found))
+	for _, session := range found {
+		if !session.Expired(now) {
+			sessions = append(sessions, session)
+		}
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt().After(sessions[j].LastSeenAt())
+	})
+	return sessions, nil
+}
+
+// removeExpiredSessions removes sessions of the user which no valid access token is bound to
+func (s *Service) removeExpiredSessions(tx transaction.Transaction, userID model.UserID) error {
+	sessions, err := s.sessionRepository.FindByUser(tx, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to find sessions")
+	}
+
+	now := clock.Now()
+	for _, session := range sessions {
+		if !session.Expired(now) {
+			continue
+		}
+		if err := s.sessionRepository.Remove(tx, userID, session.ID()); err != nil {
+			return errors.Wrap(err, "failed to remove expired session")
+		}
+	}
+	return nil
+}
+
+// RevokeSession logs the user out of the session, access tokens bound to it are rejected then
+func (s *Service) RevokeSession(c context.Context, cmd command.RevokeSession) error {
+	userID, sessionID := model.UserID(cmd.UserID), model.SessionID(cmd.SessionID)
+
+	return s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		if _, err := s.sessionRepository.FindByID(tx, userID, sessionID); err != nil {
+			return errors.Wrap(err, "failed to find session")
+		}
+		return s.sessionRepository.Remove(tx, userID, sessionID)
+	}, nil)
+}
+
+// RevokeAllSessions logs the user out everywhere
+func (s *Service) RevokeAllSessions(c context.Context, userID int64) error {
+	return s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		return s.sessionRepository.RemoveAll(tx, model.UserID(userID))
+	}, nil)
+}
+
 func (s *Service) login(tx transaction.Transaction, username, password string) (*model.User, error) {
 	user, err := s.userRepository.FindByName(tx, username)
 	if err != nil {
@@ -176,13 +328,172 @@ func (s *Service) login(tx transaction.Transaction, username, password string) (
 
 // AssignRole handles command which operator assign user to role
 func (s *Service) AssignRole(c context.Context, cmd command.AssignRole) error {
-	panic("not implemented")
+	if cmd.OperatorUser == cmd.TargetUser {
+		return domain.ErrCannotAssignSelfRole
+	}
+
+	role := model.RoleFromString(cmd.TargetRole)
+	if role.Name() != cmd.TargetRole {
+		return errors.Wrap(domain.ErrNoSuchRole, cmd.TargetRole)
+	}
+
+	// roles demanding no permission are not assignable, otherwise anyone could assign them
+	permission := model.PermissionAssignToRole(role)
+	if permission == model.NoPermission {
+		return errors.Wrap(domain.ErrNoSuchRole, cmd.TargetRole)
+	}
+
+	return s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		operator, err := s.userRepository.FindByName(tx, cmd.OperatorUser)
+		if err != nil {
+			return errors.Wrap(domain.ErrNoPermission, err.Error())
+		}
+
+		if !operator.Role().HasPermission(permission) {
+			return errors.Wrapf(domain.ErrNoPermission, "%s can not assign users to %s", operator.Role().Name(), role.Name())
+		}
+
+		target, err := s.userRepository.FindByName(tx, cmd.TargetUser)
+		if err != nil {
+			return errors.Wrap(err, "failed to find target user")
+		}
+
+		if target.Role() == role {
+			return nil
+		}
+
+		// operator should be able to assign to the current role of the target as well,
+		// otherwise one could demote users who have higher roles
+		if !operator.Role().HasPermission(model.PermissionAssignToRole(target.Role())) {
+			return errors.Wrapf(domain.ErrNoPermission, "%s can not change the role of %s", operator.Role().Name(), target.Role().Name())
+		}
+
+		if err := target.ChangeRole(role); err != nil {
+			return errors.Wrap(err, "failed to change role")
+		}
+
+		if err := s.userRepository.Save(tx, target); err != nil {
+			return errors.Wrap(err, "failed to save user after role changed")
+		}
+
+		if err := s.userEventPublisher.NotifyUserRoleChanged(c, target.ID(), role); err != nil {
+			return errors.Wrap(err, "failed to notify user role changed")
+		}
+
+		return nil
+	}, nil)
 }
 
 const maxCount uint = 100
 
+// ViewAllUsersByOptions lists users on the page in the order, with the total number of users
 func (s *Service) ViewAllUsersByOptions(c context.Context, query query.ViewAllUsers) ([]*model.UserDescriptor, uint, error) {
-	panic("not implemented")
+	options, err := userListOptions(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		users []*model.UserDescriptor
+		total uint
+	)
+	err = s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		users, total, err = s.userRepository.DescribeAll(tx, options)
+		return err
+	}, &transaction.Option{ReadOnly: true})
+
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to view users")
+	}
+	return users, total, nil
+}
+
+func userListOptions(query query.ViewAllUsers) (model.UserListOptions, error) {
+	options := model.UserListOptions{}
+
+	page, err := strconv.ParseUint(query.Page, 10, 32)
+	if err != nil || page == 0 {
+		return options, errors.Wrap(domain.ErrInvalidPage, query.Page)
+	}
+	options.Page = uint(page)
+
+	count, err := strconv.ParseUint(query.Count, 10, 32)
+	if err != nil || count == 0 || uint(count) > maxCount {
+		return options, errors.Wrap(domain.ErrInvalidCount, query.Count)
+	}
+	options.Count = uint(count)
+
+	switch orderBy := model.UserListOrderBy(query.OrderBy); orderBy {
+	case model.UserListOrderByName, model.UserListOrderByRegisteredAt:
+		options.OrderBy = orderBy
+	default:
+		return options, errors.Wrap(domain.ErrInvalidViewOrder, query.OrderBy)
+	}
+
+	switch query.Order {
+	case "asc":
+	case "desc":
+		options.Desc = true
+	default:
+		return options, errors.Wrap(domain.ErrInvalidViewOrder, query.Order)
+	}
+
+	return options, nil
+}
+
+// ChangeUserProfile changes the author profile of the user
+func (s *Service) ChangeUserProfile(c context.Context, cmd command.ChangeProfile) error {
+	profile, err := model.NewProfile(cmd.DisplayName, cmd.Bio, cmd.AvatarAssetID)
+	if err != nil {
+		return errors.Wrap(err, "invalid profile")
+	}
+
+	return s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		user, err := s.userRepository.FindByName(tx, cmd.User)
+		if err != nil {
+			return errors.Wrap(err, "failed to find user")
+		}
+
+		user.ChangeProfile(profile)
+
+		if err := s.userRepository.Save(tx, user); err != nil {
+			return errors.Wrap(err, "failed to save user after profile changed")
+		}
+
+		return nil
+	}, nil)
+}
+
+// UserDescriptorByName views the user who has the given name
+func (s *Service) UserDescriptorByName(c context.Context, name string) (descriptor *model.UserDescriptor, err error) {
+	err = s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		user, err := s.userRepository.FindByName(tx, name)
+		if err != nil {
+			return err
+		}
+		descriptor = &user.UserDescriptor
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+	return
+}
+
+// UserDescriptorsByIDs views users by their ids, ids which no user has are skipped
+func (s *Service) UserDescriptorsByIDs(c context.Context, ids []int64) (descriptors []*model.UserDescriptor, err error) {
+	err = s.transactionManager.RunInTransaction(c, func(tx transaction.Transaction) error {
+		descriptors = make([]*model.UserDescriptor, 0, len(ids))
+		for _, id := range ids {
+			user, err := s.userRepository.FindByID(tx, model.UserID(id))
+			if err == domain.ErrNoSuchUser {
+				continue
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to find user: %d", id)
+			}
+			descriptors = append(descriptors, &user.UserDescriptor)
+		}
+		return nil
+	}, &transaction.Option{ReadOnly: true})
+	return
 }
 
 // UserChangePassword supports a application to chagne user's password
@@ -210,6 +521,11 @@ func (s *Service) UserChangePassword(c context.Context, cmd command.ChangePasswo
 			return errors.Wrap(err, "failed to save user after password and token changed")
 		}
 
+		// access tokens have been invalidated by the new token, so their sessions are useless
+		if err := s.sessionRepository.RemoveAll(tx, user.ID()); err != nil {
+			return errors.Wrap(err, "failed to remove sessions after password changed")
+		}
+
 		if err := s.userEventPublisher.NotifyUserPasswordChanged(c, user.ID()); err != nil {
 			return errors.Wrap(err, "failed to notify user password changed")
 		}
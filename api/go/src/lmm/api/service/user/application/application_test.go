@@ -2,16 +2,21 @@ package application
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"lmm/api/clock"
+	authUtil "lmm/api/pkg/auth"
 	"lmm/api/pkg/pubsub/pubsubtest"
 	testUtil "lmm/api/pkg/testing"
 	"lmm/api/pkg/transaction"
 	"lmm/api/service/user/application/command"
+	"lmm/api/service/user/application/query"
 	"lmm/api/service/user/domain"
 	"lmm/api/service/user/domain/model"
 	"lmm/api/service/user/port/adapter/messaging"
@@ -25,7 +30,8 @@ import (
 )
 
 var (
-	testAppService *Service
+	testAppService        *Service
+	testSessionRepository *InmemorySessionRepository
 )
 
 type InmemoryUserRepository struct {
@@ -48,6 +54,17 @@ func (repo *InmemoryUserRepository) Save(tx transaction.Transaction, user *model
 	return nil
 }
 
+func (repo *InmemoryUserRepository) FindByID(tx transaction.Transaction, id model.UserID) (*model.User, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	user, ok := repo.memory[id]
+	if !ok {
+		return nil, domain.ErrNoSuchUser
+	}
+	return user, nil
+}
+
 func (repo *InmemoryUserRepository) FindByName(tx transaction.Transaction, username string) (*model.User, error) {
 	repo.RLock()
 	defer repo.RUnlock()
@@ -72,6 +89,98 @@ func (repo *InmemoryUserRepository) FindByToken(tx transaction.Transaction, toke
 	return nil, domain.ErrNoSuchUser
 }
 
+func (repo *InmemoryUserRepository) DescribeAll(tx transaction.Transaction, options model.UserListOptions) ([]*model.UserDescriptor, uint, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	users := make([]*model.UserDescriptor, 0, len(repo.memory))
+	for _, user := range repo.memory {
+		users = append(users, &user.UserDescriptor)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		a, b := users[i], users[j]
+		if options.Desc {
+			a, b = b, a
+		}
+		if options.OrderBy == model.UserListOrderByRegisteredAt {
+			return a.RegisteredAt().Before(b.RegisteredAt())
+		}
+		return a.Name() < b.Name()
+	})
+
+	total := uint(len(users))
+	begin := (options.Page - 1) * options.Count
+	if begin > total {
+		begin = total
+	}
+	end := begin + options.Count
+	if end > total {
+		end = total
+	}
+
+	return users[begin:end], total, nil
+}
+
+type InmemorySessionRepository struct {
+	sync.RWMutex
+	memory map[model.SessionID]*model.Session
+}
+
+func (repo *InmemorySessionRepository) Save(tx transaction.Transaction, session *model.Session) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	repo.memory[session.ID()] = session
+	return nil
+}
+
+func (repo *InmemorySessionRepository) Remove(tx transaction.Transaction, userID model.UserID, id model.SessionID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	if session, ok := repo.memory[id]; ok && session.UserID() == userID {
+		delete(repo.memory, id)
+	}
+	return nil
+}
+
+func (repo *InmemorySessionRepository) RemoveAll(tx transaction.Transaction, userID model.UserID) error {
+	repo.Lock()
+	defer repo.Unlock()
+
+	for id, session := range repo.memory {
+		if session.UserID() == userID {
+			delete(repo.memory, id)
+		}
+	}
+	return nil
+}
+
+func (repo *InmemorySessionRepository) FindByID(tx transaction.Transaction, userID model.UserID, id model.SessionID) (*model.Session, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	session, ok := repo.memory[id]
+	if !ok || session.UserID() != userID {
+		return nil, domain.ErrNoSuchSession
+	}
+	return session, nil
+}
+
+func (repo *InmemorySessionRepository) FindByUser(tx transaction.Transaction, userID model.UserID) ([]*model.Session, error) {
+	repo.RLock()
+	defer repo.RUnlock()
+
+	sessions := make([]*model.Session, 0)
+	for _, session := range repo.memory {
+		if session.UserID() == userID {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
 func (repo *InmemoryUserRepository) Begin(c context.Context, opts *transaction.Option) (transaction.Transaction, error) {
 	return transaction.Nop(), nil
 }
@@ -87,13 +196,16 @@ func (repo *InmemoryUserRepository) RunInTransaction(c context.Context, f func(t
 }
 
 func TestMain(m *testing.M) {
+	testSessionRepository = &InmemorySessionRepository{memory: make(map[model.SessionID]*model.Session)}
 	repo := &InmemoryUserRepository{memory: make(map[model.UserID]*model.User)}
 	pubsubClient := pubsubtest.NewClient()
 	pub := messaging.NewUserEventPublisher(pubsubClient)
 	testAppService = NewService(
 		&service.BcryptService{},
 		testUtil.TokenService,
-		repo, repo, pub)
+		repo, repo,
+		testSessionRepository,
+		pub)
 	code := m.Run()
 	pubsubClient.Close()
 	os.Exit(code)
@@ -206,6 +318,386 @@ func TestUserChangePassword(t *testing.T) {
 	assert.NotEqual(t, oldToken, userAfterPasswordChanging.Token())
 }
 
+func TestSessions(t *testing.T) {
+	c := context.Background()
+
+	username, password := "U"+uuidutil.NewUUID()[:8], "U$ErP@ssw0rD"
+	userID, err := testAppService.RegisterNewUser(c, command.Register{
+		UserName:     username,
+		EmailAddress: username + "@lmm.local",
+		Password:     password,
+	})
+	if !assert.NoError(t, err) {
+		t.Fatal("failed to create new user")
+	}
+
+	login := func(userAgent string) *authUtil.Auth {
+		auth, err := testAppService.BasicAuth(c, command.Login{
+			UserName:  username,
+			Password:  password,
+			UserAgent: userAgent,
+			IP:        "127.0.0.1",
+		})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		return auth
+	}
+
+	t.Run("BoundToSession", func(t *testing.T) {
+		auth := login("Mozilla/5.0")
+		assert.NotEmpty(t, auth.SessionID)
+
+		bearer, err := testAppService.BearerAuth(c, auth.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, auth.SessionID, bearer.SessionID)
+
+		newToken, err := testAppService.RefreshAccessToken(c, auth.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, model.SessionID(auth.SessionID), newToken.SessionID())
+
+		sessions, err := testAppService.UserSessions(c, userID)
+		assert.NoError(t, err)
+		if assert.Len(t, sessions, 1) {
+			assert.Equal(t, "Mozilla/5.0", sessions[0].UserAgent())
+			assert.Equal(t, "127.0.0.1", sessions[0].IP())
+		}
+	})
+
+	t.Run("RevokeSession", func(t *testing.T) {
+		auth := login("curl/7.64.1")
+		other := login("Wget/1.20.3")
+
+		assert.NoError(t, testAppService.RevokeSession(c, command.RevokeSession{UserID: userID, SessionID: auth.SessionID}))
+
+		_, err := testAppService.BearerAuth(c, auth.Token)
+		assert.Equal(t, domain.ErrNoSuchSession, errors.Cause(err))
+
+		_, err = testAppService.RefreshAccessToken(c, auth.Token)
+		assert.Equal(t, domain.ErrNoSuchSession, errors.Cause(err))
+
+		_, err = testAppService.BearerAuth(c, other.Token)
+		assert.NoError(t, err)
+
+		err = testAppService.RevokeSession(c, command.RevokeSession{UserID: userID, SessionID: auth.SessionID})
+		assert.Equal(t, domain.ErrNoSuchSession, errors.Cause(err))
+
+		err = testAppService.RevokeSession(c, command.RevokeSession{UserID: userID, SessionID: "session"})
+		assert.Equal(t, domain.ErrNoSuchSession, errors.Cause(err))
+	})
+
+	t.Run("LegacyToken", func(t *testing.T) {
+		legacyKey := uuidutil.NewUUID()[:32]
+		key, err := service.NewJWTKey("legacy", "HS256", []byte(uuidutil.NewUUID()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokenService, err := service.NewJWTTokenService([]*service.JWTKey{key}, time.Minute, legacyKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		app := NewService(&service.BcryptService{}, tokenService, testAppService.transactionManager, testAppService.userRepository,
+			testSessionRepository, testAppService.userEventPublisher)
+
+		user, err := testAppService.userRepository.FindByID(nil, model.UserID(userID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		legacy, err := service.NewCFBTokenService(legacyKey, time.Minute).Encrypt(user, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		auth, err := app.BearerAuth(c, legacy.Hashed())
+		if assert.NoError(t, err) {
+			assert.Equal(t, userID, auth.ID)
+			assert.Empty(t, auth.SessionID)
+		}
+
+		_, err = app.RefreshAccessToken(c, legacy.Hashed())
+		assert.Equal(t, domain.ErrNoSuchSession, errors.Cause(err))
+
+		_, err = testAppService.BearerAuth(c, legacy.Hashed())
+		assert.Error(t, err)
+	})
+
+	t.Run("ExpiredSessions", func(t *testing.T) {
+		past := clock.Now().Add(-48 * time.Hour)
+		expired, err := model.NewSession(model.SessionID(uuidutil.NewUUID()), model.UserID(userID), "curl/7.64.1", "127.0.0.1", past, past, past.Add(24*time.Hour))
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.NoError(t, testSessionRepository.Save(transaction.Nop(), expired))
+
+		sessions, err := testAppService.UserSessions(c, userID)
+		assert.NoError(t, err)
+		for _, session := range sessions {
+			assert.NotEqual(t, expired.ID(), session.ID())
+		}
+
+		login("curl/7.64.1")
+
+		_, err = testSessionRepository.FindByID(transaction.Nop(), model.UserID(userID), expired.ID())
+		assert.Equal(t, domain.ErrNoSuchSession, errors.Cause(err))
+	})
+
+	t.Run("RevokeAllSessions", func(t *testing.T) {
+		auth := login("curl/7.64.1")
+
+		assert.NoError(t, testAppService.RevokeAllSessions(c, userID))
+
+		_, err := testAppService.BearerAuth(c, auth.Token)
+		assert.Equal(t, domain.ErrNoSuchSession, errors.Cause(err))
+
+		sessions, err := testAppService.UserSessions(c, userID)
+		assert.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("ChangePassword", func(t *testing.T) {
+		auth := login("curl/7.64.1")
+
+		newPassword := "N3w" + password
+		assert.NoError(t, testAppService.UserChangePassword(c, command.ChangePassword{
+			User:        username,
+			OldPassword: password,
+			NewPassword: newPassword,
+		}))
+		password = newPassword
+
+		_, err := testAppService.BearerAuth(c, auth.Token)
+		assert.Error(t, err)
+
+		sessions, err := testAppService.UserSessions(c, userID)
+		assert.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
+
+func TestChangeUserProfile(t *testing.T) {
+	c := context.Background()
+
+	username := "U" + uuidutil.NewUUID()[:8]
+	userID, err := testAppService.RegisterNewUser(c, command.Register{
+		UserName:     username,
+		EmailAddress: username + "@lmm.local",
+		Password:     "U$ErP@ssw0rD",
+	})
+	if !assert.NoError(t, err) || !assert.NotZero(t, userID) {
+		t.Fatal("failed to create new user")
+	}
+
+	descriptor, err := testAppService.UserDescriptorByName(c, username)
+	assert.NoError(t, err)
+	assert.Equal(t, username, descriptor.DisplayName())
+
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, testAppService.ChangeUserProfile(c, command.ChangeProfile{
+			User:          username,
+			DisplayName:   " Writer ",
+			Bio:           "writes things",
+			AvatarAssetID: "avatar.png",
+		}))
+
+		descriptors, err := testAppService.UserDescriptorsByIDs(c, []int64{userID, userID + 10000})
+		assert.NoError(t, err)
+		if assert.Len(t, descriptors, 1) {
+			assert.Equal(t, username, descriptors[0].Name())
+			assert.Equal(t, "Writer", descriptors[0].DisplayName())
+			assert.Equal(t, "writes things", descriptors[0].Profile().Bio())
+			assert.Equal(t, "avatar.png", descriptors[0].Profile().AvatarAssetID())
+		}
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		cases := map[string]struct {
+			Command command.ChangeProfile
+			Err     error
+		}{
+			"NoSuchUser": {
+				command.ChangeProfile{User: "nobody" + uuidutil.NewUUID()[:8]}, domain.ErrNoSuchUser,
+			},
+			"DisplayNameTooLong": {
+				command.ChangeProfile{User: username, DisplayName: strings.Repeat("名", 51)}, domain.ErrDisplayNameTooLong,
+			},
+			"BioTooLong": {
+				command.ChangeProfile{User: username, Bio: strings.Repeat("b", 501)}, domain.ErrBioTooLong,
+			},
+			"InvalidAvatarAssetID": {
+				command.ChangeProfile{User: username, AvatarAssetID: "../avatar.png"}, domain.ErrInvalidAvatarAssetID,
+			},
+		}
+
+		for testName, testCase := range cases {
+			t.Run(testName, func(t *testing.T) {
+				assert.Equal(t, testCase.Err, errors.Cause(testAppService.ChangeUserProfile(c, testCase.Command)))
+			})
+		}
+
+		descriptor, err := testAppService.UserDescriptorByName(c, username)
+		assert.NoError(t, err)
+		assert.Equal(t, "Writer", descriptor.DisplayName())
+	})
+}
+
+func TestAssignRole(t *testing.T) {
+	c := context.Background()
+
+	admin, ordinary := newAdmin(), newOrdinary()
+
+	t.Run("Fail", func(t *testing.T) {
+		cases := map[string]struct {
+			Command command.AssignRole
+			Err     error
+		}{
+			"SelfRole": {
+				command.AssignRole{OperatorUser: admin.Name(), TargetUser: admin.Name(), TargetRole: model.Ordinary.Name()},
+				domain.ErrCannotAssignSelfRole,
+			},
+			"NoSuchRole": {
+				command.AssignRole{OperatorUser: admin.Name(), TargetUser: ordinary.Name(), TargetRole: "superuser"},
+				domain.ErrNoSuchRole,
+			},
+			"GuestNotAssignable": {
+				command.AssignRole{OperatorUser: admin.Name(), TargetUser: ordinary.Name(), TargetRole: model.Guest.Name()},
+				domain.ErrNoSuchRole,
+			},
+			"NoPermission": {
+				command.AssignRole{OperatorUser: ordinary.Name(), TargetUser: admin.Name(), TargetRole: model.Ordinary.Name()},
+				domain.ErrNoPermission,
+			},
+			"NoSuchOperator": {
+				command.AssignRole{OperatorUser: "nobody" + uuidutil.NewUUID()[:8], TargetUser: ordinary.Name(), TargetRole: model.Admin.Name()},
+				domain.ErrNoPermission,
+			},
+			"NoSuchUser": {
+				command.AssignRole{OperatorUser: admin.Name(), TargetUser: "nobody" + uuidutil.NewUUID()[:8], TargetRole: model.Admin.Name()},
+				domain.ErrNoSuchUser,
+			},
+		}
+
+		for testName, testCase := range cases {
+			t.Run(testName, func(t *testing.T) {
+				assert.Equal(t, testCase.Err, errors.Cause(testAppService.AssignRole(c, testCase.Command)))
+			})
+		}
+
+		user, err := testAppService.userRepository.FindByName(nil, ordinary.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, model.Ordinary, user.Role())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, testAppService.AssignRole(c, command.AssignRole{
+			OperatorUser: admin.Name(),
+			TargetUser:   ordinary.Name(),
+			TargetRole:   model.Admin.Name(),
+		}))
+
+		user, err := testAppService.userRepository.FindByName(nil, ordinary.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, model.Admin, user.Role())
+
+		// the promoted user can demote the former operator now
+		assert.NoError(t, testAppService.AssignRole(c, command.AssignRole{
+			OperatorUser: ordinary.Name(),
+			TargetUser:   admin.Name(),
+			TargetRole:   model.Ordinary.Name(),
+		}))
+
+		user, err = testAppService.userRepository.FindByName(nil, admin.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, model.Ordinary, user.Role())
+	})
+}
+
+func TestViewAllUsersByOptions(t *testing.T) {
+	c := context.Background()
+
+	for i := 0; i < 3; i++ {
+		newOrdinary()
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		cases := map[string]struct {
+			OrderBy string
+			Order   string
+			Less    func(a, b *model.UserDescriptor) bool
+		}{
+			"NameAsc": {
+				"name", "asc", func(a, b *model.UserDescriptor) bool { return a.Name() < b.Name() },
+			},
+			"NameDesc": {
+				"name", "desc", func(a, b *model.UserDescriptor) bool { return a.Name() > b.Name() },
+			},
+			"RegisteredDateAsc": {
+				"registered_date", "asc", func(a, b *model.UserDescriptor) bool { return !a.RegisteredAt().After(b.RegisteredAt()) },
+			},
+			"RegisteredDateDesc": {
+				"registered_date", "desc", func(a, b *model.UserDescriptor) bool { return !a.RegisteredAt().Before(b.RegisteredAt()) },
+			},
+		}
+
+		for testName, testCase := range cases {
+			t.Run(testName, func(t *testing.T) {
+				users, total, err := testAppService.ViewAllUsersByOptions(c, query.ViewAllUsers{
+					Page:    "1",
+					Count:   "2",
+					OrderBy: testCase.OrderBy,
+					Order:   testCase.Order,
+				})
+				assert.NoError(t, err)
+				assert.True(t, total >= 3)
+				if assert.Len(t, users, 2) {
+					assert.True(t, testCase.Less(users[0], users[1]))
+				}
+
+				users, _, err = testAppService.ViewAllUsersByOptions(c, query.ViewAllUsers{
+					Page:    fmt.Sprint(total),
+					Count:   "1",
+					OrderBy: testCase.OrderBy,
+					Order:   testCase.Order,
+				})
+				assert.NoError(t, err)
+				assert.Len(t, users, 1)
+			})
+		}
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		cases := map[string]struct {
+			Query query.ViewAllUsers
+			Err   error
+		}{
+			"ZeroPage": {
+				query.ViewAllUsers{Page: "0", Count: "10", OrderBy: "name", Order: "asc"}, domain.ErrInvalidPage,
+			},
+			"InvalidPage": {
+				query.ViewAllUsers{Page: "first", Count: "10", OrderBy: "name", Order: "asc"}, domain.ErrInvalidPage,
+			},
+			"ZeroCount": {
+				query.ViewAllUsers{Page: "1", Count: "0", OrderBy: "name", Order: "asc"}, domain.ErrInvalidCount,
+			},
+			"TooLargeCount": {
+				query.ViewAllUsers{Page: "1", Count: "101", OrderBy: "name", Order: "asc"}, domain.ErrInvalidCount,
+			},
+			"InvalidOrderBy": {
+				query.ViewAllUsers{Page: "1", Count: "10", OrderBy: "password", Order: "asc"}, domain.ErrInvalidViewOrder,
+			},
+			"InvalidOrder": {
+				query.ViewAllUsers{Page: "1", Count: "10", OrderBy: "name", Order: "random"}, domain.ErrInvalidViewOrder,
+			},
+		}
+
+		for testName, testCase := range cases {
+			t.Run(testName, func(t *testing.T) {
+				_, _, err := testAppService.ViewAllUsersByOptions(c, testCase.Query)
+				assert.Equal(t, testCase.Err, errors.Cause(err))
+			})
+		}
+	})
+}
+
 func newAdmin() *model.User {
 	return newUserWithRole(model.Admin)
 }
@@ -220,10 +712,19 @@ func newUserWithRole(role model.Role) *model.User {
 	password := uuid.New().String()
 	token := uuid.New().String()
 
-	user, err := model.NewUser(0, randomUserName, email, password, token, role, clock.Now())
+	id, err := testAppService.userRepository.NextID(nil)
 	if err != nil {
 		panic(err)
 	}
 
+	user, err := model.NewUser(id, randomUserName, email, password, token, role, clock.Now())
+	if err != nil {
+		panic(err)
+	}
+
+	if err := testAppService.userRepository.Save(nil, user); err != nil {
+		panic(err)
+	}
+
 	return user
 }
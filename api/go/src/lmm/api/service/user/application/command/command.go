@@ -11,6 +11,10 @@ type Register struct {
 type Login struct {
 	UserName string
 	Password string
+
+	// UserAgent and IP describe the device logging in
+	UserAgent string
+	IP        string
 }
 
 // AssignRole command
@@ -20,9 +24,23 @@ type AssignRole struct {
 	TargetRole   string
 }
 
+// RevokeSession command
+type RevokeSession struct {
+	UserID    int64
+	SessionID string
+}
+
 // ChangePassword command
 type ChangePassword struct {
 	User        string
 	OldPassword string
 	NewPassword string
 }
+
+// ChangeProfile command
+type ChangeProfile struct {
+	User          string
+	DisplayName   string
+	Bio           string
+	AvatarAssetID string
+}
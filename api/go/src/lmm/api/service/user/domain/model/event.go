@@ -5,4 +5,5 @@ import "context"
 type UserEventPublisher interface {
 	NotifyUserRegistered(context.Context, UserID) error
 	NotifyUserPasswordChanged(context.Context, UserID) error
+	NotifyUserRoleChanged(context.Context, UserID, Role) error
 }
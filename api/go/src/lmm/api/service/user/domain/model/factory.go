@@ -56,3 +56,9 @@ func (f *Factory) NewPassword(plainText string) (string, error) {
 func (f *Factory) NewToken() string {
 	return uuidutil.NewUUID()
 }
+
+// NewSession creates a session of the user logging in now
+func (f *Factory) NewSession(userID UserID, userAgent, ip string) (*Session, error) {
+	now := clock.Now()
+	return NewSession(SessionID(uuidutil.NewUUID()), userID, userAgent, ip, now, now, now)
+}
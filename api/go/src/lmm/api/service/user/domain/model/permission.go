@@ -14,8 +14,64 @@ const (
 
 	// PermissionAssignToAdmin means permission to assign user to admin role
 	PermissionAssignToAdmin
+
+	// PermissionPostArticle means permission to post articles and series
+	PermissionPostArticle
+
+	// PermissionEditAnyArticle means permission to edit articles written by anyone
+	PermissionEditAnyArticle
+
+	// PermissionDeleteAnyArticle means permission to delete articles written by anyone
+	PermissionDeleteAnyArticle
+
+	// PermissionUploadAsset means permission to upload photos and other assets
+	PermissionUploadAsset
+
+	// PermissionModerateComments means permission to approve, reject and delete comments
+	PermissionModerateComments
+
+	// PermissionManageTags means permission to rename, merge and delete tags across all articles
+	PermissionManageTags
+
+	// PermissionManageUsers means permission to view all users
+	PermissionManageUsers
+
+	// PermissionEditAnyAsset means permission to edit photos and other assets uploaded by anyone
+	PermissionEditAnyAsset
+
+	// PermissionExportSite means permission to export all articles, photos, users and comments
+	PermissionExportSite
 )
 
+// permissionNames names every permission in the catalogue
+var permissionNames = []struct {
+	permission Permission
+	name       string
+}{
+	{PermissionAssignToOrdinary, "assign_to_ordinary"},
+	{PermissionAssignToAdmin, "assign_to_admin"},
+	{PermissionPostArticle, "post_article"},
+	{PermissionEditAnyArticle, "edit_any_article"},
+	{PermissionDeleteAnyArticle, "delete_any_article"},
+	{PermissionUploadAsset, "upload_asset"},
+	{PermissionModerateComments, "moderate_comments"},
+	{PermissionManageTags, "manage_tags"},
+	{PermissionManageUsers, "manage_users"},
+	{PermissionEditAnyAsset, "edit_any_asset"},
+	{PermissionExportSite, "export_site"},
+}
+
+// Names lists the names of permissions which p grants in the order of the catalogue
+func (p Permission) Names() []string {
+	names := make([]string, 0)
+	for _, entry := range permissionNames {
+		if p&entry.permission == entry.permission {
+			names = append(names, entry.name)
+		}
+	}
+	return names
+}
+
 // PermissionAssignToRole returns permission demanded to assign to role
 func PermissionAssignToRole(role Role) Permission {
 	switch role {
@@ -11,4 +11,11 @@ func TestPermission(t *testing.T) {
 	assert.Equal(t, Permission(1), PermissionAssignToOrdinary)
 	assert.Equal(t, Permission(2), PermissionAssignToAdmin)
 	assert.Equal(t, Permission(3), PermissionAssignToAdmin|PermissionAssignToOrdinary)
+	assert.Equal(t, Permission(1<<8), PermissionManageUsers)
+	assert.Equal(t, Permission(1<<10), PermissionExportSite)
+}
+
+func TestPermissionNames(t *testing.T) {
+	assert.Equal(t, []string{}, NoPermission.Names())
+	assert.Equal(t, []string{"post_article", "upload_asset"}, (PermissionUploadAsset | PermissionPostArticle).Names())
 }
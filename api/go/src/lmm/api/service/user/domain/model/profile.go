@@ -0,0 +1,55 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"lmm/api/service/user/domain"
+)
+
+var (
+	displayNameMaxLength   = 50
+	bioMaxLength           = 500
+	avatarAssetIDMaxLength = 100
+)
+
+// Profile is what a user shows to readers as an author
+type Profile struct {
+	displayName   string
+	bio           string
+	avatarAssetID string
+}
+
+// NewProfile creates a new *Profile, avatarAssetID is the name of an uploaded photo asset or empty
+func NewProfile(displayName, bio, avatarAssetID string) (*Profile, error) {
+	displayName = strings.TrimSpace(displayName)
+	if utf8.RuneCountInString(displayName) > displayNameMaxLength {
+		return nil, domain.ErrDisplayNameTooLong
+	}
+
+	bio = strings.TrimSpace(bio)
+	if utf8.RuneCountInString(bio) > bioMaxLength {
+		return nil, domain.ErrBioTooLong
+	}
+
+	if len(avatarAssetID) > avatarAssetIDMaxLength || strings.ContainsAny(avatarAssetID, " \t\r\n/") {
+		return nil, domain.ErrInvalidAvatarAssetID
+	}
+
+	return &Profile{displayName: displayName, bio: bio, avatarAssetID: avatarAssetID}, nil
+}
+
+// DisplayName gets the name shown to readers, empty if not set
+func (p *Profile) DisplayName() string {
+	return p.displayName
+}
+
+// Bio gets the self introduction
+func (p *Profile) Bio() string {
+	return p.bio
+}
+
+// AvatarAssetID gets the name of the photo asset used as the avatar, empty if not set
+func (p *Profile) AvatarAssetID() string {
+	return p.avatarAssetID
+}
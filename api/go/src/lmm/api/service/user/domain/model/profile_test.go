@@ -0,0 +1,51 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lmm/api/service/user/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProfile(t *testing.T) {
+	profile, err := NewProfile(" Writer ", " writes things\n", "avatar.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "Writer", profile.DisplayName())
+	assert.Equal(t, "writes things", profile.Bio())
+	assert.Equal(t, "avatar.png", profile.AvatarAssetID())
+
+	cases := map[string]struct {
+		DisplayName   string
+		Bio           string
+		AvatarAssetID string
+		Err           error
+	}{
+		"DisplayNameTooLong":   {strings.Repeat("名", 51), "", "", domain.ErrDisplayNameTooLong},
+		"BioTooLong":           {"", strings.Repeat("b", 501), "", domain.ErrBioTooLong},
+		"AvatarAssetIDTooLong": {"", "", strings.Repeat("a", 101), domain.ErrInvalidAvatarAssetID},
+		"AvatarAssetIDWithSep": {"", "", "a/b.png", domain.ErrInvalidAvatarAssetID},
+	}
+
+	for testName, testCase := range cases {
+		t.Run(testName, func(t *testing.T) {
+			_, err := NewProfile(testCase.DisplayName, testCase.Bio, testCase.AvatarAssetID)
+			assert.Equal(t, testCase.Err, err)
+		})
+	}
+}
+
+func TestUserDescriptorDisplayName(t *testing.T) {
+	user, err := NewUser(1, "username", "username@lmm.local", "password", uuid.New().String(), Ordinary, time.Now())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "username", user.DisplayName())
+
+	profile, _ := NewProfile("User Name", "", "")
+	user.ChangeProfile(profile)
+	assert.Equal(t, "User Name", user.DisplayName())
+}
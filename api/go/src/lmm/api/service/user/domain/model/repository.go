@@ -8,6 +8,40 @@ import (
 type UserRepository interface {
 	NextID(tx transaction.Transaction) (UserID, error)
 	Save(tx transaction.Transaction, user *User) error
+	FindByID(tx transaction.Transaction, id UserID) (*User, error)
 	FindByName(tx transaction.Transaction, username string) (*User, error)
 	FindByToken(tx transaction.Transaction, token string) (*User, error)
+
+	// DescribeAll lists users on the page with the total number of users
+	DescribeAll(tx transaction.Transaction, options UserListOptions) ([]*UserDescriptor, uint, error)
+}
+
+// SessionRepository interface, sessions are owned by their users
+type SessionRepository interface {
+	Save(tx transaction.Transaction, session *Session) error
+	Remove(tx transaction.Transaction, userID UserID, id SessionID) error
+	RemoveAll(tx transaction.Transaction, userID UserID) error
+
+	// FindByID returns ErrNoSuchSession unless the user has the session
+	FindByID(tx transaction.Transaction, userID UserID, id SessionID) (*Session, error)
+	FindByUser(tx transaction.Transaction, userID UserID) ([]*Session, error)
+}
+
+// UserListOrderBy is a property which users are listed in order of
+type UserListOrderBy string
+
+const (
+	// UserListOrderByName lists users in order of their names
+	UserListOrderByName UserListOrderBy = "name"
+
+	// UserListOrderByRegisteredAt lists users in order of their registered dates
+	UserListOrderByRegisteredAt UserListOrderBy = "registered_date"
+)
+
+// UserListOptions specifies which page of users to list and its order
+type UserListOptions struct {
+	Page    uint
+	Count   uint
+	OrderBy UserListOrderBy
+	Desc    bool
 }
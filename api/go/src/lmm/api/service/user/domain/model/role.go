@@ -22,6 +22,11 @@ func RoleFromString(name string) Role {
 	}
 }
 
+// Permission returns all permissions which r has
+func (r Role) Permission() Permission {
+	return r.permssion
+}
+
 // HasPermission returns ture if r has perm
 func (r Role) HasPermission(permission Permission) bool {
 	return r.permssion&permission == permission
@@ -30,8 +35,11 @@ func (r Role) HasPermission(permission Permission) bool {
 var (
 	// Admin role
 	Admin = Role{
-		name:      "admin",
-		permssion: PermissionAssignToAdmin | PermissionAssignToOrdinary,
+		name: "admin",
+		permssion: PermissionAssignToAdmin | PermissionAssignToOrdinary |
+			PermissionPostArticle | PermissionEditAnyArticle | PermissionDeleteAnyArticle |
+			PermissionUploadAsset | PermissionModerateComments | PermissionManageTags | PermissionManageUsers |
+			PermissionEditAnyAsset | PermissionExportSite,
 	}
 
 	// Guest role
@@ -43,6 +51,6 @@ var (
 	// Ordinary role
 	Ordinary = Role{
 		name:      "ordinary",
-		permssion: NoPermission,
+		permssion: PermissionPostArticle | PermissionUploadAsset,
 	}
 )
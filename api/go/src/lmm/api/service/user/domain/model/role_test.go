@@ -10,4 +10,20 @@ func TestRoleAdmin(t *testing.T) {
 	assert.Equal(t, "admin", Admin.Name())
 	assert.True(t, Admin.HasPermission(PermissionAssignToAdmin))
 	assert.True(t, Admin.HasPermission(PermissionAssignToOrdinary))
+	assert.True(t, Admin.HasPermission(PermissionManageUsers|PermissionManageTags|PermissionModerateComments))
+	assert.True(t, Admin.HasPermission(PermissionEditAnyAsset|PermissionExportSite))
+}
+
+func TestRoleOrdinary(t *testing.T) {
+	assert.Equal(t, "ordinary", Ordinary.Name())
+	assert.True(t, Ordinary.HasPermission(PermissionPostArticle|PermissionUploadAsset))
+	assert.False(t, Ordinary.HasPermission(PermissionEditAnyArticle))
+	assert.False(t, Ordinary.HasPermission(PermissionEditAnyAsset))
+	assert.False(t, Ordinary.HasPermission(PermissionAssignToOrdinary))
+}
+
+func TestRoleGuest(t *testing.T) {
+	assert.Equal(t, Guest, RoleFromString("nobody"))
+	assert.Equal(t, NoPermission, Guest.Permission())
+	assert.False(t, Guest.HasPermission(PermissionPostArticle))
 }
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"lmm/api/service/user/domain"
+	"lmm/api/util/uuidutil"
+)
+
+var (
+	userAgentMaxLength = 500
+
+	// sessionTouchInterval is how long the last seen time of a session is kept before refreshed,
+	// so that a session is not saved on every request
+	sessionTouchInterval = 5 * time.Minute
+)
+
+// SessionID identifies a session of a user
+type SessionID string
+
+// Session is a login of a user from a device, access tokens are bound to it until revoked or expired
+type Session struct {
+	id         SessionID
+	userID     UserID
+	userAgent  string
+	ip         string
+	createdAt  time.Time
+	lastSeenAt time.Time
+	expiresAt  time.Time
+}
+
+// NewSession creates a new *Session, userAgent is truncated if too long.
+// expiresAt is when the latest access token bound to the session expires
+func NewSession(id SessionID, userID UserID, userAgent, ip string, createdAt, lastSeenAt, expiresAt time.Time) (*Session, error) {
+	if _, err := uuidutil.ParseString(string(id)); err != nil {
+		return nil, domain.ErrInvalidSessionID
+	}
+
+	userAgent = strings.TrimSpace(userAgent)
+	if utf8.RuneCountInString(userAgent) > userAgentMaxLength {
+		userAgent = string([]rune(userAgent)[:userAgentMaxLength])
+	}
+
+	return &Session{
+		id:         id,
+		userID:     userID,
+		userAgent:  userAgent,
+		ip:         strings.TrimSpace(ip),
+		createdAt:  createdAt,
+		lastSeenAt: lastSeenAt,
+		expiresAt:  expiresAt,
+	}, nil
+}
+
+// ID gets the session's id
+func (s *Session) ID() SessionID {
+	return s.id
+}
+
+// UserID gets the id of the user who logged in
+func (s *Session) UserID() UserID {
+	return s.userID
+}
+
+// UserAgent gets the user agent of the device which logged in
+func (s *Session) UserAgent() string {
+	return s.userAgent
+}
+
+// IP gets the ip address which logged in
+func (s *Session) IP() string {
+	return s.ip
+}
+
+// CreatedAt gets when the user logged in
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// LastSeenAt gets when the session was used lastly
+func (s *Session) LastSeenAt() time.Time {
+	return s.lastSeenAt
+}
+
+// Touch records the session is used at now, returns false if the last seen time is recent enough to keep
+func (s *Session) Touch(now time.Time) bool {
+	if now.Sub(s.lastSeenAt) < sessionTouchInterval {
+		return false
+	}
+	s.lastSeenAt = now
+	return true
+}
+
+// ExpiresAt gets when the latest access token bound to the session expires
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// Expired returns true if no access token bound to the session is valid at now
+func (s *Session) Expired(now time.Time) bool {
+	return !now.Before(s.expiresAt)
+}
+
+// Extend keeps the session until the access token issued for it expires
+func (s *Session) Extend(token *AccessToken) {
+	if token.Expire().After(s.expiresAt) {
+		s.expiresAt = token.Expire()
+	}
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lmm/api/service/user/domain"
+	"lmm/api/util/uuidutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSession(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Success", func(t *testing.T) {
+		session, err := NewSession(SessionID(uuidutil.NewUUID()), UserID(1), strings.Repeat("あ", 501), " 127.0.0.1 ", now, now, now)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Repeat("あ", 500), session.UserAgent())
+		assert.Equal(t, "127.0.0.1", session.IP())
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		session, err := NewSession(SessionID("session"), UserID(1), "", "", now, now, now)
+		assert.Equal(t, domain.ErrInvalidSessionID, err)
+		assert.Nil(t, session)
+	})
+}
+
+func TestSessionTouch(t *testing.T) {
+	now := time.Now()
+
+	session, err := NewSession(SessionID(uuidutil.NewUUID()), UserID(1), "", "", now, now, now)
+	if !assert.NoError(t, err) {
+		t.Fatal(err)
+	}
+
+	assert.False(t, session.Touch(now.Add(time.Minute)))
+	assert.Equal(t, now, session.LastSeenAt())
+
+	later := now.Add(sessionTouchInterval)
+	assert.True(t, session.Touch(later))
+	assert.Equal(t, later, session.LastSeenAt())
+	assert.Equal(t, now, session.CreatedAt())
+}
+
+func TestSessionExpiry(t *testing.T) {
+	now := time.Now()
+
+	session, err := NewSession(SessionID(uuidutil.NewUUID()), UserID(1), "", "", now, now, now)
+	if !assert.NoError(t, err) {
+		t.Fatal(err)
+	}
+	assert.True(t, session.Expired(now))
+
+	expire := now.Add(time.Hour)
+	session.Extend(NewAccessToken(session.UserID(), "", session.ID(), "hashed", expire))
+	assert.Equal(t, expire, session.ExpiresAt())
+	assert.False(t, session.Expired(now))
+	assert.True(t, session.Expired(expire))
+
+	// an older token never shortens the session
+	session.Extend(NewAccessToken(session.UserID(), "", session.ID(), "hashed", now.Add(time.Minute)))
+	assert.Equal(t, expire, session.ExpiresAt())
+}
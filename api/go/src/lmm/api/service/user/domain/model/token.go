@@ -3,32 +3,54 @@ package model
 import "time"
 
 type AccessToken struct {
-	raw    string
-	hashed string
-	expire time.Time
+	userID    UserID
+	raw       string
+	sessionID SessionID
+	hashed    string
+	expire    time.Time
 }
 
+// UserID gets the user who the token is issued to, 0 if the token is bound to the user token instead
+func (token AccessToken) UserID() UserID {
+	return token.userID
+}
+
+// Raw gets the user token which legacy access tokens are bound to, empty if the token is bound to the user id
 func (token AccessToken) Raw() string {
 	return token.raw
 }
 
+// SessionID gets the session which the token is bound to
+func (token AccessToken) SessionID() SessionID {
+	return token.sessionID
+}
+
 func (token AccessToken) Hashed() string {
 	return token.hashed
 }
 
+// Expire gets when the token expires
+func (token AccessToken) Expire() time.Time {
+	return token.expire
+}
+
 func (token AccessToken) Expired() bool {
 	return token.expire.Before(time.Now())
 }
 
-func NewAccessToken(raw, hashed string, expire time.Time) *AccessToken {
+// NewAccessToken creates an access token bound to either userID or the raw user token
+func NewAccessToken(userID UserID, raw string, sessionID SessionID, hashed string, expire time.Time) *AccessToken {
 	return &AccessToken{
-		raw:    raw,
-		hashed: hashed,
-		expire: expire,
+		userID:    userID,
+		raw:       raw,
+		sessionID: sessionID,
+		hashed:    hashed,
+		expire:    expire,
 	}
 }
 
 type TokenService interface {
-	Encrypt(string) (*AccessToken, error)
+	// Encrypt issues an access token of the user bound to the session
+	Encrypt(user *User, sessionID SessionID) (*AccessToken, error)
 	Decrypt(string) (*AccessToken, error)
 }
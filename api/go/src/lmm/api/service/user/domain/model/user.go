@@ -25,6 +25,7 @@ type UserDescriptor struct {
 	email        string
 	role         Role
 	registeredAt time.Time
+	profile      *Profile
 }
 
 // NewUserDescriptor creates a new *UserDescriptor
@@ -33,6 +34,7 @@ func NewUserDescriptor(id UserID, name, email string, role Role, registeredAt ti
 		id:           id,
 		role:         role,
 		registeredAt: registeredAt,
+		profile:      &Profile{},
 	}
 
 	if err := user.setName(name); err != nil {
@@ -75,6 +77,19 @@ func (user *UserDescriptor) RegisteredAt() time.Time {
 	return user.registeredAt
 }
 
+// Profile gets user's profile as an author
+func (user *UserDescriptor) Profile() *Profile {
+	return user.profile
+}
+
+// DisplayName gets the name shown to readers, which is user's name if not set in the profile
+func (user *UserDescriptor) DisplayName() string {
+	if user.profile.DisplayName() != "" {
+		return user.profile.DisplayName()
+	}
+	return user.name
+}
+
 func (user *UserDescriptor) setName(name string) error {
 	if !patternUserName.MatchString(name) {
 		return domain.ErrInvalidUserName
@@ -170,6 +185,11 @@ func (user *User) ChangeRole(role Role) error {
 	return user.setRole(role)
 }
 
+// ChangeProfile changes user's profile
+func (user *User) ChangeProfile(profile *Profile) {
+	user.profile = profile
+}
+
 // ChangeEmail changes user's email
 func (user *User) ChangeEmail(newEmailAddress string) error {
 	return user.setEmail(newEmailAddress)
@@ -21,9 +21,24 @@ var (
 	// ErrInvalidPermission error
 	ErrInvalidPermission = errors.New("invalid permission")
 
+	// ErrDisplayNameTooLong error
+	ErrDisplayNameTooLong = errors.New("display name too long")
+
+	// ErrBioTooLong error
+	ErrBioTooLong = errors.New("bio too long")
+
+	// ErrInvalidAvatarAssetID error
+	ErrInvalidAvatarAssetID = errors.New("invalid avatar asset id")
+
 	// ErrNoSuchUser error
 	ErrNoSuchUser = errors.New("no such user")
 
+	// ErrNoSuchSession error
+	ErrNoSuchSession = errors.New("no such session")
+
+	// ErrInvalidSessionID error
+	ErrInvalidSessionID = errors.New("invalid session id")
+
 	// ErrNoSuchRole error
 	ErrNoSuchRole = errors.New("no such role")
 
@@ -59,4 +74,8 @@ var (
 	ErrInvalidTokenFormat = errors.New("invalid token format")
 
 	ErrInvalidTokenLength = errors.New("invalid token length")
+
+	ErrInvalidTokenSignature = errors.New("invalid token signature")
+
+	ErrUnknownTokenKey = errors.New("unknown token key")
 )
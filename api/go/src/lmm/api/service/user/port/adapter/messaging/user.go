@@ -11,6 +11,7 @@ import (
 const (
 	TopicUserPasswordChanged = "UserPasswordChanged"
 	TopicUserRegistered      = "UserRegistered"
+	TopicUserRoleChanged     = "UserRoleChanged"
 )
 
 type userEventPublisher struct {
@@ -24,7 +25,8 @@ func NewUserEventPublisher(pub messaging.Publisher) model.UserEventPublisher {
 }
 
 type userEvent struct {
-	UserID int `json:"user_id"`
+	UserID int    `json:"user_id"`
+	Role   string `json:"role,omitempty"`
 
 	topic       string
 	publishedAt time.Time
@@ -57,3 +59,12 @@ func (p *userEventPublisher) NotifyUserRegistered(c context.Context, userID mode
 		publishedAt: time.Now(),
 	})
 }
+
+func (p *userEventPublisher) NotifyUserRoleChanged(c context.Context, userID model.UserID, role model.Role) error {
+	return p.client.Publish(c, &userEvent{
+		UserID:      int(userID),
+		Role:        role.Name(),
+		topic:       TopicUserRoleChanged,
+		publishedAt: time.Now(),
+	})
+}
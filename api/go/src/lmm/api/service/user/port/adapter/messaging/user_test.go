@@ -27,6 +27,15 @@ func TestUserEventPublisher(t *testing.T) {
 				return pub.NotifyUserPasswordChanged
 			},
 		},
+		TopicUserRoleChanged: {
+			UserID: model.UserID(97),
+			AckMsg: "role changed",
+			NotifyFunc: func(pub model.UserEventPublisher) func(context.Context, model.UserID) error {
+				return func(c context.Context, userID model.UserID) error {
+					return pub.NotifyUserRoleChanged(c, userID, model.Admin)
+				}
+			},
+		},
 		TopicUserRegistered: {
 			UserID: model.UserID(541),
 			AckMsg: "registered",
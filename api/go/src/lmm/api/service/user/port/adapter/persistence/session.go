@@ -0,0 +1,130 @@
+package persistence
+
+import (
+	"time"
+
+	dsUtil "lmm/api/pkg/datastore"
+	"lmm/api/pkg/transaction"
+	"lmm/api/service/user/domain"
+	"lmm/api/service/user/domain/model"
+
+	"cloud.google.com/go/datastore"
+	"github.com/pkg/errors"
+)
+
+type session struct {
+	ID         *datastore.Key `datastore:"__key__"`
+	UserAgent  string         `datastore:"UserAgent,noindex"`
+	IP         string         `datastore:"IP,noindex"`
+	CreatedAt  time.Time      `datastore:"CreatedAt,noindex"`
+	LastSeenAt time.Time      `datastore:"LastSeenAt,noindex"`
+	ExpiresAt  time.Time      `datastore:"ExpiresAt,noindex"`
+}
+
+const (
+	sessionKind = "Session"
+)
+
+// SessionDataStore implements SessionRepository, sessions are stored as children of their users
+type SessionDataStore struct {
+	source *datastore.Client
+	transaction.Manager
+}
+
+func NewSessionDataStore(source *datastore.Client) *SessionDataStore {
+	return &SessionDataStore{
+		source:  source,
+		Manager: dsUtil.NewTransactionManager(source),
+	}
+}
+
+func (s *SessionDataStore) sessionKey(userID model.UserID, id model.SessionID) *datastore.Key {
+	return datastore.NameKey(sessionKind, string(id), datastore.IDKey(userKind, int64(userID), nil))
+}
+
+// Save implementation
+func (s *SessionDataStore) Save(tx transaction.Transaction, model *model.Session) error {
+	k := s.sessionKey(model.UserID(), model.ID())
+
+	_, err := dsUtil.MustTransaction(tx).Mutate(
+		datastore.NewUpsert(k, &session{
+			ID:         k,
+			UserAgent:  model.UserAgent(),
+			IP:         model.IP(),
+			CreatedAt:  model.CreatedAt(),
+			LastSeenAt: model.LastSeenAt(),
+			ExpiresAt:  model.ExpiresAt(),
+		}),
+	)
+
+	return errors.Wrap(err, "failed to save session to datastore")
+}
+
+// Remove implementation
+func (s *SessionDataStore) Remove(tx transaction.Transaction, userID model.UserID, id model.SessionID) error {
+	_, err := dsUtil.MustTransaction(tx).Mutate(datastore.NewDelete(s.sessionKey(userID, id)))
+	return errors.Wrap(err, "failed to remove session from datastore")
+}
+
+// RemoveAll implementation
+func (s *SessionDataStore) RemoveAll(tx transaction.Transaction, userID model.UserID) error {
+	q := datastore.NewQuery(sessionKind).Ancestor(datastore.IDKey(userKind, int64(userID), nil)).KeysOnly().Transaction(dsUtil.MustTransaction(tx))
+
+	keys, err := s.source.GetAll(tx, q, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get session keys")
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(dsUtil.MustTransaction(tx).DeleteMulti(keys), "failed to remove sessions from datastore")
+}
+
+// FindByID implementation
+func (s *SessionDataStore) FindByID(tx transaction.Transaction, userID model.UserID, id model.SessionID) (*model.Session, error) {
+	var entity session
+	if err := dsUtil.MustTransaction(tx).Get(s.sessionKey(userID, id), &entity); err != nil {
+		if err == datastore.ErrNoSuchEntity {
+			return nil, domain.ErrNoSuchSession
+		}
+		return nil, errors.Wrap(err, "internal error: failed to get session by key")
+	}
+	return s.sessionFromEntity(&entity)
+}
+
+// FindByUser implementation
+func (s *SessionDataStore) FindByUser(tx transaction.Transaction, userID model.UserID) ([]*model.Session, error) {
+	q := datastore.NewQuery(sessionKind).Ancestor(datastore.IDKey(userKind, int64(userID), nil)).Transaction(dsUtil.MustTransaction(tx))
+
+	var entities []*session
+	if _, err := s.source.GetAll(tx, q, &entities); err != nil {
+		return nil, errors.Wrap(err, "failed to get sessions")
+	}
+
+	sessions := make([]*model.Session, len(entities), len(entities))
+	for i, entity := range entities {
+		session, err := s.sessionFromEntity(entity)
+		if err != nil {
+			return nil, err
+		}
+		sessions[i] = session
+	}
+	return sessions, nil
+}
+
+func (s *SessionDataStore) sessionFromEntity(entity *session) (*model.Session, error) {
+	session, err := model.NewSession(
+		model.SessionID(entity.ID.Name),
+		model.UserID(entity.ID.Parent.ID),
+		entity.UserAgent,
+		entity.IP,
+		entity.CreatedAt,
+		entity.LastSeenAt,
+		entity.ExpiresAt,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error: invalid session")
+	}
+	return session, nil
+}
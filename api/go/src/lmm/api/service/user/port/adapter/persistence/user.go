@@ -1,6 +1,7 @@
 package persistence
 
 import (
+	"context"
 	"time"
 
 	dsUtil "lmm/api/pkg/datastore"
@@ -19,7 +20,11 @@ type user struct {
 	Password     string         `datastore:"Password,noindex"`
 	Token        string         `datastore:"Token"`
 	Role         string         `datastore:"Role,noindex"`
-	RegisteredAt time.Time      `datastore:"RegisteredAt,noindex"`
+	RegisteredAt time.Time      `datastore:"RegisteredAt"`
+
+	DisplayName   string `datastore:"DisplayName,noindex"`
+	Bio           string `datastore:"Bio,noindex"`
+	AvatarAssetID string `datastore:"AvatarAssetID,noindex"`
 }
 
 const (
@@ -64,6 +69,10 @@ func (s *UserDataStore) Save(tx transaction.Transaction, model *model.User) erro
 			Token:        model.Token(),
 			Role:         model.Role().Name(),
 			RegisteredAt: model.RegisteredAt(),
+
+			DisplayName:   model.Profile().DisplayName(),
+			Bio:           model.Profile().Bio(),
+			AvatarAssetID: model.Profile().AvatarAssetID(),
 		}),
 	)
 
@@ -82,15 +91,19 @@ func (s *UserDataStore) findByFilter(tx transaction.Transaction, filter, value s
 		return nil, domain.ErrNoSuchUser
 	}
 
+	return s.findByKey(tx, keys[0])
+}
+
+func (s *UserDataStore) findByKey(tx transaction.Transaction, key *datastore.Key) (*model.User, error) {
 	var user user
-	if err := dsUtil.MustTransaction(tx).Get(keys[0], &user); err != nil {
+	if err := dsUtil.MustTransaction(tx).Get(key, &user); err != nil {
 		if err == datastore.ErrNoSuchEntity {
 			return nil, domain.ErrNoSuchUser
 		}
 		return nil, errors.Wrap(err, "internal error: failed to get user by key")
 	}
 
-	return model.NewUser(
+	u, err := model.NewUser(
 		model.UserID(user.ID.ID),
 		user.Name,
 		user.Email,
@@ -99,6 +112,42 @@ func (s *UserDataStore) findByFilter(tx transaction.Transaction, filter, value s
 		model.RoleFromString(user.Role),
 		user.RegisteredAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := model.NewProfile(user.DisplayName, user.Bio, user.AvatarAssetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error: invalid user profile")
+	}
+	u.ChangeProfile(profile)
+
+	return u, nil
+}
+
+// FindAll finds all users, used to export users
+func (s *UserDataStore) FindAll(c context.Context) ([]*model.User, error) {
+	keys, err := s.source.GetAll(c, datastore.NewQuery(userKind).KeysOnly(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user keys")
+	}
+
+	users := make([]*model.User, len(keys), len(keys))
+	for i, key := range keys {
+		if err := s.RunInTransaction(c, func(tx transaction.Transaction) error {
+			users[i], err = s.findByKey(tx, key)
+			return err
+		}, &transaction.Option{ReadOnly: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	return users, nil
+}
+
+// FindByID implementation
+func (s *UserDataStore) FindByID(tx transaction.Transaction, id model.UserID) (*model.User, error) {
+	return s.findByKey(tx, datastore.IDKey(userKind, int64(id), nil))
 }
 
 // FindByName implementation
@@ -110,3 +159,44 @@ func (s *UserDataStore) FindByName(tx transaction.Transaction, username string)
 func (s *UserDataStore) FindByToken(tx transaction.Transaction, token string) (*model.User, error) {
 	return s.findByFilter(tx, "Token =", token)
 }
+
+// DescribeAll implementation
+func (s *UserDataStore) DescribeAll(tx transaction.Transaction, options model.UserListOptions) ([]*model.UserDescriptor, uint, error) {
+	order := "Name"
+	if options.OrderBy == model.UserListOrderByRegisteredAt {
+		order = "RegisteredAt"
+	}
+	if options.Desc {
+		order = "-" + order
+	}
+
+	counting := datastore.NewQuery(userKind).KeysOnly()
+	paging := datastore.NewQuery(userKind).Order(order).Limit(int(options.Count)).Offset(int((options.Page - 1) * options.Count))
+
+	total, err := s.source.Count(tx, counting)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count users")
+	}
+
+	var entities []*user
+	if _, err := s.source.GetAll(tx, paging, &entities); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get users")
+	}
+
+	users := make([]*model.UserDescriptor, len(entities), len(entities))
+	for i, entity := range entities {
+		descriptor, err := model.NewUserDescriptor(
+			model.UserID(entity.ID.ID),
+			entity.Name,
+			entity.Email,
+			model.RoleFromString(entity.Role),
+			entity.RegisteredAt,
+		)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "internal error: invalid user %d", entity.ID.ID)
+		}
+		users[i] = descriptor
+	}
+
+	return users, uint(total), nil
+}
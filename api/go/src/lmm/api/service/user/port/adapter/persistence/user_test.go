@@ -156,4 +156,36 @@ func TestUserDataStore(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("DescribeAll", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			mustSaveRandomUser(c, userDataStore)
+		}
+
+		userDataStore.RunInTransaction(c, func(tx transaction.Transaction) error {
+			users, total, err := userDataStore.DescribeAll(tx, model.UserListOptions{
+				Page:    1,
+				Count:   2,
+				OrderBy: model.UserListOrderByRegisteredAt,
+				Desc:    true,
+			})
+			assert.NoError(t, err)
+			assert.True(t, total >= 3)
+			if assert.Len(t, users, 2) {
+				assert.False(t, users[0].RegisteredAt().Before(users[1].RegisteredAt()))
+			}
+
+			users, _, err = userDataStore.DescribeAll(tx, model.UserListOptions{
+				Page:    1,
+				Count:   2,
+				OrderBy: model.UserListOrderByName,
+			})
+			assert.NoError(t, err)
+			if assert.Len(t, users, 2) {
+				assert.True(t, users[0].Name() < users[1].Name())
+			}
+
+			return nil
+		}, &transaction.Option{ReadOnly: true})
+	})
 }
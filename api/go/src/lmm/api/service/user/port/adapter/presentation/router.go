@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 
 	authUtil "lmm/api/pkg/auth"
 	httpUtil "lmm/api/pkg/http"
+	"lmm/api/pkg/http/middleware"
 	"lmm/api/service/user/application"
 	"lmm/api/service/user/application/command"
+	"lmm/api/service/user/application/query"
 	"lmm/api/service/user/domain"
 
 	"github.com/gin-gonic/gin"
@@ -33,9 +36,17 @@ func NewGinRouterProvider(appService *application.Service) *GinRouterProvider {
 
 func (p *GinRouterProvider) Provide(router *gin.Engine) {
 	router.POST("/v1/users", p.SignUp)
+	router.GET("/v1/users", middleware.RequirePermission(authUtil.PermissionManageUsers), p.ViewAllUsers)
 	router.PUT("/v1/users/:user/password", p.ChangeUserPassword)
+	router.GET("/v1/users/:user/profile", p.GetUserProfile)
+	router.PUT("/v1/users/:user/profile", p.ChangeUserProfile)
+	router.PUT("/v1/users/:user/role", p.AssignUserRole)
 
 	router.POST("/v1/auth/token", p.Token)
+
+	router.GET("/v1/sessions", p.ListSessions)
+	router.DELETE("/v1/sessions", p.RevokeAllSessions)
+	router.DELETE("/v1/sessions/:session", p.RevokeSession)
 }
 
 // SignUp handles POST /v1/users
@@ -107,8 +118,10 @@ func (p *GinRouterProvider) BasicAuth(next gin.HandlerFunc) gin.HandlerFunc {
 		}
 
 		auth, err := p.appService.BasicAuth(c, command.Login{
-			UserName: basicauth.UserName,
-			Password: basicauth.Password,
+			UserName:  basicauth.UserName,
+			Password:  basicauth.Password,
+			UserAgent: c.Request.UserAgent(),
+			IP:        c.ClientIP(),
 		})
 		if err != nil {
 			httpUtil.LogWarn(c, "error on calling BasicAuth app service", err)
@@ -223,3 +236,234 @@ func (p *GinRouterProvider) ChangeUserPassword(c *gin.Context) {
 		httpUtil.LogPanic(c, "unexpect error", err)
 	}
 }
+
+// GetUserProfile handles GET /v1/users/:user/profile
+func (p *GinRouterProvider) GetUserProfile(c *gin.Context) {
+	user, err := p.appService.UserDescriptorByName(c, c.Param("user"))
+
+	switch errors.Cause(err) {
+	case nil:
+		c.JSON(http.StatusOK, profileView{
+			Name:          user.Name(),
+			DisplayName:   user.DisplayName(),
+			Bio:           user.Profile().Bio(),
+			AvatarAssetID: user.Profile().AvatarAssetID(),
+		})
+
+	case domain.ErrNoSuchUser:
+		httpUtil.NotFound(c)
+
+	default:
+		httpUtil.LogPanic(c, "unexpect error", err)
+	}
+}
+
+// ChangeUserProfile handles PUT /v1/users/:user/profile
+func (p *GinRouterProvider) ChangeUserProfile(c *gin.Context) {
+	auth, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	if auth.Name != c.Param("user") {
+		httpUtil.Forbidden(c)
+		return
+	}
+
+	requestBody := changeProfileRequestBody{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		httpUtil.LogWarn(c, "bind json error", err)
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	err := p.appService.ChangeUserProfile(c, command.ChangeProfile{
+		User:          auth.Name,
+		DisplayName:   requestBody.DisplayName,
+		Bio:           requestBody.Bio,
+		AvatarAssetID: requestBody.AvatarAssetID,
+	})
+
+	originalError := errors.Cause(err)
+	switch originalError {
+	case nil:
+		httpUtil.Response(c, http.StatusOK, "Success")
+
+	case
+		domain.ErrDisplayNameTooLong,
+		domain.ErrBioTooLong,
+		domain.ErrInvalidAvatarAssetID:
+		c.String(http.StatusBadRequest, originalError.Error())
+
+	case domain.ErrNoSuchUser:
+		httpUtil.NotFound(c)
+
+	default:
+		httpUtil.LogPanic(c, "unexpect error", err)
+	}
+}
+
+// AssignUserRole handles PUT /v1/users/:user/role
+func (p *GinRouterProvider) AssignUserRole(c *gin.Context) {
+	auth, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	requestBody := assignRoleRequestBody{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		httpUtil.LogWarn(c, "bind json error", err)
+		httpUtil.BadRequest(c)
+		return
+	}
+
+	err := p.appService.AssignRole(c, command.AssignRole{
+		OperatorUser: auth.Name,
+		TargetUser:   c.Param("user"),
+		TargetRole:   requestBody.Role,
+	})
+
+	originalError := errors.Cause(err)
+	switch originalError {
+	case nil:
+		httpUtil.Response(c, http.StatusOK, "Success")
+
+	case domain.ErrNoSuchRole:
+		c.String(http.StatusBadRequest, originalError.Error())
+
+	case domain.ErrNoPermission, domain.ErrCannotAssignSelfRole:
+		c.String(http.StatusForbidden, originalError.Error())
+
+	case domain.ErrNoSuchUser:
+		httpUtil.NotFound(c)
+
+	default:
+		httpUtil.LogPanic(c, "unexpect error", err)
+	}
+}
+
+// ViewAllUsers handles GET /v1/users
+func (p *GinRouterProvider) ViewAllUsers(c *gin.Context) {
+	if _, ok := httpUtil.AuthFromGinContext(c); !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	viewQuery := query.ViewAllUsers{
+		Page:    c.DefaultQuery("page", "1"),
+		Count:   c.DefaultQuery("count", "50"),
+		OrderBy: c.DefaultQuery("sort_by", "name"),
+		Order:   c.DefaultQuery("sort", "asc"),
+	}
+
+	users, total, err := p.appService.ViewAllUsersByOptions(c, viewQuery)
+
+	originalError := errors.Cause(err)
+	switch originalError {
+	case nil:
+		views := make([]userView, len(users), len(users))
+		for i, user := range users {
+			views[i] = userView{
+				Name:           user.Name(),
+				Role:           user.Role().Name(),
+				RegisteredDate: user.RegisteredAt().Unix(),
+			}
+		}
+
+		c.JSON(http.StatusOK, usersView{
+			Users:  views,
+			Page:   canonicalNumber(viewQuery.Page),
+			Count:  canonicalNumber(viewQuery.Count),
+			Total:  total,
+			SortBy: viewQuery.OrderBy,
+			Sort:   viewQuery.Order,
+		})
+
+	case
+		domain.ErrInvalidPage,
+		domain.ErrInvalidCount,
+		domain.ErrInvalidViewOrder:
+		c.String(http.StatusBadRequest, originalError.Error())
+
+	default:
+		httpUtil.LogPanic(c, "unexpect error", err)
+	}
+}
+
+// canonicalNumber formats a number which has been validated, "01" into "1" for example
+func canonicalNumber(s string) json.Number {
+	n, _ := strconv.ParseUint(s, 10, 32)
+	return json.Number(strconv.FormatUint(n, 10))
+}
+
+// ListSessions handles GET /v1/sessions
+func (p *GinRouterProvider) ListSessions(c *gin.Context) {
+	auth, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	sessions, err := p.appService.UserSessions(c, auth.ID)
+	if err != nil {
+		httpUtil.LogPanic(c, "unexpect error", err)
+		return
+	}
+
+	views := make([]sessionView, len(sessions), len(sessions))
+	for i, session := range sessions {
+		views[i] = sessionView{
+			ID:         string(session.ID()),
+			UserAgent:  session.UserAgent(),
+			IP:         session.IP(),
+			CreatedAt:  session.CreatedAt().Unix(),
+			LastSeenAt: session.LastSeenAt().Unix(),
+			Current:    string(session.ID()) == auth.SessionID,
+		}
+	}
+
+	c.JSON(http.StatusOK, sessionsView{Sessions: views})
+}
+
+// RevokeSession handles DELETE /v1/sessions/:session
+func (p *GinRouterProvider) RevokeSession(c *gin.Context) {
+	auth, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	err := p.appService.RevokeSession(c, command.RevokeSession{
+		UserID:    auth.ID,
+		SessionID: c.Param("session"),
+	})
+
+	switch errors.Cause(err) {
+	case nil:
+		c.Status(http.StatusNoContent)
+
+	case domain.ErrNoSuchSession:
+		httpUtil.NotFound(c)
+
+	default:
+		httpUtil.LogPanic(c, "unexpect error", err)
+	}
+}
+
+// RevokeAllSessions handles DELETE /v1/sessions, which logs the user out everywhere
+func (p *GinRouterProvider) RevokeAllSessions(c *gin.Context) {
+	auth, ok := httpUtil.AuthFromGinContext(c)
+	if !ok {
+		httpUtil.Unauthorized(c)
+		return
+	}
+
+	if err := p.appService.RevokeAllSessions(c, auth.ID); err != nil {
+		httpUtil.LogPanic(c, "unexpect error", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -17,7 +17,9 @@ import (
 	jsonUtil "lmm/api/pkg/json"
 	"lmm/api/pkg/pubsub/pubsubtest"
 	testUtil "lmm/api/pkg/testing"
+	"lmm/api/pkg/transaction"
 	"lmm/api/service/user/application"
+	"lmm/api/service/user/application/command"
 	"lmm/api/service/user/domain"
 	"lmm/api/service/user/domain/model"
 	"lmm/api/service/user/port/adapter/messaging"
@@ -34,6 +36,7 @@ import (
 var (
 	router   *gin.Engine
 	provider *GinRouterProvider
+	userRepo *persistence.UserDataStore
 )
 
 func TestMain(m *testing.M) {
@@ -46,16 +49,18 @@ func TestMain(m *testing.M) {
 
 	router = gin.New()
 
-	userRepo := persistence.NewUserDataStore(dataStore)
+	userRepo = persistence.NewUserDataStore(dataStore)
 	userPub := messaging.NewUserEventPublisher(pubsubClient)
 	userAppService := application.NewService(
 		&service.BcryptService{},
 		testUtil.TokenService,
 		userRepo,
 		userRepo,
+		persistence.NewSessionDataStore(dataStore),
 		userPub,
 	)
 	provider = NewGinRouterProvider(userAppService)
+	router.Use(provider.BearerAuth)
 	provider.Provide(router)
 
 	exitCode := m.Run()
@@ -297,6 +302,267 @@ func TestBasicAuth(t *testing.T) {
 	})
 }
 
+func TestV1UsersProfile(t *testing.T) {
+	username := "U" + uuidutil.NewUUID()[:8]
+	password := uuidutil.NewUUID() + uuidutil.NewUUID()
+
+	if res := postV1Users(signUpRequestBody{
+		Name:     username,
+		Password: password,
+		Email:    username + "@lmm.local",
+	}); !assert.Equal(t, http.StatusCreated, res.Code) {
+		t.Fatal("failed to create user: ", res.Body.String())
+	}
+
+	auth, err := provider.appService.BasicAuth(context.Background(), command.Login{
+		UserName: username,
+		Password: password,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		res := getV1UsersProfile(username)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		profile := profileView{}
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&profile))
+		assert.Equal(t, profileView{Name: username, DisplayName: username}, profile)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		res := getV1UsersProfile("U" + uuidutil.NewUUID()[:8])
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		res := putV1UsersProfile(username, "", changeProfileRequestBody{DisplayName: "writer"})
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		res := putV1UsersProfile("someone", auth.Token, changeProfileRequestBody{DisplayName: "writer"})
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("BadRequest", func(t *testing.T) {
+		res := putV1UsersProfile(username, auth.Token, changeProfileRequestBody{Bio: strings.Repeat("b", 501)})
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, domain.ErrBioTooLong.Error(), res.Body.String())
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		res := putV1UsersProfile(username, auth.Token, changeProfileRequestBody{
+			DisplayName:   "writer",
+			Bio:           "writes things",
+			AvatarAssetID: "avatar.png",
+		})
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		res = getV1UsersProfile(username)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		profile := profileView{}
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&profile))
+		assert.Equal(t, profileView{
+			Name:          username,
+			DisplayName:   "writer",
+			Bio:           "writes things",
+			AvatarAssetID: "avatar.png",
+		}, profile)
+	})
+}
+
+func TestPutV1UsersRole(t *testing.T) {
+	admin, adminToken := signUpWithRole(t, model.Admin)
+	ordinary, ordinaryToken := signUpWithRole(t, model.Ordinary)
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		res := putV1UsersRole(ordinary, "", assignRoleRequestBody{Role: "admin"})
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("NoPermission", func(t *testing.T) {
+		res := putV1UsersRole(admin, ordinaryToken, assignRoleRequestBody{Role: "ordinary"})
+		assert.Equal(t, http.StatusForbidden, res.Code)
+		assert.Equal(t, domain.ErrNoPermission.Error(), res.Body.String())
+	})
+
+	t.Run("SelfRole", func(t *testing.T) {
+		res := putV1UsersRole(admin, adminToken, assignRoleRequestBody{Role: "ordinary"})
+		assert.Equal(t, http.StatusForbidden, res.Code)
+		assert.Equal(t, domain.ErrCannotAssignSelfRole.Error(), res.Body.String())
+	})
+
+	t.Run("NoSuchRole", func(t *testing.T) {
+		res := putV1UsersRole(ordinary, adminToken, assignRoleRequestBody{Role: "superuser"})
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, domain.ErrNoSuchRole.Error(), res.Body.String())
+	})
+
+	t.Run("NoSuchUser", func(t *testing.T) {
+		res := putV1UsersRole("U"+uuidutil.NewUUID()[:8], adminToken, assignRoleRequestBody{Role: "admin"})
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		res := putV1UsersRole(ordinary, adminToken, assignRoleRequestBody{Role: "admin"})
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		auth, err := provider.appService.BearerAuth(context.Background(), ordinaryToken)
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", auth.Role)
+	})
+}
+
+func TestGetV1Users(t *testing.T) {
+	_, adminToken := signUpWithRole(t, model.Admin)
+	_, ordinaryToken := signUpWithRole(t, model.Ordinary)
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		res := getV1Users("", "")
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		res := getV1Users(ordinaryToken, "")
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("BadRequest", func(t *testing.T) {
+		cases := map[string]struct {
+			Query string
+			Err   error
+		}{
+			"InvalidPage":  {"page=0", domain.ErrInvalidPage},
+			"InvalidCount": {"count=101", domain.ErrInvalidCount},
+			"InvalidOrder": {"sort_by=password", domain.ErrInvalidViewOrder},
+			"InvalidSort":  {"sort=random", domain.ErrInvalidViewOrder},
+		}
+
+		for testName, testCase := range cases {
+			t.Run(testName, func(t *testing.T) {
+				res := getV1Users(adminToken, testCase.Query)
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+				assert.Equal(t, testCase.Err.Error(), res.Body.String())
+			})
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		res := getV1Users(adminToken, "page=01&count=2&sort_by=registered_date&sort=desc")
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		view := usersView{}
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&view))
+		assert.Equal(t, json.Number("1"), view.Page)
+		assert.Equal(t, json.Number("2"), view.Count)
+		assert.Equal(t, "registered_date", view.SortBy)
+		assert.Equal(t, "desc", view.Sort)
+		assert.True(t, view.Total >= 2)
+		if assert.Len(t, view.Users, 2) {
+			assert.True(t, view.Users[0].RegisteredDate >= view.Users[1].RegisteredDate)
+		}
+		assert.NotContains(t, res.Body.String(), "password")
+		assert.NotContains(t, res.Body.String(), "token")
+	})
+}
+
+func TestV1Sessions(t *testing.T) {
+	_, token := signUpWithRole(t, model.Ordinary)
+	_, otherToken := signUpWithRole(t, model.Ordinary)
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		res := requestV1Sessions("GET", "", "")
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	var current, other sessionView
+
+	t.Run("List", func(t *testing.T) {
+		res := requestV1Sessions("GET", "", otherToken)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		view := sessionsView{}
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&view))
+		if assert.Len(t, view.Sessions, 1) {
+			other = view.Sessions[0]
+			assert.True(t, other.Current)
+		}
+
+		res = requestV1Sessions("GET", "", token)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		view = sessionsView{}
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&view))
+		if assert.Len(t, view.Sessions, 1) {
+			current = view.Sessions[0]
+			assert.True(t, current.Current)
+		}
+	})
+
+	t.Run("RevokeOthersSession", func(t *testing.T) {
+		res := requestV1Sessions("DELETE", other.ID, token)
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, domain.ErrNoSuchSession.Error(), res.Body.String())
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		res := requestV1Sessions("DELETE", current.ID, token)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+
+		res = requestV1Sessions("GET", "", token)
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("RevokeAll", func(t *testing.T) {
+		res := requestV1Sessions("DELETE", "", otherToken)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+
+		res = requestV1Sessions("GET", "", otherToken)
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+}
+
+// signUpWithRole creates a user with the role, returns the name and an access token of the user
+func signUpWithRole(t *testing.T, role model.Role) (string, string) {
+	username := "U" + uuidutil.NewUUID()[:8]
+	password := uuidutil.NewUUID() + uuidutil.NewUUID()
+
+	if res := postV1Users(signUpRequestBody{
+		Name:     username,
+		Password: password,
+		Email:    username + "@lmm.local",
+	}); !assert.Equal(t, http.StatusCreated, res.Code) {
+		t.Fatal("failed to create user: ", res.Body.String())
+	}
+
+	err := userRepo.RunInTransaction(context.Background(), func(tx transaction.Transaction) error {
+		user, err := userRepo.FindByName(tx, username)
+		if err != nil {
+			return err
+		}
+		if err := user.ChangeRole(role); err != nil {
+			return err
+		}
+		return userRepo.Save(tx, user)
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := provider.appService.BasicAuth(context.Background(), command.Login{
+		UserName: username,
+		Password: password,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return username, auth.Token
+}
+
 func postV1Users(body signUpRequestBody) *httptest.ResponseRecorder {
 	b, err := json.Marshal(body)
 	if err != nil {
@@ -324,3 +590,75 @@ func putV1UsersPassword(username string, body changePasswordRequestBody) *httpte
 
 	return res
 }
+
+func getV1UsersProfile(username string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/v1/users/"+username+"/profile", nil)
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func putV1UsersProfile(username, accessToken string, body changeProfileRequestBody) *httptest.ResponseRecorder {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to decode to json"))
+	}
+
+	req := httptest.NewRequest("PUT", "/v1/users/"+username+"/profile", bytes.NewReader(b))
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func putV1UsersRole(username, accessToken string, body assignRoleRequestBody) *httptest.ResponseRecorder {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to decode to json"))
+	}
+
+	req := httptest.NewRequest("PUT", "/v1/users/"+username+"/role", bytes.NewReader(b))
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func getV1Users(accessToken, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/v1/users?"+query, nil)
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func requestV1Sessions(method, sessionID, accessToken string) *httptest.ResponseRecorder {
+	path := "/v1/sessions"
+	if sessionID != "" {
+		path += "/" + sessionID
+	}
+
+	req := httptest.NewRequest(method, path, nil)
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	res := httptest.NewRecorder()
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
@@ -22,10 +22,36 @@ type changePasswordRequestBody struct {
 	NewPassword string `json:"new_password"`
 }
 
+type changeProfileRequestBody struct {
+	DisplayName   string `json:"display_name"`
+	Bio           string `json:"bio"`
+	AvatarAssetID string `json:"avatar_asset_id"`
+}
+
+type profileView struct {
+	Name          string `json:"name"`
+	DisplayName   string `json:"display_name"`
+	Bio           string `json:"bio"`
+	AvatarAssetID string `json:"avatar_asset_id"`
+}
+
 type accessTokenView struct {
 	AccessToken string `json:"access_token"`
 }
 
+type sessionView struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	Current    bool   `json:"current"`
+}
+
+type sessionsView struct {
+	Sessions []sessionView `json:"sessions"`
+}
+
 type userView struct {
 	Name           string `json:"name"`
 	Role           string `json:"role"`
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"lmm/api/service/user/domain"
+	"lmm/api/service/user/domain/model"
+	"lmm/api/util/uuidutil"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+)
+
+const (
+	jwtAlgorithmHS256 = "HS256"
+	jwtAlgorithmEdDSA = "EdDSA"
+
+	hs256MinKeyLength = 32
+)
+
+// JWTKey signs and verifies access tokens, its id is sent as the kid header
+type JWTKey struct {
+	id         string
+	algorithm  string
+	secret     []byte
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+// NewJWTKey creates a HS256 key from a secret of at least 32 bytes,
+// or an EdDSA key from an ed25519 seed or private key
+func NewJWTKey(id, algorithm string, key []byte) (*JWTKey, error) {
+	if id == "" || strings.ContainsAny(id, ":,") {
+		return nil, errors.Errorf("invalid key id: '%s'", id)
+	}
+
+	switch algorithm {
+	case jwtAlgorithmHS256:
+		if len(key) < hs256MinKeyLength {
+			return nil, errors.Errorf("%s key %s should be equal to or longer than %d bytes", algorithm, id, hs256MinKeyLength)
+		}
+		return &JWTKey{id: id, algorithm: algorithm, secret: key}, nil
+	case jwtAlgorithmEdDSA:
+		var privateKey ed25519.PrivateKey
+		switch len(key) {
+		case ed25519.SeedSize:
+			privateKey = ed25519.NewKeyFromSeed(key)
+		case ed25519.PrivateKeySize:
+			privateKey = ed25519.PrivateKey(key)
+		default:
+			return nil, errors.Errorf("%s key %s should be a seed or a private key", algorithm, id)
+		}
+		return &JWTKey{
+			id:         id,
+			algorithm:  algorithm,
+			privateKey: privateKey,
+			publicKey:  privateKey.Public().(ed25519.PublicKey),
+		}, nil
+	default:
+		return nil, errors.Errorf("unsupported algorithm: '%s'", algorithm)
+	}
+}
+
+// NewJWTPublicKey creates an EdDSA key from an ed25519 public key, which only verifies tokens
+func NewJWTPublicKey(id, algorithm string, key []byte) (*JWTKey, error) {
+	if id == "" || strings.ContainsAny(id, ":,") {
+		return nil, errors.Errorf("invalid key id: '%s'", id)
+	}
+	if algorithm != jwtAlgorithmEdDSA {
+		return nil, errors.Errorf("%s key %s has no public key", algorithm, id)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.Errorf("%s key %s should be a public key", algorithm, id)
+	}
+	return &JWTKey{id: id, algorithm: algorithm, publicKey: ed25519.PublicKey(key)}, nil
+}
+
+// ParseJWTKeys parses a keyset like "2020-08:HS256:<base64 secret>,2020-01:EdDSA:<base64 public key>:public",
+// the first key signs new tokens and the others only verify tokens signed before rotation.
+// EdDSA keys marked public are given by their public key, which is enough to verify
+func ParseJWTKeys(s string) ([]*JWTKey, error) {
+	var keys []*JWTKey
+	for _, entry := range strings.Split(s, ",") {
+		params := strings.Split(strings.TrimSpace(entry), ":")
+		if len(params) != 3 && (len(params) != 4 || params[3] != "public") {
+			return nil, errors.Errorf("invalid key entry: '%s'", entry)
+		}
+
+		b, err := base64.StdEncoding.DecodeString(params[2])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key %s", params[0])
+		}
+
+		newKey := NewJWTKey
+		if len(params) == 4 {
+			newKey = NewJWTPublicKey
+		}
+
+		key, err := newKey(params[0], params[1], b)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (key *JWTKey) sign(b []byte) []byte {
+	if key.algorithm == jwtAlgorithmEdDSA {
+		return ed25519.Sign(key.privateKey, b)
+	}
+	mac := hmac.New(sha256.New, key.secret)
+	mac.Write(b)
+	return mac.Sum(nil)
+}
+
+func (key *JWTKey) verify(b, signature []byte) bool {
+	if key.algorithm == jwtAlgorithmEdDSA {
+		return ed25519.Verify(key.publicKey, b, signature)
+	}
+	return hmac.Equal(key.sign(b), signature)
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// jwtClaims uses the user id as the subject and binds the token to the session by sid,
+// changing password removes all sessions of the user, so that issued tokens are rejected.
+// The role is only informative, permissions are always checked by the role in storage
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	IssuedAt  int64  `json:"iat"`
+	ID        string `json:"jti"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid"`
+}
+
+// NewJWTTokenService returns a token service issuing JWTs signed by the first key,
+// tokens encrypted by legacyKey are still accepted unless legacyKey is empty
+func NewJWTTokenService(keys []*JWTKey, expire time.Duration, legacyKey string) (model.TokenService, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no key to sign tokens")
+	}
+	if keys[0].algorithm == jwtAlgorithmEdDSA && keys[0].privateKey == nil {
+		return nil, errors.Errorf("public key %s cannot sign tokens", keys[0].id)
+	}
+
+	keySet := make(map[string]*JWTKey, len(keys))
+	for _, key := range keys {
+		if _, ok := keySet[key.id]; ok {
+			return nil, errors.Errorf("duplicate key id: '%s'", key.id)
+		}
+		keySet[key.id] = key
+	}
+
+	var legacy model.TokenService
+	if legacyKey != "" {
+		legacy = NewCFBTokenService(legacyKey, expire)
+	}
+
+	return &jwtTokenService{
+		signingKey: keys[0],
+		keys:       keySet,
+		expireSec:  int64(expire.Seconds()),
+		legacy:     legacy,
+	}, nil
+}
+
+type jwtTokenService struct {
+	signingKey *JWTKey
+	keys       map[string]*JWTKey
+	expireSec  int64
+	legacy     model.TokenService
+}
+
+func (s *jwtTokenService) Encrypt(user *model.User, sessionID model.SessionID) (*model.AccessToken, error) {
+	now := time.Now().Unix()
+
+	header, err := json.Marshal(jwtHeader{
+		Algorithm: s.signingKey.algorithm,
+		Type:      "JWT",
+		KeyID:     s.signingKey.id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := json.Marshal(jwtClaims{
+		Subject:   strconv.FormatInt(int64(user.ID()), 10),
+		ExpiresAt: now + s.expireSec,
+		IssuedAt:  now,
+		ID:        uuidutil.NewUUID(),
+		Role:      user.Role().Name(),
+		SessionID: string(sessionID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	signature := base64.RawURLEncoding.EncodeToString(s.signingKey.sign([]byte(signingInput)))
+
+	hashed := fmt.Sprintf("%s.%s", signingInput, signature)
+
+	return model.NewAccessToken(user.ID(), "", sessionID, hashed, time.Unix(now+s.expireSec, 0)), nil
+}
+
+func (s *jwtTokenService) Decrypt(hashed string) (*model.AccessToken, error) {
+	parts := strings.Split(hashed, ".")
+	if len(parts) != 3 {
+		// tokens issued by cfbTokenService never contain dots
+		if s.legacy != nil {
+			return s.legacy.Decrypt(hashed)
+		}
+		return nil, domain.ErrInvalidTokenFormat
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	key, ok := s.keys[header.KeyID]
+	if !ok {
+		return nil, errors.Wrap(domain.ErrUnknownTokenKey, header.KeyID)
+	}
+
+	// the algorithm is decided by the key, never by the header
+	if header.Algorithm != key.algorithm {
+		return nil, errors.Wrap(domain.ErrInvalidTokenSignature, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(domain.ErrInvalidTokenFormat, err.Error())
+	}
+	if !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return nil, domain.ErrInvalidTokenSignature
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return nil, errors.Wrapf(domain.ErrInvalidTokenFormat, "invalid subject: '%s'", claims.Subject)
+	}
+
+	return model.NewAccessToken(model.UserID(userID), "", model.SessionID(claims.SessionID), hashed, time.Unix(claims.ExpiresAt, 0)), nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.Wrap(domain.ErrInvalidTokenFormat, err.Error())
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Wrap(domain.ErrInvalidTokenFormat, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"lmm/api/service/user/domain"
+	"lmm/api/service/user/domain/model"
+	"lmm/api/util/uuidutil"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestParseJWTKeys(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("s", 32)))
+	seed := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("e", 32)))
+	publicKey := base64.StdEncoding.EncodeToString(ed25519.NewKeyFromSeed([]byte(strings.Repeat("p", 32))).Public().(ed25519.PublicKey))
+
+	t.Run("Success", func(t *testing.T) {
+		keys, err := ParseJWTKeys("new:EdDSA:" + seed + ", old:HS256:" + secret + ", older:EdDSA:" + publicKey + ":public")
+		assert.NoError(t, err)
+		if assert.Len(t, keys, 3) {
+			assert.Equal(t, "new", keys[0].id)
+			assert.Equal(t, "EdDSA", keys[0].algorithm)
+			assert.Equal(t, "old", keys[1].id)
+			assert.Equal(t, "HS256", keys[1].algorithm)
+			assert.Equal(t, "older", keys[2].id)
+			assert.Nil(t, keys[2].privateKey)
+		}
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		cases := map[string]string{
+			"Empty":          "",
+			"NoAlgorithm":    "key:" + secret,
+			"NoKeyID":        ":HS256:" + secret,
+			"UnknownAlg":     "key:RS256:" + secret,
+			"NotBase64":      "key:HS256:!!!",
+			"ShortSecret":    "key:HS256:" + base64.StdEncoding.EncodeToString([]byte("short")),
+			"InvalidEd25519": "key:EdDSA:" + secret + secret,
+			"PublicHS256":    "key:HS256:" + secret + ":public",
+			"UnknownMarker":  "key:EdDSA:" + publicKey + ":private",
+		}
+
+		for testName, keys := range cases {
+			t.Run(testName, func(t *testing.T) {
+				_, err := ParseJWTKeys(keys)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestJWTTokenService(t *testing.T) {
+	hs256, err := NewJWTKey("hs256", "HS256", []byte(uuidutil.NewUUID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	eddsa, err := NewJWTKey("eddsa", "EdDSA", []byte(uuidutil.NewUUID()[:32]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := newUser(t)
+	sessionID := model.SessionID(uuidutil.NewUUID())
+
+	for _, key := range []*JWTKey{hs256, eddsa} {
+		t.Run(key.algorithm, func(t *testing.T) {
+			jwt, err := NewJWTTokenService([]*JWTKey{key}, time.Minute, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			accessToken, err := jwt.Encrypt(user, sessionID)
+			assert.NoError(t, err)
+			assert.Equal(t, user.ID(), accessToken.UserID())
+			assert.Empty(t, accessToken.Raw())
+			assert.Equal(t, sessionID, accessToken.SessionID())
+			assert.False(t, accessToken.Expired())
+
+			parts := strings.Split(accessToken.Hashed(), ".")
+			if !assert.Len(t, parts, 3) {
+				t.FailNow()
+			}
+
+			header := jwtHeader{}
+			assert.NoError(t, decodeJWTSegment(parts[0], &header))
+			assert.Equal(t, jwtHeader{Algorithm: key.algorithm, Type: "JWT", KeyID: key.id}, header)
+
+			claims := jwtClaims{}
+			assert.NoError(t, decodeJWTSegment(parts[1], &claims))
+			assert.Equal(t, strconv.FormatInt(int64(user.ID()), 10), claims.Subject)
+			assert.Equal(t, string(sessionID), claims.SessionID)
+			assert.NotEqual(t, string(sessionID), claims.ID)
+			assert.Equal(t, "ordinary", claims.Role)
+			assert.Equal(t, int64(60), claims.ExpiresAt-claims.IssuedAt)
+
+			other, err := jwt.Encrypt(user, sessionID)
+			assert.NoError(t, err)
+			otherClaims := jwtClaims{}
+			assert.NoError(t, decodeJWTSegment(strings.Split(other.Hashed(), ".")[1], &otherClaims))
+			assert.NotEqual(t, claims.ID, otherClaims.ID)
+
+			sameAccessToken, err := jwt.Decrypt(accessToken.Hashed())
+			assert.NoError(t, err)
+			assert.Equal(t, accessToken, sameAccessToken)
+
+			t.Run("Tampered", func(t *testing.T) {
+				claims.Subject = "2"
+				b, _ := json.Marshal(claims)
+				tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString(b) + "." + parts[2]
+
+				_, err := jwt.Decrypt(tampered)
+				assert.Equal(t, domain.ErrInvalidTokenSignature, errors.Cause(err))
+			})
+		})
+	}
+
+	t.Run("Rotation", func(t *testing.T) {
+		old, err := NewJWTTokenService([]*JWTKey{hs256}, time.Minute, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rotated, err := NewJWTTokenService([]*JWTKey{eddsa, hs256}, time.Minute, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		retired, err := NewJWTTokenService([]*JWTKey{eddsa}, time.Minute, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		accessToken, err := old.Encrypt(user, sessionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = rotated.Decrypt(accessToken.Hashed())
+		assert.NoError(t, err)
+
+		_, err = retired.Decrypt(accessToken.Hashed())
+		assert.Equal(t, domain.ErrUnknownTokenKey, errors.Cause(err))
+
+		t.Run("PublicKey", func(t *testing.T) {
+			public, err := NewJWTPublicKey(eddsa.id, "EdDSA", eddsa.publicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = NewJWTTokenService([]*JWTKey{public}, time.Minute, "")
+			assert.Error(t, err)
+
+			verifying, err := NewJWTTokenService([]*JWTKey{hs256, public}, time.Minute, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			accessToken, err := retired.Encrypt(user, sessionID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = verifying.Decrypt(accessToken.Hashed())
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("AlgorithmMismatch", func(t *testing.T) {
+		forged, err := NewJWTKey(eddsa.id, "HS256", []byte(uuidutil.NewUUID()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		forger, err := NewJWTTokenService([]*JWTKey{forged}, time.Minute, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		jwt, err := NewJWTTokenService([]*JWTKey{eddsa}, time.Minute, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		accessToken, err := forger.Encrypt(user, sessionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = jwt.Decrypt(accessToken.Hashed())
+		assert.Equal(t, domain.ErrInvalidTokenSignature, errors.Cause(err))
+	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		legacyKey := uuidutil.NewUUID()[:32]
+
+		legacyAccessToken, err := NewCFBTokenService(legacyKey, time.Minute).Encrypt(user, sessionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		migrating, err := NewJWTTokenService([]*JWTKey{hs256}, time.Minute, legacyKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		accessToken, err := migrating.Decrypt(legacyAccessToken.Hashed())
+		assert.NoError(t, err)
+		assert.Equal(t, legacyAccessToken, accessToken)
+
+		migrated, err := NewJWTTokenService([]*JWTKey{hs256}, time.Minute, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = migrated.Decrypt(legacyAccessToken.Hashed())
+		assert.Equal(t, domain.ErrInvalidTokenFormat, errors.Cause(err))
+	})
+
+	t.Run("DuplicateKeyID", func(t *testing.T) {
+		_, err := NewJWTTokenService([]*JWTKey{hs256, hs256}, time.Minute, "")
+		assert.Error(t, err)
+	})
+}
@@ -30,10 +30,11 @@ type cfbTokenService struct {
 	expireSec int64
 }
 
-func (s *cfbTokenService) Encrypt(rawToken string) (*model.AccessToken, error) {
+func (s *cfbTokenService) Encrypt(user *model.User, sessionID model.SessionID) (*model.AccessToken, error) {
+	rawToken := user.Token()
 	expire := time.Now().Unix() + s.expireSec
 
-	token := fmt.Sprintf("%d:%s", expire, rawToken)
+	token := fmt.Sprintf("%d:%s:%s", expire, rawToken, sessionID)
 	b := []byte(token)
 
 	block, err := aes.NewCipher(s.secretKey)
@@ -52,7 +53,7 @@ func (s *cfbTokenService) Encrypt(rawToken string) (*model.AccessToken, error) {
 
 	hashed := base64.URLEncoding.EncodeToString(encoded)
 
-	return model.NewAccessToken(rawToken, hashed, time.Unix(expire, 0)), nil
+	return model.NewAccessToken(0, rawToken, sessionID, hashed, time.Unix(expire, 0)), nil
 }
 
 func (s *cfbTokenService) Decrypt(hashed string) (*model.AccessToken, error) {
@@ -76,8 +77,9 @@ func (s *cfbTokenService) Decrypt(hashed string) (*model.AccessToken, error) {
 	stream := cipher.NewCFBDecrypter(block, iv)
 	stream.XORKeyStream(dst, src)
 
+	// tokens issued before sessions are "expire:token" bound to no session
 	params := strings.Split(string(dst), ":")
-	if len(params) != 2 {
+	if len(params) != 2 && len(params) != 3 {
 		return nil, domain.ErrInvalidTokenFormat
 	}
 
@@ -86,5 +88,10 @@ func (s *cfbTokenService) Decrypt(hashed string) (*model.AccessToken, error) {
 		return nil, errors.Wrap(domain.ErrInvalidTokenFormat, err.Error())
 	}
 
-	return model.NewAccessToken(params[1], hashed, time.Unix(expire, 0)), nil
+	var sessionID model.SessionID
+	if len(params) == 3 {
+		sessionID = model.SessionID(params[2])
+	}
+
+	return model.NewAccessToken(0, params[1], sessionID, hashed, time.Unix(expire, 0)), nil
 }
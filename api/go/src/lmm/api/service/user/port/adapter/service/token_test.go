@@ -1,23 +1,33 @@
 package service
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
 	"testing"
 	"time"
 
+	"lmm/api/service/user/domain/model"
 	"lmm/api/util/uuidutil"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCFBTokenService(t *testing.T) {
-	cfb := NewCFBTokenService(uuidutil.NewUUID(), time.Minute)
+	secretKey := uuidutil.NewUUID()
+	cfb := NewCFBTokenService(secretKey, time.Minute)
 
-	token := uuidutil.NewUUID()
+	user := newUser(t)
+	sessionID := model.SessionID(uuidutil.NewUUID())
 
 	t.Run("Encrypt", func(t *testing.T) {
-		accessToken, err := cfb.Encrypt(token)
+		accessToken, err := cfb.Encrypt(user, sessionID)
 		assert.NoError(t, err)
-		assert.Equal(t, token, accessToken.Raw())
+		assert.Equal(t, user.Token(), accessToken.Raw())
+		assert.Equal(t, sessionID, accessToken.SessionID())
 		assert.False(t, accessToken.Expired())
 
 		t.Run("Decrypt", func(t *testing.T) {
@@ -26,4 +36,35 @@ func TestCFBTokenService(t *testing.T) {
 			assert.Equal(t, accessToken, sameAccessToken)
 		})
 	})
+
+	t.Run("WithoutSession", func(t *testing.T) {
+		// tokens issued before sessions
+		expire := time.Now().Unix() + 60
+		plain := []byte(fmt.Sprintf("%d:%s", expire, user.Token()))
+
+		block, err := aes.NewCipher([]byte(secretKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded := make([]byte, aes.BlockSize+len(plain))
+		if _, err := io.ReadFull(rand.Reader, encoded[:aes.BlockSize]); err != nil {
+			t.Fatal(err)
+		}
+		cipher.NewCFBEncrypter(block, encoded[:aes.BlockSize]).XORKeyStream(encoded[aes.BlockSize:], plain)
+
+		accessToken, err := cfb.Decrypt(base64.URLEncoding.EncodeToString(encoded))
+		if assert.NoError(t, err) {
+			assert.Equal(t, user.Token(), accessToken.Raw())
+			assert.Empty(t, accessToken.SessionID())
+			assert.Equal(t, time.Unix(expire, 0), accessToken.Expire())
+		}
+	})
+}
+
+func newUser(t *testing.T) *model.User {
+	user, err := model.NewUser(model.UserID(1), "username", "username@lmm.local", "password", uuidutil.NewUUID(), model.Ordinary, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
 }
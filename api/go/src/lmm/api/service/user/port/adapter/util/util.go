@@ -16,6 +16,9 @@ type Auth struct {
 	Name  string
 	Token string
 	Role  string
+
+	// SessionID is the session which the request is authorized by
+	SessionID string
 }
 
 func NewContext(c context.Context, auth *Auth) context.Context {
@@ -34,3 +37,8 @@ func FromContext(c context.Context) (*Auth, bool) {
 func (auth *Auth) IsAdmin() bool {
 	return auth.Role == model.Admin.Name()
 }
+
+// HasPermission returns true if auth's role has the permission
+func (auth *Auth) HasPermission(permission model.Permission) bool {
+	return model.RoleFromString(auth.Role).HasPermission(permission)
+}
@@ -8,5 +8,6 @@ python /gae/render_app_yaml.py --input ./template.yaml --output ./app.yaml \
 	--GOOGLE_APPLICATION_CREDENTIALS ${GOOGLE_APPLICATION_CREDENTIALS} \
 	--GO_VERSION ${GO_VERSION} \
 	--LMM_API_TOKEN_KEY ${LMM_API_TOKEN_KEY} \
+	--LMM_API_TOKEN_KEYS ${LMM_API_TOKEN_KEYS} \
 	--LMM_DOMAIN ${LMM_DOMAIN} \
 	--PUBSUB_PROJECT_ID ${PUBSUB_PROJECT_ID}